@@ -0,0 +1,32 @@
+package main
+
+import "context"
+
+// accountCommands represents commands of the account group.
+var accountCommands = map[string]*command{
+	"get": {
+		summary: "show details of the account",
+		run:     getAccount,
+	},
+}
+
+// getAccount runs "account get".
+func getAccount(ctx context.Context, a *app, args []string) error {
+	if _, err := a.parse(a.flagSet("account get"), args, 0, 0); err != nil {
+		return err
+	}
+
+	client, err := a.commonClient()
+	if err != nil {
+		return err
+	}
+
+	account, _, err := client.Account.Details(ctx)
+	if err != nil {
+		return err
+	}
+
+	return a.print(account, []string{"ID", "CNAME", "USERS"}, func() [][]string {
+		return [][]string{{itoa(account.ID), account.Cname, itoa(len(account.Users))}}
+	})
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetAccount(t *testing.T) {
+	server := newTestServer(t)
+
+	code, stdout, stderr := runTest(t, server, "account", "get")
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+	if !strings.HasPrefix(stdout, "ID") || !strings.Contains(stdout, "\n1 ") {
+		t.Errorf("unexpected output: %q", stdout)
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// certCommands represents commands of the certs group.
+var certCommands = map[string]*command{
+	"list": {
+		summary: "list SSL certificates",
+		run:     listCerts,
+	},
+	"get": {
+		usage:   "CERT_ID",
+		summary: "show the SSL certificate",
+		run:     getCert,
+	},
+	"add": {
+		usage:   "--name NAME --cert-file PATH --key-file PATH",
+		summary: "add an SSL certificate",
+		run:     addCert,
+	},
+	"delete": {
+		usage:   "CERT_ID",
+		summary: "delete the SSL certificate",
+		run:     deleteCert,
+	},
+}
+
+// printCerts prints the value with the table of the certificates.
+func (a *app) printCerts(v interface{}, certs ...*gcore.CertSSL) error {
+	return a.print(v, []string{"ID", "NAME", "SUBJECT", "ISSUER", "NOT_AFTER", "IN_USE"}, func() [][]string {
+		rows := make([][]string, 0, len(certs))
+		for _, cert := range certs {
+			notAfter := ""
+			if cert.ValidityNotAfter != nil {
+				notAfter = cert.ValidityNotAfter.Format("2006-01-02")
+			}
+			rows = append(rows, []string{
+				itoa(cert.ID),
+				cert.Name,
+				cert.CertSubjectCn,
+				cert.CertIssuer,
+				notAfter,
+				yesNo(cert.HasRelatedResources),
+			})
+		}
+		return rows
+	})
+}
+
+// listCerts runs "certs list".
+func listCerts(ctx context.Context, a *app, args []string) error {
+	var opts gcore.ListCertsOpts
+
+	fs := a.flagSet("certs list")
+	fs.StringVar(&opts.Name, "name", "", "name of the certificates")
+	listFlags(fs, &opts.Ordering, &opts.Limit, &opts.Offset)
+	if _, err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	client, err := a.commonClient()
+	if err != nil {
+		return err
+	}
+
+	certs, _, err := client.Certificates.ListWithOpts(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	return a.printCerts(certs, certs...)
+}
+
+// getCert runs "certs get".
+func getCert(ctx context.Context, a *app, args []string) error {
+	client, id, err := a.certArgs("certs get", args)
+	if err != nil {
+		return err
+	}
+
+	cert, _, err := client.Certificates.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.printCerts(cert, cert)
+}
+
+// addCert runs "certs add".
+func addCert(ctx context.Context, a *app, args []string) error {
+	var name, certFile, keyFile string
+
+	fs := a.flagSet("certs add")
+	fs.StringVar(&name, "name", "", "name of the certificate")
+	fs.StringVar(&certFile, "cert-file", "", "PEM file with the certificate chain")
+	fs.StringVar(&keyFile, "key-file", "", "PEM file with the private key")
+	if _, err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	if name == "" || certFile == "" || keyFile == "" {
+		return fmt.Errorf("%w: --name, --cert-file and --key-file are required", errUsage)
+	}
+
+	certificate, err := os.ReadFile(certFile)
+	if err != nil {
+		return err
+	}
+
+	privateKey, err := os.ReadFile(keyFile)
+	if err != nil {
+		return err
+	}
+
+	client, err := a.commonClient()
+	if err != nil {
+		return err
+	}
+
+	cert, _, err := client.Certificates.Add(ctx, &gcore.AddCertBody{
+		Name:        name,
+		Certificate: string(certificate),
+		PrivateKey:  string(privateKey),
+	})
+	if err != nil {
+		return err
+	}
+
+	return a.printCerts(cert, cert)
+}
+
+// deleteCert runs "certs delete".
+func deleteCert(ctx context.Context, a *app, args []string) error {
+	client, id, err := a.certArgs("certs delete", args)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.Certificates.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	return a.printDone("Certificate %d has been deleted.", id)
+}
+
+// certArgs parses arguments of the commands that accept only ID of
+// the certificate and returns the client.
+func (a *app) certArgs(name string, args []string) (*gcore.CommonClient, int, error) {
+	rest, err := a.parse(a.flagSet(name), args, 1, 1)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	id, err := parseID(rest[0], "CERT_ID")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	client, err := a.commonClient()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return client, id, nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeTestCertificate writes self-signed certificate and its private key in
+// PEM format and returns paths to the files.
+func writeTestCertificate(t *testing.T, cn string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     []string{cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
+func TestCerts(t *testing.T) {
+	server := newTestServer(t)
+	certFile, keyFile := writeTestCertificate(t, "cdn.example.com")
+
+	code, stdout, stderr := runTest(t, server, "certs", "add", "--name", "cdn", "--cert-file", certFile, "--key-file", keyFile)
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "cdn.example.com") {
+		t.Errorf("expected subject of the certificate, got %q", stdout)
+	}
+
+	certs, _, err := mustCommonClient(t, server).Certificates.List(t.Context())
+	if err != nil || len(certs) != 1 {
+		t.Fatalf("expected one certificate, got %v: %v", certs, err)
+	}
+	id := itoa(certs[0].ID)
+
+	code, stdout, _ = runTest(t, server, "certs", "list")
+	if code != exitOK || !strings.Contains(stdout, "cdn") {
+		t.Errorf("expected the certificate in the list, got %d: %q", code, stdout)
+	}
+
+	code, _, _ = runTest(t, server, "certs", "get", id)
+	if code != exitOK {
+		t.Errorf("unexpected exit code %d", code)
+	}
+
+	code, _, _ = runTest(t, server, "certs", "delete", id)
+	if code != exitOK {
+		t.Errorf("unexpected exit code %d", code)
+	}
+	if _, ok := server.Certificate(certs[0].ID); ok {
+		t.Error("expected the certificate to be deleted")
+	}
+}
+
+func TestCerts_DryRunRedactsKey(t *testing.T) {
+	server := newTestServer(t)
+	certFile, keyFile := writeTestCertificate(t, "cdn.example.com")
+
+	code, stdout, stderr := runTest(t, server, "certs", "add", "--dry-run",
+		"--name", "cdn", "--cert-file", certFile, "--key-file", keyFile)
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+	if strings.Contains(stdout, "PRIVATE KEY") {
+		t.Errorf("expected the private key to be redacted, got %q", stdout)
+	}
+}
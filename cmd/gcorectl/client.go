@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// signInPath represents path of the sign in request that's sent in the dry
+// run mode as well.
+const signInPath = "/auth/signin"
+
+// secretFields represents fields of the request bodies that aren't printed in
+// the dry run mode.
+var secretFields = []string{"password", "sslPrivateKey"}
+
+// errDryRun is returned by the clients for mutating requests in the dry run
+// mode.
+var errDryRun = errors.New("dry run")
+
+// dryRunRequest represents mutating request intercepted in the dry run mode.
+type dryRunRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Body   interface{} `json:"body,omitempty"`
+}
+
+// options returns options of the clients.
+func (a *app) options() []gcore.Option {
+	var credentials gcore.CredentialsProvider = gcore.NewDefaultCredentials()
+	if a.profile != "" || a.credentialsFile != "" {
+		// Explicitly selected profile takes precedence over the environment.
+		credentials = gcore.NewFileCredentials(a.credentialsFile, a.profile)
+	}
+
+	opts := []gcore.Option{
+		gcore.WithCredentials(credentials),
+		gcore.WithTimeout(a.timeout),
+		gcore.WithUserAgent("gcorectl"),
+		gcore.WithMiddleware(a.dryRunMiddleware),
+	}
+	if a.apiURL != "" {
+		opts = append(opts, gcore.WithBaseURL(a.apiURL))
+	}
+
+	return append(opts, a.clientOptions...)
+}
+
+// commonClient returns client of the common account.
+func (a *app) commonClient() (*gcore.CommonClient, error) {
+	return gcore.NewCommon(a.options()...)
+}
+
+// resellerClient returns client of the reseller account.
+func (a *app) resellerClient() (*gcore.ResellerClient, error) {
+	return gcore.NewReseller(a.options()...)
+}
+
+// dryRunMiddleware intercepts mutating requests in the dry run mode, so
+// they are printed instead of being sent. Read-only requests are sent, so
+// the commands that update objects by their current state build the same
+// requests as without the dry run.
+func (a *app) dryRunMiddleware(next gcore.RoundTripFunc) gcore.RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		if !a.dryRun || req.Method == http.MethodGet || strings.HasSuffix(req.URL.Path, signInPath) {
+			return next(req)
+		}
+
+		intercepted := &dryRunRequest{Method: req.Method, URL: req.URL.String()}
+		if req.Body != nil {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(body) > 0 {
+				var v interface{}
+				if err = json.Unmarshal(body, &v); err != nil {
+					v = string(body)
+				}
+				if fields, ok := v.(map[string]interface{}); ok {
+					for _, name := range secretFields {
+						if _, ok := fields[name]; ok {
+							fields[name] = "REDACTED"
+						}
+					}
+				}
+				intercepted.Body = v
+			}
+		}
+		a.mu.Lock()
+		a.dryRunRequests = append(a.dryRunRequests, intercepted)
+		a.mu.Unlock()
+
+		return nil, errDryRun
+	}
+}
+
+// interceptedRequests returns requests intercepted in the dry run mode
+// in order.
+func (a *app) interceptedRequests() []*dryRunRequest {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]*dryRunRequest(nil), a.dryRunRequests...)
+}
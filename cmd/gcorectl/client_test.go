@@ -0,0 +1,95 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+func TestDryRun(t *testing.T) {
+	server := newTestServer(t)
+	resource := server.AddResource(&gcore.Resource{Cname: "cdn.example.com", OriginGroup: 1})
+
+	code, stdout, stderr := runTest(t, server, "--timeout", "5s", "resources", "suspend", itoa(resource.ID), "--dry-run")
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "PUT") || !strings.Contains(stdout, "/resources/"+itoa(resource.ID)) {
+		t.Errorf("expected the intercepted request in output, got %q", stdout)
+	}
+	if n := mutatingRequests(server); n != 0 {
+		t.Errorf("expected no mutating requests, got %d", n)
+	}
+
+	stored, _ := server.Resource(resource.ID)
+	if stored.Status == "suspended" {
+		t.Error("expected the resource not to be suspended")
+	}
+}
+
+func TestDryRun_RedactsSecrets(t *testing.T) {
+	server := newTestServer(t)
+
+	code, stdout, stderr := runTest(t, server, "clients", "create", "--dry-run", "-o", "json",
+		"--email", "new@example.com", "--password", "secret")
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+	if strings.Contains(stdout, "secret") || !strings.Contains(stdout, `"password": "REDACTED"`) {
+		t.Errorf("expected the password to be redacted, got %q", stdout)
+	}
+}
+
+func TestDryRunMiddleware_Concurrent(t *testing.T) {
+	a := &app{dryRun: true}
+	roundTrip := a.dryRunMiddleware(func(req *http.Request) (*http.Response, error) {
+		t.Error("intercepted request has been sent")
+		return nil, nil
+	})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodPost, "/resources/1/purge", strings.NewReader(`{}`))
+			if _, err := roundTrip(req); err != errDryRun {
+				t.Errorf("Expected: %v, got %v", errDryRun, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := len(a.interceptedRequests()); n != 10 {
+		t.Errorf("Expected: %d intercepted requests, got %d", 10, n)
+	}
+}
+
+func TestCredentialsFile(t *testing.T) {
+	server := newTestServer(t)
+
+	path := filepath.Join(t.TempDir(), "credentials.yaml")
+	data := "profiles:\n  test:\n    username: " + "user@example.com" + "\n    password: password\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr strings.Builder
+	code := run(t.Context(), []string{"--api-url", server.URL(), "--credentials-file", path, "--profile", "test", "account", "get"},
+		nil, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+	}
+
+	code = run(t.Context(), []string{"--api-url", server.URL(), "--credentials-file", path, "--profile", "missing", "account", "get"},
+		nil, &stdout, &stderr)
+	if code != exitError {
+		t.Errorf("expected exit code %d for missing profile, got %d", exitError, code)
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// clientCommands represents commands of the clients group.
+var clientCommands = map[string]*command{
+	"list": {
+		summary: "list clients of the reseller",
+		run:     listClients,
+	},
+	"get": {
+		usage:   "CLIENT_ID",
+		summary: "show the client",
+		run:     getClient,
+	},
+	"create": {
+		usage:   "--email EMAIL --password PASSWORD",
+		summary: "create a client with a user",
+		run:     createClient,
+	},
+	"update": {
+		usage:   "CLIENT_ID",
+		summary: "update the client keeping the fields that aren't set",
+		run:     updateClient,
+	},
+}
+
+// printClients prints the value with the table of the clients.
+func (a *app) printClients(v interface{}, clients ...*gcore.ClientAccount) error {
+	return a.print(v, []string{"ID", "NAME", "COMPANY", "EMAIL", "STATUS", "USERS"}, func() [][]string {
+		rows := make([][]string, 0, len(clients))
+		for _, client := range clients {
+			emails := make([]string, 0, len(client.Users))
+			for _, user := range client.Users {
+				emails = append(emails, user.Email)
+			}
+			rows = append(rows, []string{
+				itoa(client.ID),
+				client.Name,
+				client.CompanyName,
+				client.Email,
+				client.Status,
+				strings.Join(emails, ","),
+			})
+		}
+		return rows
+	})
+}
+
+// listClients runs "clients list".
+func listClients(ctx context.Context, a *app, args []string) error {
+	var opts gcore.ListOpts
+
+	fs := a.flagSet("clients list")
+	fs.StringVar(&opts.Email, "email", "", "email of the clients")
+	fs.StringVar(&opts.Name, "name", "", "name of the clients")
+	fs.StringVar(&opts.CompanyName, "company", "", "company name of the clients")
+	fs.BoolVar(&opts.Deleted, "deleted", false, "list deleted clients")
+	fs.BoolVar(&opts.Activated, "activated", false, "list activated clients only")
+	listFlags(fs, &opts.Ordering, &opts.Limit, &opts.Offset)
+	if _, err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	client, err := a.resellerClient()
+	if err != nil {
+		return err
+	}
+
+	clients, _, err := client.Clients.List(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	return a.printClients(clients, clients...)
+}
+
+// getClient runs "clients get".
+func getClient(ctx context.Context, a *app, args []string) error {
+	rest, err := a.parse(a.flagSet("clients get"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseID(rest[0], "CLIENT_ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := a.resellerClient()
+	if err != nil {
+		return err
+	}
+
+	account, _, err := client.Clients.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.printClients(account, account)
+}
+
+// createClient runs "clients create".
+func createClient(ctx context.Context, a *app, args []string) error {
+	body := gcore.CreateClientBody{UserType: "common"}
+
+	fs := a.flagSet("clients create")
+	fs.StringVar(&body.Email, "email", "", "email of the user")
+	fs.StringVar(&body.Password, "password", "", "password of the user")
+	fs.StringVar(&body.Name, "name", "", "name of the user")
+	fs.StringVar(&body.Company, "company", "", "company name of the client")
+	fs.StringVar(&body.Phone, "phone", "", "phone of the user")
+	fs.StringVar(&body.UserType, "user-type", body.UserType, "type of the user")
+	if _, err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	if body.Email == "" || body.Password == "" {
+		return fmt.Errorf("%w: --email and --password are required", errUsage)
+	}
+
+	client, err := a.resellerClient()
+	if err != nil {
+		return err
+	}
+
+	account, _, err := client.Clients.Create(ctx, &body)
+	if err != nil {
+		return err
+	}
+
+	return a.printClients(account, account)
+}
+
+// updateClient runs "clients update".
+func updateClient(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("clients update")
+	name := fs.String("name", "", "name of the client")
+	company := fs.String("company", "", "company name of the client")
+	phone := fs.String("phone", "", "phone of the client")
+	email := fs.String("email", "", "email of the client")
+
+	rest, err := a.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseID(rest[0], "CLIENT_ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := a.resellerClient()
+	if err != nil {
+		return err
+	}
+
+	account, _, err := client.Clients.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	body := account.UpdateBody()
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "name":
+			body.Name = *name
+		case "company":
+			body.CompanyName = *company
+		case "phone":
+			body.Phone = *phone
+		case "email":
+			body.Email = *email
+		}
+	})
+
+	account, _, err = client.Clients.Update(ctx, id, body)
+	if err != nil {
+		return err
+	}
+
+	return a.printClients(account, account)
+}
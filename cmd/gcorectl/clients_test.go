@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+func TestClients(t *testing.T) {
+	server := newTestServer(t)
+
+	code, stdout, stderr := runTest(t, server, "clients", "create", "-o", "json",
+		"--email", "new@example.com", "--password", "secret", "--company", "Example")
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+
+	var client gcore.ClientAccount
+	if err := json.Unmarshal([]byte(stdout), &client); err != nil {
+		t.Fatal(err)
+	}
+	id := itoa(client.ID)
+
+	code, stdout, _ = runTest(t, server, "clients", "list", "--email", "new@example.com")
+	if code != exitOK || !strings.Contains(stdout, "new@example.com") {
+		t.Errorf("expected the client in the list, got %d: %q", code, stdout)
+	}
+
+	code, _, _ = runTest(t, server, "clients", "update", id, "--name", "Renamed")
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+	stored, _ := server.Client(client.ID)
+	if stored.Name != "Renamed" || stored.CompanyName != "Example" {
+		t.Errorf("expected only the name to change, got %+v", stored)
+	}
+
+	code, stdout, _ = runTest(t, server, "clients", "get", id)
+	if code != exitOK || !strings.Contains(stdout, "Renamed") {
+		t.Errorf("unexpected output, got %d: %q", code, stdout)
+	}
+}
+
+func TestClients_CreateUsage(t *testing.T) {
+	server := newTestServer(t)
+
+	code, _, stderr := runTest(t, server, "clients", "create", "--email", "new@example.com")
+	if code != exitUsage || !strings.Contains(stderr, "--password") {
+		t.Errorf("expected usage error, got %d: %q", code, stderr)
+	}
+}
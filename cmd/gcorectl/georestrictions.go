@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// geoRestrictionCommands represents commands of the geo-restrictions group.
+var geoRestrictionCommands = map[string]*command{
+	"regions": {
+		summary: "list billing regions",
+		run:     listRegions,
+	},
+	"get": {
+		usage:   "CLIENT_ID",
+		summary: "show geo restrictions of the client",
+		run:     getRestrictions,
+	},
+	"set": {
+		usage:   "CLIENT_ID --regions ID,... [--in=true|false]",
+		summary: "set geo restrictions of the client",
+		run:     setRestrictions,
+	},
+}
+
+// listRegions runs "geo-restrictions regions".
+func listRegions(ctx context.Context, a *app, args []string) error {
+	if _, err := a.parse(a.flagSet("geo-restrictions regions"), args, 0, 0); err != nil {
+		return err
+	}
+
+	client, err := a.resellerClient()
+	if err != nil {
+		return err
+	}
+
+	regions, _, err := client.GeoRestrictions.ListRegions(ctx)
+	if err != nil {
+		return err
+	}
+
+	return a.print(regions, []string{"ID", "NAME", "DESCRIPTION", "REQUIRED"}, func() [][]string {
+		rows := make([][]string, 0, len(regions))
+		for _, region := range regions {
+			rows = append(rows, []string{itoa(region.ID), region.Name, region.Description, yesNo(region.Required)})
+		}
+		return rows
+	})
+}
+
+// printRestrictions prints the geo restrictions.
+func (a *app) printRestrictions(restrictions *gcore.GeoRestrictions) error {
+	return a.print(restrictions, []string{"IS_IN", "REGIONS"}, func() [][]string {
+		regions := make([]string, 0, len(restrictions.RegionList))
+		for _, id := range restrictions.RegionList {
+			regions = append(regions, itoa(id))
+		}
+		return [][]string{{yesNo(restrictions.IsIn), strings.Join(regions, ",")}}
+	})
+}
+
+// getRestrictions runs "geo-restrictions get".
+func getRestrictions(ctx context.Context, a *app, args []string) error {
+	rest, err := a.parse(a.flagSet("geo-restrictions get"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	clientID, err := parseID(rest[0], "CLIENT_ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := a.resellerClient()
+	if err != nil {
+		return err
+	}
+
+	restrictions, _, err := client.GeoRestrictions.GetRestrictions(ctx, clientID)
+	if err != nil {
+		return err
+	}
+
+	return a.printRestrictions(restrictions)
+}
+
+// setRestrictions runs "geo-restrictions set".
+func setRestrictions(ctx context.Context, a *app, args []string) error {
+	var regions string
+	restrictions := &gcore.GeoRestrictions{RegionList: []int{}}
+
+	fs := a.flagSet("geo-restrictions set")
+	fs.StringVar(&regions, "regions", "", "comma separated IDs of the billing regions")
+	fs.BoolVar(&restrictions.IsIn, "in", true, "allow the regions if true, deny them otherwise")
+
+	rest, err := a.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	clientID, err := parseID(rest[0], "CLIENT_ID")
+	if err != nil {
+		return err
+	}
+
+	for _, value := range strings.Split(regions, ",") {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%w: invalid region ID %q", errUsage, value)
+		}
+		restrictions.RegionList = append(restrictions.RegionList, id)
+	}
+
+	client, err := a.resellerClient()
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.GeoRestrictions.SetRestrictions(ctx, clientID, restrictions); err != nil {
+		return err
+	}
+
+	return a.printRestrictions(restrictions)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+func TestGeoRestrictions(t *testing.T) {
+	server := newTestServer(t)
+	client := server.AddClient(&gcore.ClientAccount{Name: "client"})
+	clientID := itoa(client.ID)
+
+	code, stdout, stderr := runTest(t, server, "geo-restrictions", "regions")
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "Europe") {
+		t.Errorf("expected the regions, got %q", stdout)
+	}
+
+	code, _, stderr = runTest(t, server, "geo-restrictions", "set", clientID, "--regions", "1,2", "--in=false")
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+
+	restrictions, _, err := mustResellerClient(t, server).GeoRestrictions.GetRestrictions(t.Context(), client.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restrictions.IsIn || len(restrictions.RegionList) != 2 {
+		t.Errorf("unexpected restrictions: %+v", restrictions)
+	}
+
+	code, stdout, _ = runTest(t, server, "geo-restrictions", "get", clientID)
+	if code != exitOK || !strings.Contains(stdout, "1,2") {
+		t.Errorf("unexpected output, got %d: %q", code, stdout)
+	}
+
+	code, _, stderr = runTest(t, server, "geo-restrictions", "set", clientID, "--regions", "eu")
+	if code != exitUsage || !strings.Contains(stderr, `invalid region ID "eu"`) {
+		t.Errorf("expected usage error, got %d: %q", code, stderr)
+	}
+}
@@ -0,0 +1,332 @@
+// Command gcorectl manages G-Core CDN from the command line.
+//
+// Usage:
+//
+//	gcorectl [global flags] <group> <command> [flags] [arguments]
+//
+// Groups are account, resources, rules, origin-groups, certs, clients,
+// services and geo-restrictions, run "gcorectl <group>" to list their
+// commands. Credentials are taken from GCORE_USERNAME and GCORE_PASSWORD or
+// GCORE_API_TOKEN environment variables or from a profile of the credentials
+// file (~/.gcore/credentials.yaml by default):
+//
+//	profiles:
+//	  default:
+//	    username: user@example.com
+//	    password: secret
+//	  production:
+//	    api_token: 123$abc
+//
+// The purge command purges files of a static site that have changed since
+// the previous deploy:
+//
+//	gcorectl purge --resource cdn.example.com --from-dir ./public --since v1.2.0
+//
+// Mutating commands run with --dry-run print requests they would send
+// instead of sending them.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// The list of the exit codes.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// errUsage is returned by commands run with invalid arguments.
+var errUsage = errors.New("invalid usage")
+
+// command represents command of a group.
+type command struct {
+	// usage represents arguments of the command, e.g. "RESOURCE_ID".
+	usage string
+
+	summary string
+	run     func(ctx context.Context, a *app, args []string) error
+}
+
+// groups represents commands by names of their groups.
+var groups = map[string]map[string]*command{
+	"account":          accountCommands,
+	"resources":        resourceCommands,
+	"rules":            ruleCommands,
+	"origin-groups":    originGroupCommands,
+	"certs":            certCommands,
+	"clients":          clientCommands,
+	"services":         serviceCommands,
+	"geo-restrictions": geoRestrictionCommands,
+}
+
+// commands represents commands that don't belong to a group.
+var commands = map[string]*command{
+	"purge": purgeCommand,
+}
+
+// app represents state of the command line invocation.
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	profile         string
+	credentialsFile string
+	apiURL          string
+	timeout         time.Duration
+	output          string
+	dryRun          bool
+
+	// clientOptions represents additional options of the clients, they
+	// are used by the tests.
+	clientOptions []gcore.Option
+
+	// dryRunRequests represents requests intercepted in the dry run mode,
+	// the requests may be sent concurrently, so it's guarded by mu.
+	mu             sync.Mutex
+	dryRunRequests []*dryRunRequest
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	stop()
+
+	os.Exit(code)
+}
+
+// run runs the command line and returns the exit code.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer, opts ...gcore.Option) int {
+	a := &app{stdin: stdin, stdout: stdout, stderr: stderr, clientOptions: opts}
+
+	fs := a.flagSet("gcorectl")
+	fs.StringVar(&a.profile, "profile", "", "profile of the credentials file")
+	fs.StringVar(&a.credentialsFile, "credentials-file", "", "path to the credentials file")
+	fs.StringVar(&a.apiURL, "api-url", "", "base URL of the API")
+	fs.DurationVar(&a.timeout, "timeout", 30*time.Second, "timeout of the requests")
+	fs.Usage = func() { a.usage() }
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	args = fs.Args()
+	if len(args) == 0 {
+		a.usage()
+		return exitUsage
+	}
+
+	// name represents the invoked command, e.g. "resources list".
+	var (
+		name string
+		cmd  *command
+	)
+	if top, ok := commands[args[0]]; ok {
+		name, cmd, args = args[0], top, args[1:]
+	} else {
+		group, ok := groups[args[0]]
+		if !ok {
+			fmt.Fprintf(stderr, "gcorectl: unknown group %q\n", args[0])
+			a.usage()
+			return exitUsage
+		}
+
+		if len(args) < 2 {
+			a.groupUsage(args[0])
+			return exitUsage
+		}
+
+		if cmd, ok = group[args[1]]; !ok {
+			fmt.Fprintf(stderr, "gcorectl: unknown command %q of %s\n", args[1], args[0])
+			a.groupUsage(args[0])
+			return exitUsage
+		}
+		name, args = args[0]+" "+args[1], args[2:]
+	}
+
+	err := cmd.run(ctx, a, args)
+	if errors.Is(err, errDryRun) {
+		requests := a.interceptedRequests()
+		err = a.print(requests, []string{"METHOD", "URL", "BODY"}, func() [][]string {
+			rows := make([][]string, 0, len(requests))
+			for _, req := range requests {
+				rows = append(rows, []string{req.Method, req.URL, compactJSON(req.Body)})
+			}
+			return rows
+		})
+	}
+
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errUsage), errors.Is(err, flag.ErrHelp):
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(stderr, "gcorectl: %v\nusage: gcorectl %s %s\n", err, name, cmd.usage)
+		}
+		return exitUsage
+	default:
+		fmt.Fprintf(stderr, "gcorectl: %v\n", err)
+		return exitError
+	}
+}
+
+// flagSet returns flag set with the flags common for all commands.
+func (a *app) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+
+	if a.output == "" {
+		a.output = formatTable
+	}
+	fs.StringVar(&a.output, "output", a.output, "output format: table, json or yaml")
+	fs.StringVar(&a.output, "o", a.output, "shorthand for --output")
+	fs.BoolVar(&a.dryRun, "dry-run", a.dryRun, "print mutating requests instead of sending them")
+
+	return fs
+}
+
+// parse parses flags of the command and checks number of its arguments.
+// Flags may follow the arguments, "--" terminates the flags.
+func (a *app) parse(fs *flag.FlagSet, args []string, minArgs, maxArgs int) ([]string, error) {
+	var rest []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %v", errUsage, err)
+		}
+
+		remaining := fs.Args()
+		if len(remaining) == 0 {
+			break
+		}
+		if consumed := len(args) - len(remaining); consumed > 0 && args[consumed-1] == "--" {
+			rest = append(rest, remaining...)
+			break
+		}
+
+		rest = append(rest, remaining[0])
+		args = remaining[1:]
+	}
+
+	switch a.output {
+	case formatTable, formatJSON, formatYAML:
+	default:
+		return nil, fmt.Errorf("%w: unknown output format %q", errUsage, a.output)
+	}
+
+	if len(rest) < minArgs || (maxArgs >= 0 && len(rest) > maxArgs) {
+		return nil, fmt.Errorf("%w: unexpected number of arguments", errUsage)
+	}
+
+	return rest, nil
+}
+
+// usage prints usage of gcorectl.
+func (a *app) usage() {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(a.stderr, "usage: gcorectl [global flags] <group> <command> [flags] [arguments]\n"+
+		"       gcorectl [global flags] <command> [flags] [arguments]\n\ngroups:\n")
+	for _, name := range names {
+		fmt.Fprintf(a.stderr, "  %s\n", name)
+	}
+	fmt.Fprintf(a.stderr, "\ncommands:\n")
+	names = names[:0]
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(a.stderr, "  %s\n", strings.TrimSpace(name+" "+commands[name].usage))
+	}
+	fmt.Fprintf(a.stderr, "\nglobal flags:\n")
+	fs := a.flagSet("gcorectl")
+	fs.String("profile", "", "profile of the credentials file")
+	fs.String("credentials-file", "", "path to the credentials file")
+	fs.String("api-url", "", "base URL of the API")
+	fs.Duration("timeout", 30*time.Second, "timeout of the requests")
+	fs.PrintDefaults()
+}
+
+// groupUsage prints commands of the group.
+func (a *app) groupUsage(group string) {
+	commands := groups[group]
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(a.stderr, "usage: gcorectl %s <command> [flags] [arguments]\n\ncommands:\n", group)
+	for _, name := range names {
+		cmd := commands[name]
+		fmt.Fprintf(a.stderr, "  %-40s %s\n", strings.TrimSpace(name+" "+cmd.usage), cmd.summary)
+	}
+}
+
+// parseID parses ID argument with given name.
+func parseID(value, name string) (int, error) {
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w: %s must be a positive integer, got %q", errUsage, name, value)
+	}
+
+	return id, nil
+}
+
+// parseIDs parses ID arguments with given names.
+func parseIDs(values []string, names ...string) ([]int, error) {
+	ids := make([]int, len(values))
+	for i, value := range values {
+		id, err := parseID(value, names[i])
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+// listFlags adds flags of the list commands that order and paginate the list,
+// all objects are listed unless --limit is set.
+func listFlags(fs *flag.FlagSet, ordering *string, limit, offset *int) {
+	fs.StringVar(ordering, "ordering", "", "field to order by, \"-\" prefix reverses the order")
+	fs.IntVar(limit, "limit", 0, "maximum number of the objects")
+	fs.IntVar(offset, "offset", 0, "number of the objects to skip")
+}
+
+// stringsFlag represents flag that can be repeated.
+type stringsFlag []string
+
+// String implements flag.Value interface.
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+// Set implements flag.Value interface.
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+
+	return nil
+}
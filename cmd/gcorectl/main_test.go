@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dstdfx/go-gcore/gcore"
+	"github.com/dstdfx/go-gcore/gcore/gcoretest"
+)
+
+// newTestServer returns the fake API server.
+func newTestServer(t *testing.T) *gcoretest.Server {
+	t.Helper()
+
+	server := gcoretest.NewServer()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// runTest runs gcorectl against the server and returns its exit code and
+// output.
+func runTest(t *testing.T, server *gcoretest.Server, args ...string) (int, string, string) {
+	t.Helper()
+
+	return runTestInput(t, server, "", args...)
+}
+
+// runTestInput runs gcorectl against the server with given standard input.
+func runTestInput(t *testing.T, server *gcoretest.Server, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	credentials := gcore.NewStaticCredentials(gcoretest.DefaultUsername, gcoretest.DefaultPassword)
+	code := run(context.Background(), append([]string{"--api-url", server.URL()}, args...), strings.NewReader(stdin),
+		&stdout, &stderr, gcore.WithCredentials(credentials))
+
+	return code, stdout.String(), stderr.String()
+}
+
+// mustCommonClient returns common client of the server.
+func mustCommonClient(t *testing.T, server *gcoretest.Server) *gcore.CommonClient {
+	t.Helper()
+
+	client, err := server.CommonClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+// mustResellerClient returns reseller client of the server.
+func mustResellerClient(t *testing.T, server *gcoretest.Server) *gcore.ResellerClient {
+	t.Helper()
+
+	client, err := server.ResellerClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+// mutatingRequests returns number of the requests the server has received
+// other than GET and the sign in.
+func mutatingRequests(server *gcoretest.Server) int {
+	n := 0
+	for _, req := range server.Requests() {
+		if req.Method != "GET" && !strings.HasSuffix(req.Path, signInPath) {
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestRun_Usage(t *testing.T) {
+	server := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		args   []string
+		stderr string
+	}{
+		{name: "no group", stderr: "usage: gcorectl"},
+		{name: "unknown group", args: []string{"foo"}, stderr: `unknown group "foo"`},
+		{name: "no command", args: []string{"resources"}, stderr: "purge-history"},
+		{name: "unknown command", args: []string{"resources", "foo"}, stderr: `unknown command "foo"`},
+		{name: "invalid ID", args: []string{"resources", "get", "abc"}, stderr: "RESOURCE_ID must be a positive integer"},
+		{name: "too many arguments", args: []string{"resources", "get", "1", "2"}, stderr: "unexpected number of arguments"},
+		{name: "unknown flag", args: []string{"resources", "list", "--foo"}, stderr: "flag provided but not defined"},
+		{name: "unknown output", args: []string{"resources", "list", "-o", "xml"}, stderr: `unknown output format "xml"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, stderr := runTest(t, server, tt.args...)
+			if code != exitUsage {
+				t.Errorf("expected exit code %d, got %d", exitUsage, code)
+			}
+			if !strings.Contains(stderr, tt.stderr) {
+				t.Errorf("expected stderr to contain %q, got %q", tt.stderr, stderr)
+			}
+		})
+	}
+}
+
+func TestRun_APIError(t *testing.T) {
+	server := newTestServer(t)
+
+	code, _, stderr := runTest(t, server, "resources", "get", "42")
+	if code != exitError {
+		t.Fatalf("expected exit code %d, got %d", exitError, code)
+	}
+	if !strings.Contains(stderr, "404") {
+		t.Errorf("expected the status code in stderr, got %q", stderr)
+	}
+}
+
+func TestRun_InterspersedFlags(t *testing.T) {
+	server := newTestServer(t)
+	resource := server.AddResource(&gcore.Resource{Cname: "cdn.example.com", OriginGroup: 1})
+
+	code, stdout, stderr := runTest(t, server, "resources", "get", itoa(resource.ID), "-o", "json")
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, `"cname": "cdn.example.com"`) {
+		t.Errorf("expected JSON output, got %q", stdout)
+	}
+
+	code, _, _ = runTest(t, server, "resources", "get", "--", "-1")
+	if code != exitUsage {
+		t.Errorf("expected argument after \"--\" to be rejected as ID, got exit code %d", code)
+	}
+}
+
+func TestParseIDs(t *testing.T) {
+	ids, err := parseIDs([]string{"1", "2"}, "A", "B")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("unexpected IDs: %v", ids)
+	}
+
+	if _, err = parseIDs([]string{"1", "0"}, "A", "B"); err == nil || !strings.Contains(err.Error(), "B must be") {
+		t.Errorf("expected error about B, got %v", err)
+	}
+}
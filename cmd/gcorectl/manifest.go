@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// manifestVersion represents version of the manifest format.
+const manifestVersion = 1
+
+// The list of the changes of the files.
+const (
+	changeAdded    = "added"
+	changeModified = "modified"
+	changeDeleted  = "deleted"
+)
+
+// manifest represents content hashes of the files of a build directory
+// deployed from a commit.
+type manifest struct {
+	Version int    `json:"version"`
+	Commit  string `json:"commit"`
+
+	// Files represents SHA-256 of the files by their slash separated paths
+	// relative to the build directory.
+	Files map[string]string `json:"files"`
+}
+
+// fileChange represents file of the build directory that has changed since
+// the previous deploy.
+type fileChange struct {
+	Path   string `json:"path"`
+	Change string `json:"change"`
+}
+
+// buildManifest hashes regular files of the directory, git metadata and
+// the manifests aren't a part of the site, so they're skipped.
+func buildManifest(dir, manifestDir string) (*manifest, error) {
+	m := &manifest{Version: manifestVersion, Files: map[string]string{}}
+
+	manifestDir, err := filepath.Abs(manifestDir)
+	if err != nil {
+		return nil, err
+	}
+
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			if abs, err := filepath.Abs(path); err == nil && abs == manifestDir {
+				return filepath.SkipDir
+			}
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		hash, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+		m.Files[filepath.ToSlash(rel)] = hash
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// hashFile returns hex encoded SHA-256 of the file content.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// manifestPath returns path of the manifest of the commit.
+func manifestPath(dir, commit string) string {
+	return filepath.Join(dir, commit+".json")
+}
+
+// loadManifest reads the manifest of the commit from the directory.
+func loadManifest(dir, commit string) (*manifest, error) {
+	data, err := os.ReadFile(manifestPath(dir, commit))
+	if err != nil {
+		return nil, err
+	}
+
+	m := &manifest{}
+	if err = json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("unable to parse manifest of %s: %w", commit, err)
+	}
+	if m.Version != manifestVersion {
+		return nil, fmt.Errorf("unsupported version %d of manifest of %s", m.Version, commit)
+	}
+
+	return m, nil
+}
+
+// save writes the manifest to the directory, the file is replaced atomically
+// so an interrupted deploy doesn't leave a truncated manifest.
+func (m *manifest) save(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, ".manifest-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err = f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), manifestPath(dir, m.Commit))
+}
+
+// diffManifests returns files that differ between the manifests sorted by
+// their paths. All files are added if there's no previous manifest.
+func diffManifests(previous, current *manifest) []fileChange {
+	var changes []fileChange
+	for path, hash := range current.Files {
+		switch previousHash, ok := previous.files()[path]; {
+		case !ok:
+			changes = append(changes, fileChange{Path: path, Change: changeAdded})
+		case previousHash != hash:
+			changes = append(changes, fileChange{Path: path, Change: changeModified})
+		}
+	}
+	for path := range previous.files() {
+		if _, ok := current.Files[path]; !ok {
+			changes = append(changes, fileChange{Path: path, Change: changeDeleted})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes
+}
+
+// files returns files of the manifest, it's nil safe.
+func (m *manifest) files() map[string]string {
+	if m == nil {
+		return nil
+	}
+
+	return m.Files
+}
+
+// resolveCommit returns hash of the commit the git reference points to,
+// git runs in the directory.
+func resolveCommit(ctx context.Context, dir, ref string) (string, error) {
+	if strings.HasPrefix(ref, "-") {
+		return "", fmt.Errorf("%w: invalid git reference %q", errUsage, ref)
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("unable to resolve git reference %q in %s", ref, dir)
+		}
+		return "", fmt.Errorf("unable to resolve git reference %q: %w", ref, err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// writeFiles writes the files by their slash separated paths to the directory.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"index.html":     "index",
+		"css/site.css":   "body {}",
+		"img/logo.png":   "logo",
+		"old/index.html": "old",
+	})
+
+	manifestDir := filepath.Join(t.TempDir(), "manifests")
+	previous, err := buildManifest(dir, manifestDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(previous.Files) != 4 || previous.Files["css/site.css"] == "" {
+		t.Fatalf("unexpected files: %v", previous.Files)
+	}
+
+	previous.Commit = "abc"
+	if err = previous.save(manifestDir); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := loadManifest(manifestDir, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, previous) {
+		t.Errorf("expected %+v, got %+v", previous, loaded)
+	}
+
+	if _, err = loadManifest(manifestDir, "def"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected not exist error, got %v", err)
+	}
+
+	if err = os.RemoveAll(filepath.Join(dir, "old")); err != nil {
+		t.Fatal(err)
+	}
+	writeFiles(t, dir, map[string]string{
+		"css/site.css": "body { color: red }",
+		"js/app.js":    "app",
+	})
+
+	current, err := buildManifest(dir, manifestDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []fileChange{
+		{Path: "css/site.css", Change: changeModified},
+		{Path: "js/app.js", Change: changeAdded},
+		{Path: "old/index.html", Change: changeDeleted},
+	}
+	if changes := diffManifests(previous, current); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("expected %v, got %v", expected, changes)
+	}
+
+	if changes := diffManifests(nil, current); len(changes) != 4 || changes[0].Change != changeAdded {
+		t.Errorf("expected all files to be added, got %v", changes)
+	}
+}
+
+func TestLoadManifest_Version(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"abc.json": `{"version": 2, "commit": "abc", "files": {}}`})
+
+	if _, err := loadManifest(dir, "abc"); err == nil {
+		t.Error("expected error for unsupported version")
+	}
+}
+
+func TestBuildManifest_ManifestDir(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"index.html":                 "index",
+		".gcore-manifests/abc.json":  "{}",
+		"assets/.gcore-manifests/js": "js",
+	})
+
+	t.Chdir(dir)
+
+	m, err := buildManifest(".", ".gcore-manifests")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"assets/.gcore-manifests/js", "index.html"}
+	var files []string
+	for file := range m.Files {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected %v, got %v", expected, files)
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// originGroupCommands represents commands of the origin-groups group.
+var originGroupCommands = map[string]*command{
+	"list": {
+		summary: "list origin groups",
+		run:     listOriginGroups,
+	},
+	"get": {
+		usage:   "ORIGIN_GROUP_ID",
+		summary: "show the origin group",
+		run:     getOriginGroup,
+	},
+	"create": {
+		usage:   "--name NAME --origin SOURCE...",
+		summary: "create an origin group",
+		run:     createOriginGroup,
+	},
+	"update": {
+		usage:   "ORIGIN_GROUP_ID",
+		summary: "update the origin group keeping the fields that aren't set",
+		run:     updateOriginGroup,
+	},
+	"delete": {
+		usage:   "ORIGIN_GROUP_ID",
+		summary: "delete the origin group",
+		run:     deleteOriginGroup,
+	},
+}
+
+// printOriginGroups prints the value with the table of the origin groups.
+func (a *app) printOriginGroups(v interface{}, groups ...*gcore.OriginGroup) error {
+	return a.print(v, []string{"ID", "NAME", "USE_NEXT", "ORIGINS"}, func() [][]string {
+		rows := make([][]string, 0, len(groups))
+		for _, group := range groups {
+			origins := make([]string, 0, len(group.Origins))
+			for _, origin := range group.Origins {
+				source := origin.Source
+				if origin.Backup {
+					source += " (backup)"
+				}
+				origins = append(origins, source)
+			}
+			rows = append(rows, []string{itoa(group.ID), group.Name, yesNo(group.UseNext), strings.Join(origins, ",")})
+		}
+		return rows
+	})
+}
+
+// originFlags adds flags of the origins to the flag set.
+func originFlags(fs *flag.FlagSet) (origins, backups *stringsFlag) {
+	origins, backups = &stringsFlag{}, &stringsFlag{}
+	fs.Var(origins, "origin", "source of the origin, can be repeated")
+	fs.Var(backups, "backup-origin", "source of the backup origin, can be repeated")
+
+	return origins, backups
+}
+
+// newOrigins returns enabled origins with given sources.
+func newOrigins(sources, backups []string) []gcore.Origin {
+	origins := make([]gcore.Origin, 0, len(sources)+len(backups))
+	for _, source := range sources {
+		origins = append(origins, gcore.Origin{Source: source, Enabled: true})
+	}
+	for _, source := range backups {
+		origins = append(origins, gcore.Origin{Source: source, Enabled: true, Backup: true})
+	}
+
+	return origins
+}
+
+// listOriginGroups runs "origin-groups list".
+func listOriginGroups(ctx context.Context, a *app, args []string) error {
+	var opts gcore.ListOriginGroupsOpts
+
+	fs := a.flagSet("origin-groups list")
+	fs.StringVar(&opts.Name, "name", "", "name of the origin groups")
+	listFlags(fs, &opts.Ordering, &opts.Limit, &opts.Offset)
+	if _, err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	client, err := a.commonClient()
+	if err != nil {
+		return err
+	}
+
+	groups, _, err := client.OriginGroups.ListWithOpts(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	return a.printOriginGroups(groups, groups...)
+}
+
+// getOriginGroup runs "origin-groups get".
+func getOriginGroup(ctx context.Context, a *app, args []string) error {
+	client, id, err := a.originGroupArgs("origin-groups get", args)
+	if err != nil {
+		return err
+	}
+
+	group, _, err := client.OriginGroups.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.printOriginGroups(group, group)
+}
+
+// createOriginGroup runs "origin-groups create".
+func createOriginGroup(ctx context.Context, a *app, args []string) error {
+	var body gcore.CreateOriginGroupBody
+
+	fs := a.flagSet("origin-groups create")
+	fs.StringVar(&body.Name, "name", "", "name of the origin group")
+	fs.BoolVar(&body.UseNext, "use-next", false, "use the next origin if the current one responds with an error")
+	origins, backups := originFlags(fs)
+	if _, err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	if body.Name == "" || len(*origins) == 0 {
+		return fmt.Errorf("%w: --name and at least one --origin are required", errUsage)
+	}
+	body.Origins = newOrigins(*origins, *backups)
+
+	client, err := a.commonClient()
+	if err != nil {
+		return err
+	}
+
+	group, _, err := client.OriginGroups.Create(ctx, &body)
+	if err != nil {
+		return err
+	}
+
+	return a.printOriginGroups(group, group)
+}
+
+// updateOriginGroup runs "origin-groups update". Origins given by the flags
+// replace the current ones.
+func updateOriginGroup(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("origin-groups update")
+	name := fs.String("name", "", "name of the origin group")
+	useNext := fs.Bool("use-next", false, "use the next origin if the current one responds with an error")
+	origins, backups := originFlags(fs)
+
+	rest, err := a.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseID(rest[0], "ORIGIN_GROUP_ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := a.commonClient()
+	if err != nil {
+		return err
+	}
+
+	group, _, err := client.OriginGroups.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	body := group.UpdateBody()
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "name":
+			body.Name = *name
+		case "use-next":
+			body.UseNext = *useNext
+		}
+	})
+	if len(*origins) > 0 || len(*backups) > 0 {
+		body.Origins = newOrigins(*origins, *backups)
+	}
+
+	group, _, err = client.OriginGroups.Update(ctx, id, body)
+	if err != nil {
+		return err
+	}
+
+	return a.printOriginGroups(group, group)
+}
+
+// deleteOriginGroup runs "origin-groups delete".
+func deleteOriginGroup(ctx context.Context, a *app, args []string) error {
+	client, id, err := a.originGroupArgs("origin-groups delete", args)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.OriginGroups.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	return a.printDone("Origin group %d has been deleted.", id)
+}
+
+// originGroupArgs parses arguments of the commands that accept only ID of
+// the origin group and returns the client.
+func (a *app) originGroupArgs(name string, args []string) (*gcore.CommonClient, int, error) {
+	rest, err := a.parse(a.flagSet(name), args, 1, 1)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	id, err := parseID(rest[0], "ORIGIN_GROUP_ID")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	client, err := a.commonClient()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return client, id, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+func TestOriginGroups(t *testing.T) {
+	server := newTestServer(t)
+
+	code, stdout, stderr := runTest(t, server, "origin-groups", "create", "-o", "json", "--name", "origins",
+		"--origin", "a.example.com", "--backup-origin", "b.example.com")
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+
+	var group gcore.OriginGroup
+	if err := json.Unmarshal([]byte(stdout), &group); err != nil {
+		t.Fatal(err)
+	}
+	if len(group.Origins) != 2 || group.Origins[0].Backup || !group.Origins[1].Backup {
+		t.Errorf("unexpected origins: %+v", group.Origins)
+	}
+	id := itoa(group.ID)
+
+	code, _, _ = runTest(t, server, "origin-groups", "update", id, "--name", "renamed")
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+	stored, _ := server.OriginGroup(group.ID)
+	if stored.Name != "renamed" || len(stored.Origins) != 2 {
+		t.Errorf("expected the name to change and the origins to stay, got %+v", stored)
+	}
+
+	code, stdout, _ = runTest(t, server, "origin-groups", "list")
+	if code != exitOK || !strings.Contains(stdout, "renamed") {
+		t.Errorf("expected the group in the list, got %d: %q", code, stdout)
+	}
+
+	server.AddOriginGroup(&gcore.OriginGroup{Name: "other", Origins: []gcore.Origin{{Source: "c.example.com"}}})
+	code, stdout, _ = runTest(t, server, "origin-groups", "list", "--ordering", "-id", "--limit", "1")
+	if code != exitOK || !strings.Contains(stdout, "other") || strings.Contains(stdout, "renamed") {
+		t.Errorf("expected only the last group in the list, got %d: %q", code, stdout)
+	}
+
+	code, _, _ = runTest(t, server, "origin-groups", "delete", id)
+	if code != exitOK {
+		t.Errorf("unexpected exit code %d", code)
+	}
+	if _, ok := server.OriginGroup(group.ID); ok {
+		t.Error("expected the group to be deleted")
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The list of the output formats.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+// print prints the value in the output format. Table consists of given
+// columns and rows returned by the function.
+func (a *app) print(v interface{}, columns []string, rows func() [][]string) error {
+	switch a.output {
+	case formatJSON:
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+
+		return enc.Encode(v)
+	case formatYAML:
+		// The value is converted through JSON, so YAML has the same keys and
+		// includes fields kept in Extra.
+		tree, err := jsonTree(v)
+		if err != nil {
+			return err
+		}
+
+		enc := yaml.NewEncoder(a.stdout)
+		enc.SetIndent(2)
+		if err = enc.Encode(tree); err != nil {
+			return err
+		}
+
+		return enc.Close()
+	default:
+		w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, strings.Join(columns, "\t"))
+		for _, row := range rows() {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+
+		return w.Flush()
+	}
+}
+
+// printDone prints message of the finished command that returns no object,
+// the message isn't printed in JSON and YAML formats.
+func (a *app) printDone(format string, args ...interface{}) error {
+	if a.output != formatTable {
+		return nil
+	}
+
+	_, err := fmt.Fprintf(a.stdout, format+"\n", args...)
+
+	return err
+}
+
+// jsonTree returns JSON representation of v decoded into maps and slices.
+func jsonTree(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var tree interface{}
+	if err = json.Unmarshal(b, &tree); err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+// readFile decodes JSON or YAML file into v by the JSON field names.
+func readFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	// YAML is a superset of JSON, so both formats are parsed the same way.
+	var tree interface{}
+	if err = yaml.Unmarshal(data, &tree); err != nil {
+		return fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+
+	b, err := json.Marshal(tree)
+	if err != nil {
+		return fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+
+	if err = json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// compactJSON returns compact JSON of the value or an empty string for nil.
+func compactJSON(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(b)
+}
+
+// itoa returns decimal representation of the integer.
+func itoa(i int) string {
+	return strconv.Itoa(i)
+}
+
+// yesNo returns table representation of the boolean.
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+
+	return "no"
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestPrint(t *testing.T) {
+	value := map[string]interface{}{"id": 1, "name": "<cdn>"}
+	rows := func() [][]string { return [][]string{{"1", "<cdn>"}} }
+
+	tests := []struct {
+		format string
+		check  func(t *testing.T, out string)
+	}{
+		{
+			format: formatTable,
+			check: func(t *testing.T, out string) {
+				if out != "ID  NAME\n1   <cdn>\n" {
+					t.Errorf("unexpected table: %q", out)
+				}
+			},
+		},
+		{
+			format: formatJSON,
+			check: func(t *testing.T, out string) {
+				var v map[string]interface{}
+				if err := json.Unmarshal([]byte(out), &v); err != nil {
+					t.Fatal(err)
+				}
+				if v["name"] != "<cdn>" || !strings.Contains(out, "<cdn>") {
+					t.Errorf("unexpected JSON: %q", out)
+				}
+			},
+		},
+		{
+			format: formatYAML,
+			check: func(t *testing.T, out string) {
+				var v map[string]interface{}
+				if err := yaml.Unmarshal([]byte(out), &v); err != nil {
+					t.Fatal(err)
+				}
+				if v["id"] != 1 || v["name"] != "<cdn>" {
+					t.Errorf("unexpected YAML: %q", out)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var stdout bytes.Buffer
+			a := &app{stdout: &stdout, output: tt.format}
+			if err := a.print(value, []string{"ID", "NAME"}, rows); err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, stdout.String())
+		})
+	}
+}
+
+func TestPrintDone(t *testing.T) {
+	var stdout bytes.Buffer
+	a := &app{stdout: &stdout, output: formatJSON}
+	if err := a.printDone("done %d", 1); err != nil {
+		t.Fatal(err)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("expected no output in JSON format, got %q", stdout.String())
+	}
+
+	a.output = formatTable
+	if err := a.printDone("done %d", 1); err != nil {
+		t.Fatal(err)
+	}
+	if stdout.String() != "done 1\n" {
+		t.Errorf("unexpected output: %q", stdout.String())
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"options.json": `{"cache_http_headers": {"enabled": true, "value": ["a"]}}`,
+		"options.yaml": "cache_http_headers:\n  enabled: true\n  value: [a]\n",
+	}
+
+	for name, data := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			var v struct {
+				CacheHTTPHeaders struct {
+					Enabled bool     `json:"enabled"`
+					Value   []string `json:"value"`
+				} `json:"cache_http_headers"`
+			}
+			if err := readFile(path, &v); err != nil {
+				t.Fatal(err)
+			}
+			if !v.CacheHTTPHeaders.Enabled || len(v.CacheHTTPHeaders.Value) != 1 {
+				t.Errorf("unexpected value: %+v", v)
+			}
+		})
+	}
+
+	if err := readFile(filepath.Join(dir, "missing.yaml"), &struct{}{}); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// purgeCommand represents command that purges files of a static site that
+// have changed since the previous deploy.
+var purgeCommand = &command{
+	usage:   "--resource CNAME --from-dir DIR [--since REF]",
+	summary: "purge files of the build directory changed since the previous deploy",
+	run:     purgeChanges,
+}
+
+// changedFile represents file changed since the previous deploy and URLs it's
+// served by.
+type changedFile struct {
+	fileChange
+	URLs []string `json:"urls"`
+}
+
+// purgeResult represents result of the purge command.
+type purgeResult struct {
+	Resource   int           `json:"resource"`
+	Commit     string        `json:"commit"`
+	Since      string        `json:"since,omitempty"`
+	Purges     []int         `json:"purges"`
+	Prefetched int           `json:"prefetched"`
+	Changes    []changedFile `json:"changes"`
+}
+
+// purgeChanges runs "purge". Files of the build directory are compared with
+// the manifest stored by the purge of the deploy of the --since commit, so
+// only the added, modified and deleted files are purged. The manifest of
+// the deployed commit is stored after the purge for the next deploy.
+func purgeChanges(ctx context.Context, a *app, args []string) error {
+	var (
+		resourceName, dir, since, ref, manifestDir string
+		prefetch, wait, yes                        bool
+	)
+
+	fs := a.flagSet("purge")
+	fs.StringVar(&resourceName, "resource", "", "cname, secondary hostname or ID of the resource")
+	fs.StringVar(&dir, "from-dir", "", "build directory of the site")
+	fs.StringVar(&since, "since", "", "git reference of the previous deploy, all files are purged if it isn't set")
+	fs.StringVar(&ref, "ref", "HEAD", "git reference of the deployed build")
+	fs.StringVar(&manifestDir, "manifest-dir", ".gcore-manifests", "directory of the manifests of the deploys")
+	fs.BoolVar(&prefetch, "prefetch", false, "prefetch the added and modified files")
+	fs.BoolVar(&wait, "wait", false, "wait until the purges are finished")
+	fs.BoolVar(&yes, "yes", false, "purge without confirmation")
+	fs.BoolVar(&yes, "y", false, "shorthand for --yes")
+	if _, err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	if resourceName == "" || dir == "" {
+		return fmt.Errorf("%w: --resource and --from-dir are required", errUsage)
+	}
+
+	commit, err := resolveCommit(ctx, dir, ref)
+	if err != nil {
+		return err
+	}
+
+	current, err := buildManifest(dir, manifestDir)
+	if err != nil {
+		return err
+	}
+	current.Commit = commit
+
+	var previous *manifest
+	if since != "" {
+		sinceCommit, err := resolveCommit(ctx, dir, since)
+		if err != nil {
+			return err
+		}
+
+		previous, err = loadManifest(manifestDir, sinceCommit)
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("no manifest of %s (%s) in %s, it's stored by the purge of the deploy", since, sinceCommit, manifestDir)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := a.commonClient()
+	if err != nil {
+		return err
+	}
+
+	resource, err := findResource(ctx, client, resourceName)
+	if err != nil {
+		return err
+	}
+
+	result := &purgeResult{Resource: resource.ID, Commit: commit, Since: since, Purges: []int{}, Changes: []changedFile{}}
+	var paths, prefetchPaths []string
+	for _, change := range diffManifests(previous, current) {
+		cdnPaths := filePaths(change.Path)
+		paths = append(paths, cdnPaths...)
+		if change.Change != changeDeleted {
+			prefetchPaths = append(prefetchPaths, cdnPaths...)
+		}
+		result.Changes = append(result.Changes, changedFile{fileChange: change, URLs: resourceURLs(resource, cdnPaths)})
+	}
+	paths = gcore.NormalizePaths(paths)
+	prefetchPaths = gcore.NormalizePaths(prefetchPaths)
+
+	if len(paths) > 0 && !yes && !a.dryRun {
+		for _, change := range result.Changes {
+			fmt.Fprintf(a.stderr, "  %-8s  %s\n", change.Change, change.URLs[0])
+		}
+
+		ok, err := a.confirm("Purge %d paths of %s?", len(paths), resource.Cname)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("purge has been cancelled")
+		}
+	}
+
+	// In the dry run mode every chunk fails with errDryRun, so all of them
+	// are printed.
+	if len(paths) > 0 {
+		batch, err := client.Resources.PurgeBatch(ctx, resource.ID, gcore.PurgeURLs(paths...))
+		if err != nil && !a.dryRun {
+			return err
+		}
+		for _, chunk := range batch.Succeeded() {
+			result.Purges = append(result.Purges, chunk.Task.ID)
+		}
+	}
+
+	if prefetch && len(prefetchPaths) > 0 {
+		batch, err := client.Resources.PrefetchBatch(ctx, resource.ID, prefetchPaths)
+		if err != nil && !a.dryRun {
+			return err
+		}
+		for _, chunk := range batch.Succeeded() {
+			result.Prefetched += len(chunk.Paths)
+		}
+	}
+
+	if a.dryRun {
+		if len(a.interceptedRequests()) > 0 {
+			return errDryRun
+		}
+		return a.printPurge(result)
+	}
+
+	if wait {
+		for _, id := range result.Purges {
+			if _, err = client.Resources.WaitForPurge(ctx, id); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err = current.save(manifestDir); err != nil {
+		return fmt.Errorf("files have been purged, but the manifest hasn't been stored: %w", err)
+	}
+
+	return a.printPurge(result)
+}
+
+// printPurge prints the result of the purge command.
+func (a *app) printPurge(result *purgeResult) error {
+	return a.print(result, []string{"CHANGE", "URL"}, func() [][]string {
+		var rows [][]string
+		for _, change := range result.Changes {
+			for _, u := range change.URLs {
+				rows = append(rows, []string{change.Change, u})
+			}
+		}
+		return rows
+	})
+}
+
+// confirm asks the user to confirm the action, anything but "y" or "yes"
+// declines it.
+func (a *app) confirm(format string, args ...interface{}) (bool, error) {
+	fmt.Fprintf(a.stderr, format+" [y/N] ", args...)
+	if a.stdin == nil {
+		return false, nil
+	}
+
+	line, err := bufio.NewReader(a.stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// findResource returns the resource by its ID, cname or secondary hostname.
+func findResource(ctx context.Context, client *gcore.CommonClient, name string) (*gcore.Resource, error) {
+	if id, err := strconv.Atoi(name); err == nil {
+		resource, _, err := client.Resources.Get(ctx, id)
+
+		return resource, err
+	}
+
+	resources, _, err := client.Resources.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, resource := range resources {
+		if strings.EqualFold(resource.Cname, name) {
+			return resource, nil
+		}
+		for _, hostname := range resource.SecondaryHostnames {
+			if strings.EqualFold(hostname, name) {
+				return resource, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("resource %q not found", name)
+}
+
+// filePaths returns escaped paths the file of the build directory is served
+// by, index.html is served by its directory as well.
+func filePaths(file string) []string {
+	p := (&url.URL{Path: "/" + file}).EscapedPath()
+	if path.Base(file) == "index.html" {
+		return []string{strings.TrimSuffix(p, "index.html"), p}
+	}
+
+	return []string{p}
+}
+
+// resourceURLs returns URLs of the paths on the cname and the secondary
+// hostnames of the resource.
+func resourceURLs(resource *gcore.Resource, paths []string) []string {
+	scheme := "http"
+	if resource.SslEnabled {
+		scheme = "https"
+	}
+
+	hostnames := append([]string{resource.Cname}, resource.SecondaryHostnames...)
+	urls := make([]string, 0, len(hostnames)*len(paths))
+	for _, p := range paths {
+		for _, hostname := range hostnames {
+			urls = append(urls, scheme+"://"+hostname+p)
+		}
+	}
+
+	return urls
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dstdfx/go-gcore/gcore"
+	"github.com/dstdfx/go-gcore/gcore/gcoretest"
+)
+
+// gitCommit commits all files of the repository and returns hash of
+// the commit, the repository is initialized if needed.
+func gitCommit(t *testing.T, dir, message string) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s: %v: %s", strings.Join(args, " "), err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		git("init", "-q")
+	}
+	git("add", "-A")
+	git("commit", "-q", "--allow-empty", "-m", message)
+
+	return git("rev-parse", "HEAD")
+}
+
+// purgedURLs returns URLs of the purges received by the server.
+func purgedURLs(t *testing.T, server *gcoretest.Server) []string {
+	t.Helper()
+
+	statuses, _, err := mustCommonClient(t, server).Resources.PurgeStatuses(t.Context(), gcore.PurgeStatusesOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var urls []string
+	for _, status := range statuses {
+		urls = append(urls, status.URLs...)
+	}
+
+	return urls
+}
+
+func TestPurge(t *testing.T) {
+	server := newTestServer(t)
+	resource := server.AddResource(&gcore.Resource{
+		Cname:              "cdn.example.com",
+		SecondaryHostnames: []string{"www.example.com"},
+		OriginGroup:        1,
+		SslEnabled:         true,
+	})
+
+	repo := t.TempDir()
+	public := filepath.Join(repo, "public")
+	manifestDir := filepath.Join(t.TempDir(), "manifests")
+	writeFiles(t, public, map[string]string{
+		"index.html":        "index",
+		"css/site.css":      "body {}",
+		"blog/index.html":   "blog",
+		"img/my photo.jpeg": "photo",
+	})
+	first := gitCommit(t, repo, "first")
+
+	code, stdout, stderr := runTest(t, server, "purge", "--resource", "www.example.com", "--from-dir", public,
+		"--manifest-dir", manifestDir, "--yes", "-o", "json")
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+
+	var result purgeResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Resource != resource.ID || result.Commit != first || len(result.Changes) != 4 || len(result.Purges) != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if _, err := os.Stat(manifestPath(manifestDir, first)); err != nil {
+		t.Errorf("expected the manifest to be stored: %v", err)
+	}
+
+	writeFiles(t, public, map[string]string{"css/site.css": "body { color: red }"})
+	if err := os.Remove(filepath.Join(public, "blog", "index.html")); err != nil {
+		t.Fatal(err)
+	}
+	second := gitCommit(t, repo, "second")
+
+	code, stdout, stderr = runTest(t, server, "purge", "--resource", "cdn.example.com", "--from-dir", public,
+		"--manifest-dir", manifestDir, "--since", "HEAD~1", "--yes", "--prefetch", "--wait")
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+	for _, expected := range []string{
+		"modified  https://cdn.example.com/css/site.css",
+		"modified  https://www.example.com/css/site.css",
+		"deleted   https://cdn.example.com/blog/",
+		"deleted   https://cdn.example.com/blog/index.html",
+	} {
+		if !strings.Contains(stdout, expected) {
+			t.Errorf("expected %q in output, got %q", expected, stdout)
+		}
+	}
+	if strings.Contains(stdout, "photo") {
+		t.Errorf("expected unchanged files not to be purged, got %q", stdout)
+	}
+	if _, err := os.Stat(manifestPath(manifestDir, second)); err != nil {
+		t.Errorf("expected the manifest to be stored: %v", err)
+	}
+
+	// All files are purged by the first deploy and the changed ones by
+	// the second.
+	urls := purgedURLs(t, server)
+	sort.Strings(urls)
+	expected := []string{
+		"/", "/blog/", "/blog/", "/blog/index.html", "/blog/index.html",
+		"/css/site.css", "/css/site.css", "/img/my%20photo.jpeg", "/index.html",
+	}
+	if !reflect.DeepEqual(urls, expected) {
+		t.Errorf("expected purged URLs %v, got %v", expected, urls)
+	}
+}
+
+func TestPurge_Confirmation(t *testing.T) {
+	server := newTestServer(t)
+	server.AddResource(&gcore.Resource{Cname: "cdn.example.com", OriginGroup: 1})
+
+	repo := t.TempDir()
+	writeFiles(t, repo, map[string]string{"index.html": "index"})
+	gitCommit(t, repo, "first")
+	manifestDir := filepath.Join(t.TempDir(), "manifests")
+
+	code, _, stderr := runTestInput(t, server, "n\n", "purge", "--resource", "cdn.example.com", "--from-dir", repo,
+		"--manifest-dir", manifestDir)
+	if code != exitError || !strings.Contains(stderr, "Purge 2 paths of cdn.example.com? [y/N]") {
+		t.Errorf("expected the purge to be cancelled, got %d: %q", code, stderr)
+	}
+	if n := mutatingRequests(server); n != 0 {
+		t.Errorf("expected no purges, got %d requests", n)
+	}
+	if _, err := os.Stat(manifestDir); !os.IsNotExist(err) {
+		t.Errorf("expected no manifest, got %v", err)
+	}
+
+	code, _, stderr = runTestInput(t, server, "y\n", "purge", "--resource", "cdn.example.com", "--from-dir", repo,
+		"--manifest-dir", manifestDir)
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+	if n := mutatingRequests(server); n != 1 {
+		t.Errorf("expected one purge, got %d requests", n)
+	}
+}
+
+func TestPurge_DryRun(t *testing.T) {
+	server := newTestServer(t)
+	server.AddResource(&gcore.Resource{Cname: "cdn.example.com", OriginGroup: 1})
+
+	repo := t.TempDir()
+	files := map[string]string{}
+	for i := 0; i < gcore.DefaultBatchChunkSize+1; i++ {
+		files["file"+itoa(i)+".txt"] = itoa(i)
+	}
+	writeFiles(t, repo, files)
+	gitCommit(t, repo, "first")
+	manifestDir := filepath.Join(t.TempDir(), "manifests")
+
+	code, stdout, stderr := runTest(t, server, "purge", "--resource", "cdn.example.com", "--from-dir", repo,
+		"--manifest-dir", manifestDir, "--dry-run", "--prefetch")
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+	if n := strings.Count(stdout, "/purge "); n != 2 {
+		t.Errorf("expected two purge batches, got %d: %q", n, stdout)
+	}
+	if n := strings.Count(stdout, "/prefetch "); n != 2 {
+		t.Errorf("expected two prefetch batches, got %d: %q", n, stdout)
+	}
+	if n := mutatingRequests(server); n != 0 {
+		t.Errorf("expected no mutating requests, got %d", n)
+	}
+	if _, err := os.Stat(manifestDir); !os.IsNotExist(err) {
+		t.Errorf("expected no manifest in the dry run, got %v", err)
+	}
+}
+
+func TestPurge_Errors(t *testing.T) {
+	server := newTestServer(t)
+	server.AddResource(&gcore.Resource{Cname: "cdn.example.com", OriginGroup: 1})
+
+	repo := t.TempDir()
+	writeFiles(t, repo, map[string]string{"index.html": "index"})
+	gitCommit(t, repo, "first")
+	manifestDir := filepath.Join(t.TempDir(), "manifests")
+
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stderr string
+	}{
+		{
+			name:   "missing flags",
+			args:   []string{"--resource", "cdn.example.com"},
+			code:   exitUsage,
+			stderr: "--resource and --from-dir are required",
+		},
+		{
+			name:   "unknown resource",
+			args:   []string{"--resource", "other.example.com", "--from-dir", repo, "--yes"},
+			code:   exitError,
+			stderr: `resource "other.example.com" not found`,
+		},
+		{
+			name:   "unknown reference",
+			args:   []string{"--resource", "cdn.example.com", "--from-dir", repo, "--since", "v9"},
+			code:   exitError,
+			stderr: `unable to resolve git reference "v9"`,
+		},
+		{
+			name:   "no manifest",
+			args:   []string{"--resource", "cdn.example.com", "--from-dir", repo, "--since", "HEAD", "--manifest-dir", manifestDir},
+			code:   exitError,
+			stderr: "no manifest of HEAD",
+		},
+		{
+			name:   "invalid reference",
+			args:   []string{"--resource", "cdn.example.com", "--from-dir", repo, "--ref", "--output=x"},
+			code:   exitUsage,
+			stderr: "invalid git reference",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, stderr := runTest(t, server, append([]string{"purge"}, tt.args...)...)
+			if code != tt.code || !strings.Contains(stderr, tt.stderr) {
+				t.Errorf("expected exit code %d and %q, got %d: %q", tt.code, tt.stderr, code, stderr)
+			}
+		})
+	}
+}
+
+func TestFilePaths(t *testing.T) {
+	tests := map[string][]string{
+		"index.html":       {"/", "/index.html"},
+		"blog/index.html":  {"/blog/", "/blog/index.html"},
+		"img/my photo.png": {"/img/my%20photo.png"},
+		"css/site.css":     {"/css/site.css"},
+	}
+
+	for file, expected := range tests {
+		if paths := filePaths(file); !reflect.DeepEqual(paths, expected) {
+			t.Errorf("%s: expected %v, got %v", file, expected, paths)
+		}
+	}
+}
@@ -0,0 +1,497 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// resourceCommands represents commands of the resources group.
+var resourceCommands = map[string]*command{
+	"list": {
+		summary: "list CDN resources",
+		run:     listResources,
+	},
+	"get": {
+		usage:   "RESOURCE_ID",
+		summary: "show the CDN resource",
+		run:     getResource,
+	},
+	"create": {
+		usage:   "--cname CNAME (--origin-group ID | --origin SOURCE)",
+		summary: "create a CDN resource",
+		run:     createResource,
+	},
+	"update": {
+		usage:   "RESOURCE_ID",
+		summary: "update the CDN resource keeping the fields that aren't set",
+		run:     updateResource,
+	},
+	"delete": {
+		usage:   "RESOURCE_ID",
+		summary: "delete the CDN resource",
+		run:     deleteResource,
+	},
+	"suspend": {
+		usage:   "RESOURCE_ID",
+		summary: "suspend the CDN resource",
+		run:     suspendResource,
+	},
+	"activate": {
+		usage:   "RESOURCE_ID",
+		summary: "activate the suspended CDN resource",
+		run:     activateResource,
+	},
+	"purge": {
+		usage:   "RESOURCE_ID [--all | --patterns] [PATH...]",
+		summary: "purge cache of the CDN resource",
+		run:     purgeResource,
+	},
+	"prefetch": {
+		usage:   "RESOURCE_ID PATH...",
+		summary: "pre-load the paths to the cache of the CDN resource",
+		run:     prefetchResource,
+	},
+	"purge-status": {
+		usage:   "PURGE_ID",
+		summary: "show status of the purge",
+		run:     getPurgeStatus,
+	},
+	"purge-history": {
+		summary: "list purges of the account",
+		run:     listPurgeStatuses,
+	},
+}
+
+// resourceColumns represents columns of the resources table.
+var resourceColumns = []string{"ID", "CNAME", "STATUS", "ORIGIN_GROUP", "SSL", "SECONDARY_HOSTNAMES"}
+
+// resourceRow returns row of the resources table.
+func resourceRow(resource *gcore.Resource) []string {
+	ssl := "-"
+	if resource.SslEnabled && resource.SslData != nil {
+		ssl = itoa(*resource.SslData)
+	}
+
+	return []string{
+		itoa(resource.ID),
+		resource.Cname,
+		resource.Status,
+		itoa(resource.OriginGroup),
+		ssl,
+		strings.Join(resource.SecondaryHostnames, ","),
+	}
+}
+
+// printResource prints the resource.
+func (a *app) printResource(resource *gcore.Resource) error {
+	return a.print(resource, resourceColumns, func() [][]string {
+		return [][]string{resourceRow(resource)}
+	})
+}
+
+// listResources runs "resources list".
+func listResources(ctx context.Context, a *app, args []string) error {
+	var opts gcore.ListResourcesOpts
+
+	fs := a.flagSet("resources list")
+	fs.StringVar(&opts.Cname, "cname", "", "domain name of the resources")
+	fs.StringVar(&opts.Status, "status", "", "status of the resources: active, suspended or processed")
+	fs.IntVar(&opts.OriginGroup, "origin-group", 0, "ID of the origin group of the resources")
+	fs.BoolVar(&opts.Deleted, "deleted", false, "list deleted resources")
+	listFlags(fs, &opts.Ordering, &opts.Limit, &opts.Offset)
+	if _, err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	client, err := a.commonClient()
+	if err != nil {
+		return err
+	}
+
+	resources, _, err := client.Resources.ListWithOpts(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	return a.print(resources, resourceColumns, func() [][]string {
+		rows := make([][]string, 0, len(resources))
+		for _, resource := range resources {
+			rows = append(rows, resourceRow(resource))
+		}
+		return rows
+	})
+}
+
+// getResource runs "resources get".
+func getResource(ctx context.Context, a *app, args []string) error {
+	client, id, err := a.resourceArgs("resources get", args)
+	if err != nil {
+		return err
+	}
+
+	resource, _, err := client.Resources.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.printResource(resource)
+}
+
+// createResource runs "resources create".
+func createResource(ctx context.Context, a *app, args []string) error {
+	var (
+		body               gcore.CreateResourceBody
+		originGroup, ssl   int
+		secondaryHostnames stringsFlag
+		optionsFile        string
+	)
+
+	fs := a.flagSet("resources create")
+	fs.StringVar(&body.Cname, "cname", "", "domain name of the resource")
+	fs.StringVar(&body.Origin, "origin", "", "source of the origin, an origin group is created for it")
+	fs.IntVar(&originGroup, "origin-group", 0, "ID of the origin group")
+	fs.Var(&secondaryHostnames, "secondary-hostname", "additional domain name, can be repeated")
+	fs.StringVar(&body.OriginProtocol, "origin-protocol", "", "protocol of the requests to the origins: HTTP, HTTPS or MATCH")
+	fs.IntVar(&ssl, "ssl-data", 0, "ID of the SSL certificate, enables HTTPS")
+	fs.StringVar(&optionsFile, "options-file", "", "JSON or YAML file with the options")
+	if _, err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	if body.Cname == "" || (originGroup == 0) == (body.Origin == "") {
+		return fmt.Errorf("%w: --cname and either --origin-group or --origin are required", errUsage)
+	}
+
+	if originGroup != 0 {
+		body.OriginGroupID = &originGroup
+	}
+	if ssl != 0 {
+		body.SslData = &ssl
+		body.SslEnabled = true
+	}
+	body.SecondaryHostnames = secondaryHostnames
+
+	if optionsFile != "" {
+		body.Options = &gcore.Options{}
+		if err := readFile(optionsFile, body.Options); err != nil {
+			return err
+		}
+	}
+
+	client, err := a.commonClient()
+	if err != nil {
+		return err
+	}
+
+	resource, _, err := client.Resources.Create(ctx, &body)
+	if err != nil {
+		return err
+	}
+
+	return a.printResource(resource)
+}
+
+// updateResource runs "resources update". The update is built from
+// the current state of the resource, so the fields that aren't set by
+// the flags stay unchanged.
+func updateResource(ctx context.Context, a *app, args []string) error {
+	var (
+		originGroup, ssl   int
+		originProtocol     string
+		secondaryHostnames stringsFlag
+		optionsFile        string
+		disableSSL         bool
+	)
+
+	fs := a.flagSet("resources update")
+	fs.IntVar(&originGroup, "origin-group", 0, "ID of the origin group")
+	fs.Var(&secondaryHostnames, "secondary-hostname", "additional domain name replacing the current ones, can be repeated")
+	fs.StringVar(&originProtocol, "origin-protocol", "", "protocol of the requests to the origins: HTTP, HTTPS or MATCH")
+	fs.IntVar(&ssl, "ssl-data", 0, "ID of the SSL certificate, enables HTTPS")
+	fs.BoolVar(&disableSSL, "disable-ssl", false, "disable HTTPS")
+	fs.StringVar(&optionsFile, "options-file", "", "JSON or YAML file with the options to change")
+
+	rest, err := a.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseID(rest[0], "RESOURCE_ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := a.commonClient()
+	if err != nil {
+		return err
+	}
+
+	resource, _, err := client.Resources.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	body := resource.UpdateBody()
+	// Only the options from the file are sent, so the others stay unchanged.
+	body.Options = nil
+
+	if originGroup != 0 {
+		body.OriginGroup = originGroup
+	}
+	if secondaryHostnames != nil {
+		body.SecondaryHostnames = secondaryHostnames
+	}
+	if originProtocol != "" {
+		body.OriginProtocol = originProtocol
+	}
+	if ssl != 0 {
+		enabled := true
+		body.SslData = &ssl
+		body.SslEnabled = &enabled
+	}
+	if disableSSL {
+		disabled := false
+		body.SslEnabled = &disabled
+	}
+	if optionsFile != "" {
+		body.Options = &gcore.Options{}
+		if err = readFile(optionsFile, body.Options); err != nil {
+			return err
+		}
+	}
+
+	resource, _, err = client.Resources.Update(ctx, id, body)
+	if err != nil {
+		return err
+	}
+
+	return a.printResource(resource)
+}
+
+// deleteResource runs "resources delete".
+func deleteResource(ctx context.Context, a *app, args []string) error {
+	client, id, err := a.resourceArgs("resources delete", args)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.Resources.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	return a.printDone("Resource %d has been deleted.", id)
+}
+
+// suspendResource runs "resources suspend".
+func suspendResource(ctx context.Context, a *app, args []string) error {
+	client, id, err := a.resourceArgs("resources suspend", args)
+	if err != nil {
+		return err
+	}
+
+	resource, _, err := client.Resources.Suspend(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.printResource(resource)
+}
+
+// activateResource runs "resources activate".
+func activateResource(ctx context.Context, a *app, args []string) error {
+	client, id, err := a.resourceArgs("resources activate", args)
+	if err != nil {
+		return err
+	}
+
+	resource, _, err := client.Resources.Activate(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.printResource(resource)
+}
+
+// purgeResource runs "resources purge". Paths are purged as exact URLs by
+// default.
+func purgeResource(ctx context.Context, a *app, args []string) error {
+	var all, patterns, wait bool
+
+	fs := a.flagSet("resources purge")
+	fs.BoolVar(&all, "all", false, "purge all cache of the resource")
+	fs.BoolVar(&patterns, "patterns", false, "treat the paths as patterns, e.g. /static/*.css")
+	fs.BoolVar(&wait, "wait", false, "wait until the purge is finished")
+
+	rest, err := a.parse(fs, args, 1, -1)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseID(rest[0], "RESOURCE_ID")
+	if err != nil {
+		return err
+	}
+
+	paths := rest[1:]
+	var request *gcore.PurgeRequest
+	switch {
+	case all && (patterns || len(paths) > 0):
+		return fmt.Errorf("%w: --all doesn't accept paths", errUsage)
+	case all:
+		request = gcore.PurgeAll()
+	case patterns:
+		request = gcore.PurgePatterns(paths...)
+	default:
+		request = gcore.PurgeURLs(paths...)
+	}
+	if err = request.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+
+	client, err := a.commonClient()
+	if err != nil {
+		return err
+	}
+
+	task, _, err := client.Resources.CreatePurge(ctx, id, request)
+	if err != nil {
+		return err
+	}
+
+	if !wait {
+		return a.print(task, []string{"PURGE_ID"}, func() [][]string {
+			return [][]string{{itoa(task.ID)}}
+		})
+	}
+
+	status, err := client.Resources.WaitForPurge(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+
+	return a.printPurgeStatuses(status, status)
+}
+
+// prefetchResource runs "resources prefetch".
+func prefetchResource(ctx context.Context, a *app, args []string) error {
+	rest, err := a.parse(a.flagSet("resources prefetch"), args, 2, -1)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseID(rest[0], "RESOURCE_ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := a.commonClient()
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.Resources.Prefetch(ctx, id, rest[1:]); err != nil {
+		return err
+	}
+
+	return a.printDone("Prefetch of %d paths has been requested.", len(rest)-1)
+}
+
+// getPurgeStatus runs "resources purge-status".
+func getPurgeStatus(ctx context.Context, a *app, args []string) error {
+	rest, err := a.parse(a.flagSet("resources purge-status"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseID(rest[0], "PURGE_ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := a.commonClient()
+	if err != nil {
+		return err
+	}
+
+	status, _, err := client.Resources.GetPurgeStatus(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.printPurgeStatuses(status, status)
+}
+
+// listPurgeStatuses runs "resources purge-history".
+func listPurgeStatuses(ctx context.Context, a *app, args []string) error {
+	var opts gcore.PurgeStatusesOpts
+
+	fs := a.flagSet("resources purge-history")
+	fs.StringVar(&opts.Cname, "cname", "", "domain name of the resource")
+	fs.StringVar(&opts.PurgeType, "purge-type", "", "type of the purges: purge_by_url, purge_by_pattern or purge_all")
+	fs.StringVar(&opts.Status, "status", "", "status of the purges, e.g. \"In progress\"")
+	fs.StringVar(&opts.FromCreated, "from", "", "list purges created since the time")
+	fs.StringVar(&opts.ToCreated, "to", "", "list purges created before the time")
+	fs.IntVar(&opts.Limit, "limit", 0, "maximum number of the purges")
+	fs.IntVar(&opts.Offset, "offset", 0, "number of the purges to skip")
+	if _, err := a.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	client, err := a.commonClient()
+	if err != nil {
+		return err
+	}
+
+	statuses, _, err := client.Resources.PurgeStatuses(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	return a.printPurgeStatuses(statuses, statuses...)
+}
+
+// printPurgeStatuses prints the value with the table of the purges.
+func (a *app) printPurgeStatuses(v interface{}, statuses ...*gcore.PurgeStatus) error {
+	return a.print(v, []string{"ID", "CNAME", "TYPE", "STATUS", "CREATED", "URLS"}, func() [][]string {
+		rows := make([][]string, 0, len(statuses))
+		for _, status := range statuses {
+			created := ""
+			if status.Created != nil {
+				created = status.Created.Format("2006-01-02 15:04:05")
+			}
+			rows = append(rows, []string{
+				itoa(status.ID),
+				status.Resource.Cname,
+				status.PurgeType,
+				status.Status,
+				created,
+				strings.Join(status.URLs, ","),
+			})
+		}
+		return rows
+	})
+}
+
+// resourceArgs parses arguments of the commands that accept only ID of
+// the resource and returns the client.
+func (a *app) resourceArgs(name string, args []string) (*gcore.CommonClient, int, error) {
+	rest, err := a.parse(a.flagSet(name), args, 1, 1)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	id, err := parseID(rest[0], "RESOURCE_ID")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	client, err := a.commonClient()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return client, id, nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+func TestResources(t *testing.T) {
+	server := newTestServer(t)
+	group := server.AddOriginGroup(&gcore.OriginGroup{Name: "origins", Origins: []gcore.Origin{{Source: "origin.example.com"}}})
+
+	optionsFile := filepath.Join(t.TempDir(), "options.yaml")
+	if err := os.WriteFile(optionsFile, []byte("cache_http_headers:\n  enabled: true\n  value: [content-type]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runTest(t, server, "resources", "create", "-o", "json",
+		"--cname", "cdn.example.com", "--origin-group", itoa(group.ID), "--options-file", optionsFile)
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+
+	var created gcore.Resource
+	if err := json.Unmarshal([]byte(stdout), &created); err != nil {
+		t.Fatal(err)
+	}
+	if created.Cname != "cdn.example.com" || created.OriginGroup != group.ID {
+		t.Errorf("unexpected resource: %+v", created)
+	}
+	if created.Options == nil || created.Options.CacheHTTPHeaders == nil {
+		t.Errorf("expected options from the file, got %+v", created.Options)
+	}
+	id := itoa(created.ID)
+
+	code, stdout, _ = runTest(t, server, "resources", "list")
+	if code != exitOK || !strings.Contains(stdout, "cdn.example.com") {
+		t.Errorf("expected the resource in the list, got %d: %q", code, stdout)
+	}
+
+	code, stdout, _ = runTest(t, server, "resources", "list", "--cname", "other.example.com")
+	if code != exitOK || strings.Contains(stdout, "cdn.example.com") {
+		t.Errorf("expected the resource to be filtered out, got %d: %q", code, stdout)
+	}
+
+	code, _, stderr = runTest(t, server, "resources", "update", id, "--secondary-hostname", "www.example.com")
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+	stored, _ := server.Resource(created.ID)
+	if len(stored.SecondaryHostnames) != 1 || stored.Options == nil || stored.Options.CacheHTTPHeaders == nil {
+		t.Errorf("expected secondary hostname and unchanged options, got %+v", stored)
+	}
+
+	code, stdout, _ = runTest(t, server, "resources", "suspend", id)
+	if code != exitOK || !strings.Contains(stdout, "suspended") {
+		t.Errorf("expected the resource to be suspended, got %d: %q", code, stdout)
+	}
+
+	code, stdout, _ = runTest(t, server, "resources", "activate", id)
+	if code != exitOK || !strings.Contains(stdout, "active") {
+		t.Errorf("expected the resource to be active, got %d: %q", code, stdout)
+	}
+
+	code, stdout, _ = runTest(t, server, "resources", "delete", id)
+	if code != exitOK || !strings.Contains(stdout, "has been deleted") {
+		t.Errorf("expected the resource to be deleted, got %d: %q", code, stdout)
+	}
+	if _, ok := server.Resource(created.ID); ok {
+		t.Error("expected the resource to be removed from the server")
+	}
+}
+
+func TestResources_CreateUsage(t *testing.T) {
+	server := newTestServer(t)
+
+	code, _, stderr := runTest(t, server, "resources", "create", "--cname", "cdn.example.com")
+	if code != exitUsage || !strings.Contains(stderr, "--origin-group or --origin") {
+		t.Errorf("expected usage error, got %d: %q", code, stderr)
+	}
+}
+
+func TestResources_Purge(t *testing.T) {
+	server := newTestServer(t)
+	resource := server.AddResource(&gcore.Resource{Cname: "cdn.example.com", OriginGroup: 1})
+	id := itoa(resource.ID)
+
+	code, stdout, stderr := runTest(t, server, "resources", "purge", id, "--patterns", "/static/*", "--wait")
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "purge_by_pattern") || !strings.Contains(stdout, "/static/*") {
+		t.Errorf("unexpected output: %q", stdout)
+	}
+
+	code, stdout, _ = runTest(t, server, "resources", "purge", id, "-o", "json", "/index.html")
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+	var task struct {
+		ID int `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &task); err != nil || task.ID == 0 {
+		t.Fatalf("expected the purge task, got %q: %v", stdout, err)
+	}
+
+	code, stdout, _ = runTest(t, server, "resources", "purge-status", itoa(task.ID))
+	if code != exitOK || !strings.Contains(stdout, "/index.html") {
+		t.Errorf("unexpected purge status, got %d: %q", code, stdout)
+	}
+
+	code, stdout, _ = runTest(t, server, "resources", "purge-history", "--purge-type", "purge_by_url")
+	if code != exitOK || !strings.Contains(stdout, "/index.html") || strings.Contains(stdout, "/static/*") {
+		t.Errorf("unexpected purge history, got %d: %q", code, stdout)
+	}
+
+	code, stdout, _ = runTest(t, server, "resources", "prefetch", id, "/a.js", "/b.js")
+	if code != exitOK || !strings.Contains(stdout, "2 paths") {
+		t.Errorf("unexpected prefetch output, got %d: %q", code, stdout)
+	}
+
+	code, _, stderr = runTest(t, server, "resources", "purge", id, "--all", "/index.html")
+	if code != exitUsage || !strings.Contains(stderr, "--all doesn't accept paths") {
+		t.Errorf("expected usage error, got %d: %q", code, stderr)
+	}
+}
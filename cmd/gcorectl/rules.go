@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// ruleCommands represents commands of the rules group.
+var ruleCommands = map[string]*command{
+	"list": {
+		usage:   "RESOURCE_ID",
+		summary: "list rules of the CDN resource",
+		run:     listRules,
+	},
+	"get": {
+		usage:   "RESOURCE_ID RULE_ID",
+		summary: "show the rule",
+		run:     getRule,
+	},
+	"create": {
+		usage:   "RESOURCE_ID --name NAME --rule PATTERN",
+		summary: "create a rule of the CDN resource",
+		run:     createRule,
+	},
+	"update": {
+		usage:   "RESOURCE_ID RULE_ID",
+		summary: "change the fields of the rule that are set",
+		run:     updateRule,
+	},
+	"delete": {
+		usage:   "RESOURCE_ID RULE_ID",
+		summary: "delete the rule",
+		run:     deleteRule,
+	},
+}
+
+// ruleColumns represents columns of the rules table.
+var ruleColumns = []string{"ID", "NAME", "RULE", "TYPE", "WEIGHT", "ORIGIN_GROUP"}
+
+// printRules prints the value with the table of the rules.
+func (a *app) printRules(v interface{}, rules ...*gcore.Rule) error {
+	return a.print(v, ruleColumns, func() [][]string {
+		rows := make([][]string, 0, len(rules))
+		for _, rule := range rules {
+			originGroup := "-"
+			if rule.OriginGroup != 0 {
+				originGroup = itoa(rule.OriginGroup)
+			}
+			rows = append(rows, []string{
+				itoa(rule.ID),
+				rule.Name,
+				rule.Rule,
+				itoa(rule.RuleType),
+				itoa(rule.Weight),
+				originGroup,
+			})
+		}
+		return rows
+	})
+}
+
+// listRules runs "rules list".
+func listRules(ctx context.Context, a *app, args []string) error {
+	client, ids, err := a.ruleArgs("rules list", args, 1)
+	if err != nil {
+		return err
+	}
+
+	rules, _, err := client.Rules.List(ctx, ids[0])
+	if err != nil {
+		return err
+	}
+
+	return a.printRules(rules, rules...)
+}
+
+// getRule runs "rules get".
+func getRule(ctx context.Context, a *app, args []string) error {
+	client, ids, err := a.ruleArgs("rules get", args, 2)
+	if err != nil {
+		return err
+	}
+
+	rule, _, err := client.Rules.Get(ctx, ids[0], ids[1])
+	if err != nil {
+		return err
+	}
+
+	return a.printRules(rule, rule)
+}
+
+// createRule runs "rules create".
+func createRule(ctx context.Context, a *app, args []string) error {
+	var (
+		body        gcore.CreateRuleBody
+		optionsFile string
+	)
+
+	fs := a.flagSet("rules create")
+	fs.StringVar(&body.Name, "name", "", "name of the rule")
+	fs.StringVar(&body.Rule, "rule", "", "pattern of the paths, e.g. /images/*")
+	fs.IntVar(&body.RuleType, "rule-type", 0, "type of the pattern")
+	fs.StringVar(&optionsFile, "options-file", "", "JSON or YAML file with the options")
+
+	rest, err := a.parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	if body.Name == "" || body.Rule == "" {
+		return fmt.Errorf("%w: --name and --rule are required", errUsage)
+	}
+
+	resourceID, err := parseID(rest[0], "RESOURCE_ID")
+	if err != nil {
+		return err
+	}
+
+	if optionsFile != "" {
+		if err = readFile(optionsFile, &body.Options); err != nil {
+			return err
+		}
+	}
+
+	client, err := a.commonClient()
+	if err != nil {
+		return err
+	}
+
+	rule, _, err := client.Rules.Create(ctx, resourceID, &body)
+	if err != nil {
+		return err
+	}
+
+	return a.printRules(rule, rule)
+}
+
+// updateRule runs "rules update", the fields that aren't set by the flags
+// stay unchanged.
+func updateRule(ctx context.Context, a *app, args []string) error {
+	var (
+		body        gcore.UpdateRuleBody
+		optionsFile string
+	)
+
+	fs := a.flagSet("rules update")
+	name := fs.String("name", "", "name of the rule")
+	rule := fs.String("rule", "", "pattern of the paths, e.g. /images/*")
+	weight := fs.Int("weight", 0, "weight of the rule")
+	originGroup := fs.Int("origin-group", 0, "ID of the origin group")
+	fs.StringVar(&optionsFile, "options-file", "", "JSON or YAML file with the options to change")
+
+	rest, err := a.parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+
+	ids, err := parseIDs(rest, "RESOURCE_ID", "RULE_ID")
+	if err != nil {
+		return err
+	}
+
+	// Only the flags that have been set are sent.
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "name":
+			body.Name = name
+		case "rule":
+			body.Rule = rule
+		case "weight":
+			body.Weight = weight
+		case "origin-group":
+			body.OriginGroup = originGroup
+		}
+	})
+	if optionsFile != "" {
+		body.Options = &gcore.Options{}
+		if err = readFile(optionsFile, body.Options); err != nil {
+			return err
+		}
+	}
+
+	client, err := a.commonClient()
+	if err != nil {
+		return err
+	}
+
+	updated, _, err := client.Rules.Patch(ctx, ids[0], ids[1], &body)
+	if err != nil {
+		return err
+	}
+
+	return a.printRules(updated, updated)
+}
+
+// deleteRule runs "rules delete".
+func deleteRule(ctx context.Context, a *app, args []string) error {
+	client, ids, err := a.ruleArgs("rules delete", args, 2)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.Rules.Delete(ctx, ids[0], ids[1]); err != nil {
+		return err
+	}
+
+	return a.printDone("Rule %d of resource %d has been deleted.", ids[1], ids[0])
+}
+
+// ruleArgs parses arguments of the commands that accept only ID of
+// the resource and optionally ID of the rule and returns the client.
+func (a *app) ruleArgs(name string, args []string, n int) (*gcore.CommonClient, []int, error) {
+	rest, err := a.parse(a.flagSet(name), args, n, n)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids, err := parseIDs(rest, "RESOURCE_ID", "RULE_ID")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := a.commonClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return client, ids, nil
+}
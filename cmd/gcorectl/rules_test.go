@@ -0,0 +1,55 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+func TestRules(t *testing.T) {
+	server := newTestServer(t)
+	resource := server.AddResource(&gcore.Resource{Cname: "cdn.example.com", OriginGroup: 1})
+	resourceID := itoa(resource.ID)
+
+	code, stdout, stderr := runTest(t, server, "rules", "create", resourceID, "--name", "images", "--rule", "/images/*")
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "/images/*") {
+		t.Errorf("unexpected output: %q", stdout)
+	}
+
+	rules, _, err := mustCommonClient(t, server).Rules.List(t.Context(), resource.ID)
+	if err != nil || len(rules) != 1 {
+		t.Fatalf("expected one rule, got %v: %v", rules, err)
+	}
+	ruleID := itoa(rules[0].ID)
+
+	code, stdout, _ = runTest(t, server, "rules", "update", resourceID, ruleID, "--weight", "5")
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+	rule, _ := server.Rule(resource.ID, rules[0].ID)
+	if rule.Weight != 5 || rule.Name != "images" {
+		t.Errorf("expected only the weight to change, got %+v", rule)
+	}
+
+	code, stdout, _ = runTest(t, server, "rules", "list", resourceID)
+	if code != exitOK || !strings.Contains(stdout, "images") {
+		t.Errorf("expected the rule in the list, got %d: %q", code, stdout)
+	}
+
+	code, _, _ = runTest(t, server, "rules", "get", resourceID, ruleID)
+	if code != exitOK {
+		t.Errorf("unexpected exit code %d", code)
+	}
+
+	code, _, _ = runTest(t, server, "rules", "delete", resourceID, ruleID)
+	if code != exitOK {
+		t.Errorf("unexpected exit code %d", code)
+	}
+	if _, ok := server.Rule(resource.ID, rules[0].ID); ok {
+		t.Error("expected the rule to be deleted")
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// serviceCommands represents commands of the services group.
+var serviceCommands = map[string]*command{
+	"list": {
+		usage:   "CLIENT_ID",
+		summary: "list services of the client",
+		run:     listServices,
+	},
+	"update": {
+		usage:   "CLIENT_ID SERVICE_ID [--enabled=true|false] [--status STATUS]",
+		summary: "enable, disable or change status of the service",
+		run:     updateService,
+	},
+}
+
+// printServices prints the value with the table of the services.
+func (a *app) printServices(v interface{}, services ...*gcore.Service) error {
+	return a.print(v, []string{"ID", "NAME", "STATUS", "ENABLED", "START"}, func() [][]string {
+		rows := make([][]string, 0, len(services))
+		for _, service := range services {
+			start := ""
+			if service.Start != nil {
+				start = service.Start.Format("2006-01-02")
+			}
+			rows = append(rows, []string{itoa(service.ID), service.Name, service.Status, yesNo(service.Enabled), start})
+		}
+		return rows
+	})
+}
+
+// listServices runs "services list".
+func listServices(ctx context.Context, a *app, args []string) error {
+	rest, err := a.parse(a.flagSet("services list"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	clientID, err := parseID(rest[0], "CLIENT_ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := a.resellerClient()
+	if err != nil {
+		return err
+	}
+
+	services, _, err := client.Services.List(ctx, clientID)
+	if err != nil {
+		return err
+	}
+
+	return a.printServices(services, services...)
+}
+
+// updateService runs "services update". The update is built from the current
+// state of the service, so the fields that aren't set stay unchanged.
+func updateService(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("services update")
+	enabled := fs.Bool("enabled", false, "enable or disable the service")
+	status := fs.String("status", "", "status of the service, e.g. active or paused")
+
+	rest, err := a.parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+
+	ids, err := parseIDs(rest, "CLIENT_ID", "SERVICE_ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := a.resellerClient()
+	if err != nil {
+		return err
+	}
+
+	services, _, err := client.Services.List(ctx, ids[0])
+	if err != nil {
+		return err
+	}
+
+	var body *gcore.UpdateServiceBody
+	for _, service := range services {
+		if service.ID == ids[1] {
+			body = service.UpdateBody()
+		}
+	}
+	if body == nil {
+		return fmt.Errorf("service %d of client %d not found", ids[1], ids[0])
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "enabled":
+			body.Enabled = *enabled
+		case "status":
+			body.Status = *status
+		}
+	})
+
+	service, _, err := client.Services.Update(ctx, ids[0], ids[1], body)
+	if err != nil {
+		return err
+	}
+
+	return a.printServices(service, service)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+func TestServices(t *testing.T) {
+	server := newTestServer(t)
+	client := server.AddClient(&gcore.ClientAccount{Name: "client"})
+	clientID := itoa(client.ID)
+
+	services, _, err := mustResellerClient(t, server).Services.List(t.Context(), client.ID)
+	if err != nil || len(services) == 0 {
+		t.Fatalf("expected services of the client, got %v: %v", services, err)
+	}
+	service := services[0]
+
+	code, stdout, stderr := runTest(t, server, "services", "list", clientID)
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, service.Name) {
+		t.Errorf("expected the service in the list, got %q", stdout)
+	}
+
+	code, stdout, stderr = runTest(t, server, "services", "update", clientID, itoa(service.ID), "--enabled=false")
+	if code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr)
+	}
+
+	services, _, err = mustResellerClient(t, server).Services.List(t.Context(), client.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, updated := range services {
+		if updated.ID == service.ID && (updated.Enabled || updated.Status != service.Status) {
+			t.Errorf("expected only the service to be disabled, got %+v", updated)
+		}
+	}
+
+	code, _, stderr = runTest(t, server, "services", "update", clientID, "999999")
+	if code != exitError || !strings.Contains(stderr, "not found") {
+		t.Errorf("expected not found error, got %d: %q", code, stderr)
+	}
+}
@@ -2,6 +2,7 @@ package gcore
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 )
 
@@ -24,6 +25,34 @@ type Account struct {
 
 	// Hostname of the edge-server
 	Cname string `json:"cname"`
+
+	// Extra represents fields the library doesn't model yet by their keys.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface,
+// unknown fields are stored in Extra.
+func (a *Account) UnmarshalJSON(b []byte) error {
+	type account Account
+
+	var alias account
+	extra, err := unmarshalWithExtra(b, &alias)
+	if err != nil {
+		return err
+	}
+
+	*a = Account(alias)
+	a.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface,
+// fields from Extra are added unless Account has a field for them.
+func (a Account) MarshalJSON() ([]byte, error) {
+	type account Account
+
+	return marshalWithExtra(account(a), a.Extra)
 }
 
 // User represents G-Core's user.
@@ -38,12 +67,68 @@ type User struct {
 	Phone    string   `json:"phone"`
 	Reseller int      `json:"reseller,omitempty"`
 	Groups   []*Group `json:"groups"`
+
+	// Extra represents fields the library doesn't model yet by their keys.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface,
+// unknown fields are stored in Extra.
+func (u *User) UnmarshalJSON(b []byte) error {
+	type user User
+
+	var alias user
+	extra, err := unmarshalWithExtra(b, &alias)
+	if err != nil {
+		return err
+	}
+
+	*u = User(alias)
+	u.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface,
+// fields from Extra are added unless User has a field for them.
+func (u User) MarshalJSON() ([]byte, error) {
+	type user User
+
+	return marshalWithExtra(user(u), u.Extra)
 }
 
 // Group represents G-Core's users group.
 type Group struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
+
+	// Extra represents fields the library doesn't model yet by their keys.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface,
+// unknown fields are stored in Extra.
+func (g *Group) UnmarshalJSON(b []byte) error {
+	type group Group
+
+	var alias group
+	extra, err := unmarshalWithExtra(b, &alias)
+	if err != nil {
+		return err
+	}
+
+	*g = Group(alias)
+	g.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface,
+// fields from Extra are added unless Group has a field for them.
+func (g Group) MarshalJSON() ([]byte, error) {
+	type group Group
+
+	return marshalWithExtra(group(g), g.Extra)
 }
 
 // Details method returns details info for the account.
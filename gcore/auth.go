@@ -0,0 +1,217 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// tokenRefreshMargin represents the time before token expiration when
+// client gets a new token.
+const tokenRefreshMargin = time.Minute
+
+// noAuthContextKey marks requests that must be sent without authentication
+// handling, e.g. the sign in request itself.
+type noAuthContextKey struct{}
+
+var (
+	// errNoCredentialsProvider is returned when the client is asked to get
+	// a new token but it wasn't authenticated with credentials before.
+	errNoCredentialsProvider = errors.New("gcore: no credentials provider to get a new token")
+
+	// errTokenNotRenewed is returned when the credentials provider returns
+	// the same token that has been rejected.
+	errTokenNotRenewed = errors.New("gcore: credentials provider returned the rejected token")
+)
+
+// expiresWithin checks if the token expires within given duration.
+// Tokens without expiration date never expire.
+func (t *Token) expiresWithin(d time.Duration) bool {
+	if t.Expire == nil || t.Expire.IsZero() {
+		return false
+	}
+
+	return t.Expire.Before(time.Now().UTC().Add(d))
+}
+
+// setAuthHeader sets Authorization header of the request for given token.
+func setAuthHeader(req *http.Request, token *Token) {
+	if token == nil {
+		return
+	}
+
+	scheme := token.Scheme
+	if scheme == "" {
+		scheme = TokenAuthScheme
+	}
+
+	req.Header.Set("Authorization", scheme+" "+token.Value)
+}
+
+// currentToken returns the token that client uses at the moment.
+func (c *Client) currentToken() *Token {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.Token
+}
+
+// authState returns the client's token and provider of its credentials.
+func (c *Client) authState() (*Token, CredentialsProvider) {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.Token, c.credentials
+}
+
+// setToken replaces the client's token.
+func (c *Client) setToken(token *Token) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.Token = token
+}
+
+// signIn gets a new token with the credentials of given provider. Permanent
+// API tokens are used as is. It must be called with the client's authMu
+// held, the client's lock isn't held during the request, so other requests
+// aren't blocked by the sign in.
+func (c *Client) signIn(ctx context.Context, provider CredentialsProvider) (*Token, error) {
+	if provider == nil {
+		return nil, errNoCredentialsProvider
+	}
+
+	creds, err := provider.Retrieve(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if creds.APIToken != "" {
+		return &Token{
+			Value:  creds.APIToken,
+			Scheme: APIKeyAuthScheme,
+		}, nil
+	}
+
+	authOpts := AuthOptions{
+		Username: creds.Username,
+		Password: creds.Password,
+	}
+
+	req, err := c.newRequest(context.WithValue(ctx, noAuthContextKey{}, true),
+		http.MethodPost, loginURL, authOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &Token{}
+	if _, err = c.Do(req, token); err != nil {
+		return nil, err
+	}
+
+	if info := CallInfoFromContext(ctx); info != nil {
+		info.TokenRefreshes++
+	}
+
+	return token, nil
+}
+
+// freshToken returns the client's token, the token is renewed if it's about
+// to expire and the client remembers credentials.
+func (c *Client) freshToken(ctx context.Context) (*Token, error) {
+	if token, provider := c.authState(); !needsRefresh(token, provider) {
+		return token, nil
+	}
+
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	// Another request may have renewed the token while this one waited.
+	token, provider := c.authState()
+	if !needsRefresh(token, provider) {
+		return token, nil
+	}
+
+	c.logger.DebugContext(ctx, "gcore: token is about to expire, getting a new one")
+	token, err := c.signIn(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+	c.setToken(token)
+
+	return token, nil
+}
+
+// needsRefresh reports whether the token has to be renewed with
+// the credentials of given provider.
+func needsRefresh(token *Token, provider CredentialsProvider) bool {
+	return provider != nil && (token == nil || token.expiresWithin(tokenRefreshMargin))
+}
+
+// reauthenticate gets a new token after the stale one has been rejected.
+// If another request has already renewed the token, it's returned as is,
+// so concurrent requests don't sign in several times.
+func (c *Client) reauthenticate(ctx context.Context, stale *Token) (*Token, error) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	token, provider := c.authState()
+	if token != stale {
+		return token, nil
+	}
+
+	c.logger.DebugContext(ctx, "gcore: token has been rejected, getting a new one")
+	token, err := c.signIn(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+	c.setToken(token)
+
+	if token.Value == stale.Value {
+		return nil, errTokenNotRenewed
+	}
+
+	return token, nil
+}
+
+// sendWithAuth executes a single attempt of the request with a valid token.
+// If the token is rejected with 401 response, the client authenticates again
+// and repeats the request once.
+func (c *Client) sendWithAuth(req *http.Request) (*http.Response, error) {
+	if req.Context().Value(noAuthContextKey{}) != nil {
+		return c.send(req)
+	}
+
+	token, err := c.freshToken(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	setAuthHeader(req, token)
+
+	resp, err := c.send(req)
+	if !IsUnauthorized(err) || token == nil {
+		return resp, err
+	}
+
+	newToken, authErr := c.reauthenticate(req.Context(), token)
+	if authErr != nil {
+		if authErr != errNoCredentialsProvider && authErr != errTokenNotRenewed {
+			c.logger.ErrorContext(req.Context(), "gcore: failed to get a new token", slog.Any("error", authErr))
+		}
+
+		return resp, err
+	}
+
+	if req.GetBody != nil {
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return resp, err
+		}
+		req.Body = body
+	}
+	setAuthHeader(req, newToken)
+
+	return c.send(req)
+}
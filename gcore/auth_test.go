@@ -0,0 +1,192 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testAuthServer represents API server that issues a new token on every
+// sign in and accepts only the last issued one.
+type testAuthServer struct {
+	server  *httptest.Server
+	mu      sync.Mutex
+	token   string
+	expire  time.Time
+	signIns int32
+}
+
+func newTestAuthServer(tokenTTL time.Duration) *testAuthServer {
+	s := &testAuthServer{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(loginURL, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&s.signIns, 1)
+
+		s.mu.Lock()
+		s.token = fmt.Sprintf("token-%d", n)
+		s.expire = time.Now().UTC().Add(tokenTTL)
+		token, expire := s.token, s.expire
+		s.mu.Unlock()
+
+		// Slow sign in makes concurrent requests pile up.
+		time.Sleep(10 * time.Millisecond)
+
+		fmt.Fprintf(w, `{"token": "%s", "expire": "%s"}`, token, expire.Format(dateFormat))
+	})
+	mux.HandleFunc(resourcesURL, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		valid := r.Header.Get("Authorization") == "Token "+s.token
+		s.mu.Unlock()
+
+		if !valid {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message": "Invalid token."}`)
+			return
+		}
+
+		fmt.Fprint(w, `[]`)
+	})
+
+	s.server = httptest.NewServer(mux)
+
+	return s
+}
+
+// rotate invalidates the current token on the server side.
+func (s *testAuthServer) rotate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.token = "rotated"
+}
+
+func (s *testAuthServer) url() *url.URL {
+	u, _ := url.Parse(s.server.URL)
+	return u
+}
+
+func TestClient_ReauthenticateOnUnauthorized(t *testing.T) {
+	authServer := newTestAuthServer(time.Hour)
+	defer authServer.server.Close()
+
+	client := NewCommonClient()
+	client.BaseURL = authServer.url()
+
+	if err := client.Authenticate(context.Background(), TestFakeAuthOptions); err != nil {
+		t.Fatal(err)
+	}
+
+	authServer.rotate()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.Resources.List(context.Background()); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if n := atomic.LoadInt32(&authServer.signIns); n != 2 {
+		t.Errorf("Expected: %d sign ins, got %d", 2, n)
+	}
+}
+
+func TestClient_RefreshTokenBeforeExpiration(t *testing.T) {
+	// The token expires within refresh margin right after sign in.
+	authServer := newTestAuthServer(tokenRefreshMargin / 2)
+	defer authServer.server.Close()
+
+	client := NewCommonClient()
+	client.BaseURL = authServer.url()
+
+	if err := client.Authenticate(context.Background(), TestFakeAuthOptions); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := client.Resources.List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := atomic.LoadInt32(&authServer.signIns); n != 2 {
+		t.Errorf("Expected: %d sign ins, got %d", 2, n)
+	}
+
+	if client.Token.Value != "token-2" {
+		t.Errorf("Expected: %s, got %s", "token-2", client.Token.Value)
+	}
+}
+
+func TestClient_NoReauthenticateWithoutCredentials(t *testing.T) {
+	authServer := newTestAuthServer(time.Hour)
+	defer authServer.server.Close()
+
+	client := NewCommonClient()
+	client.BaseURL = authServer.url()
+	client.Token = &Token{Value: "whatever"}
+
+	_, _, err := client.Resources.List(context.Background())
+	if !IsUnauthorized(err) {
+		t.Fatalf("expected unauthorized error, got %v", err)
+	}
+
+	if n := atomic.LoadInt32(&authServer.signIns); n != 0 {
+		t.Errorf("Expected: %d sign ins, got %d", 0, n)
+	}
+}
+
+func TestClient_SignInDoesNotBlockRequests(t *testing.T) {
+	client := NewCommonClient()
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	provider := CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		close(entered)
+		<-release
+		return &Credentials{APIToken: "token"}, nil
+	})
+
+	done := make(chan error)
+	go func() {
+		done <- client.AuthenticateWith(context.Background(), provider)
+	}()
+	<-entered
+
+	// The sign in is in progress, building a request must not wait for it.
+	requestBuilt := make(chan struct{})
+	go func() {
+		_, _ = client.NewRequest(context.Background(), http.MethodGet, resourcesURL, nil)
+		close(requestBuilt)
+	}()
+
+	select {
+	case <-requestBuilt:
+	case <-time.After(time.Second):
+		t.Error("request has been blocked by the sign in")
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	if token := client.currentToken(); token == nil || token.Value != "token" {
+		t.Errorf("unexpected token: %+v", token)
+	}
+}
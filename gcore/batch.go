@@ -0,0 +1,285 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+)
+
+// The default configuration of PurgeBatch and PrefetchBatch.
+const (
+	// DefaultBatchChunkSize represents maximum number of paths the API
+	// accepts in a single purge or prefetch request.
+	DefaultBatchChunkSize = 100
+
+	// defaultBatchConcurrency represents default number of chunks
+	// that are sent at the same time.
+	defaultBatchConcurrency = 4
+)
+
+// BatchOption configures PurgeBatch and PrefetchBatch.
+type BatchOption func(*batchOptions)
+
+// batchOptions represents configuration of the batch requests.
+type batchOptions struct {
+	chunkSize   int
+	concurrency int
+}
+
+// WithChunkSize sets maximum number of paths sent in a single request,
+// DefaultBatchChunkSize is used by default.
+func WithChunkSize(size int) BatchOption {
+	return func(o *batchOptions) {
+		o.chunkSize = size
+	}
+}
+
+// WithConcurrency sets maximum number of requests sent at the same time,
+// 4 requests are used by default. The requests are still limited by
+// the client's RateLimiter.
+func WithConcurrency(concurrency int) BatchOption {
+	return func(o *batchOptions) {
+		o.concurrency = concurrency
+	}
+}
+
+// newBatchOptions returns a reference to batchOptions configured with
+// given options.
+func newBatchOptions(opts ...BatchOption) *batchOptions {
+	o := &batchOptions{
+		chunkSize:   DefaultBatchChunkSize,
+		concurrency: defaultBatchConcurrency,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.chunkSize <= 0 {
+		o.chunkSize = DefaultBatchChunkSize
+	}
+	if o.concurrency <= 0 {
+		o.concurrency = 1
+	}
+
+	return o
+}
+
+// BatchChunk represents result of a single request of the batch.
+type BatchChunk struct {
+	// Paths represents paths sent in the request.
+	Paths []string
+
+	// Task represents purge created by the request, it's nil for prefetch
+	// and failed requests.
+	Task *PurgeTask
+
+	// Err represents error of the request.
+	Err error
+}
+
+// BatchResult represents aggregated result of the batch requests.
+type BatchResult struct {
+	// Chunks represents results of the requests in the order of the paths.
+	Chunks []*BatchChunk
+}
+
+// Succeeded returns chunks that have been sent successfully.
+func (r *BatchResult) Succeeded() []*BatchChunk {
+	chunks := make([]*BatchChunk, 0, len(r.Chunks))
+	for _, chunk := range r.Chunks {
+		if chunk.Err == nil {
+			chunks = append(chunks, chunk)
+		}
+	}
+
+	return chunks
+}
+
+// Failed returns chunks that have failed.
+func (r *BatchResult) Failed() []*BatchChunk {
+	chunks := make([]*BatchChunk, 0)
+	for _, chunk := range r.Chunks {
+		if chunk.Err != nil {
+			chunks = append(chunks, chunk)
+		}
+	}
+
+	return chunks
+}
+
+// Err returns errors of the failed chunks joined together or nil
+// if all chunks have succeeded.
+func (r *BatchResult) Err() error {
+	var errs []error
+	for _, chunk := range r.Failed() {
+		if len(chunk.Paths) == 0 {
+			errs = append(errs, chunk.Err)
+			continue
+		}
+		errs = append(errs, fmt.Errorf("chunk of %d paths starting with %q: %w",
+			len(chunk.Paths), chunk.Paths[0], chunk.Err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// PurgeBatch method purges cache of the resource by given request splitting
+// its paths into chunks the API accepts. Paths are normalized and
+// deduplicated before sending. The result lists all chunks, the returned
+// error is BatchResult.Err.
+func (s *ResourcesService) PurgeBatch(ctx context.Context, resourceID int, body *PurgeRequest, opts ...BatchOption) (*BatchResult, error) {
+	if body == nil {
+		return nil, errNilBody
+	}
+
+	if body.Mode == PurgeModeAll {
+		if err := body.Validate(); err != nil {
+			return nil, err
+		}
+
+		task, _, err := s.CreatePurge(ctx, resourceID, body)
+		result := &BatchResult{Chunks: []*BatchChunk{{Task: task, Err: err}}}
+
+		return result, result.Err()
+	}
+
+	// Paths are validated after normalization, so the request of only
+	// empty paths fails instead of sending nothing.
+	paths := NormalizePaths(body.Paths)
+	if err := (&PurgeRequest{Mode: body.Mode, Paths: paths}).Validate(); err != nil {
+		return nil, err
+	}
+
+	result := runBatch(ctx, paths, newBatchOptions(opts...), func(chunk *BatchChunk) {
+		chunk.Task, _, chunk.Err = s.CreatePurge(ctx, resourceID, &PurgeRequest{
+			Mode:  body.Mode,
+			Paths: chunk.Paths,
+		})
+	})
+
+	return result, result.Err()
+}
+
+// PrefetchBatch method pre-loads objects from given paths to CDN servers cache
+// splitting the paths into chunks the API accepts. Paths are normalized and
+// deduplicated before sending. The result lists all chunks, the returned
+// error is BatchResult.Err.
+func (s *ResourcesService) PrefetchBatch(ctx context.Context, resourceID int, paths []string, opts ...BatchOption) (*BatchResult, error) {
+	paths = NormalizePaths(paths)
+	if len(paths) == 0 {
+		return nil, errors.New("gcore: prefetch requires at least one path")
+	}
+
+	result := runBatch(ctx, paths, newBatchOptions(opts...), func(chunk *BatchChunk) {
+		_, chunk.Err = s.Prefetch(ctx, resourceID, chunk.Paths)
+	})
+
+	return result, result.Err()
+}
+
+// runBatch splits paths into chunks and calls send for every chunk with
+// bounded concurrency. Chunks that haven't been sent before the context is
+// done fail with the context error.
+func runBatch(ctx context.Context, paths []string, o *batchOptions, send func(chunk *BatchChunk)) *BatchResult {
+	result := &BatchResult{}
+	for start := 0; start < len(paths); start += o.chunkSize {
+		end := start + o.chunkSize
+		if end > len(paths) {
+			end = len(paths)
+		}
+		result.Chunks = append(result.Chunks, &BatchChunk{Paths: paths[start:end]})
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, o.concurrency)
+
+	for _, chunk := range result.Chunks {
+		select {
+		case <-ctx.Done():
+			chunk.Err = ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(chunk *BatchChunk) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			send(chunk)
+		}(chunk)
+	}
+
+	wg.Wait()
+
+	return result
+}
+
+// NormalizePaths returns paths without duplicates in their original order.
+// Scheme and host of the full URLs are removed, a leading slash is added
+// if it's missing, fragments are removed and empty paths are skipped.
+// Query strings are kept since they're a part of the cache key.
+func NormalizePaths(paths []string) []string {
+	seen := make(map[string]struct{}, len(paths))
+	normalized := make([]string, 0, len(paths))
+
+	for _, p := range paths {
+		p = normalizePath(p)
+		if p == "" {
+			continue
+		}
+
+		if _, ok := seen[p]; ok {
+			continue
+		}
+		seen[p] = struct{}{}
+		normalized = append(normalized, p)
+	}
+
+	return normalized
+}
+
+// normalizePath returns normalized path or an empty string if there is
+// no path.
+func normalizePath(p string) string {
+	p = strings.TrimSpace(p)
+	if p == "" {
+		return ""
+	}
+
+	if u, err := url.Parse(p); err == nil && u.Scheme != "" && u.Host != "" {
+		p = u.EscapedPath()
+		if u.RawQuery != "" {
+			p += "?" + u.RawQuery
+		}
+	} else if i := strings.IndexByte(p, '#'); i >= 0 {
+		p = p[:i]
+	}
+
+	if p == "" {
+		return "/"
+	}
+
+	query := ""
+	if i := strings.IndexByte(p, '?'); i >= 0 {
+		p, query = p[:i], p[i:]
+	}
+
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+
+	// Collapse duplicate slashes and dot segments but keep the trailing slash.
+	cleaned := path.Clean(p)
+	if strings.HasSuffix(p, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+
+	return cleaned + query
+}
@@ -0,0 +1,261 @@
+package gcore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	th "github.com/dstdfx/go-gcore/gcore/internal/testhelper"
+)
+
+func TestNormalizePaths(t *testing.T) {
+	paths := []string{
+		" /static/app.css ",
+		"static/app.css",
+		"https://cdn.site.com/static/app.css",
+		"//static//app.css",
+		"/static/app.css#top",
+		"/static/app.css?v=1",
+		"https://cdn.site.com/static/app.css?v=1#top",
+		"/static/img/",
+		"/static/./img/",
+		"/static/*.js",
+		"",
+		"https://cdn.site.com",
+	}
+
+	expected := []string{
+		"/static/app.css",
+		"/static/app.css?v=1",
+		"/static/img/",
+		"/static/*.js",
+		"/",
+	}
+
+	if got := NormalizePaths(paths); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected: %q, got %q", expected, got)
+	}
+}
+
+func TestResourcesService_PurgeBatch(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	var (
+		mu          sync.Mutex
+		received    []string
+		inFlight    int32
+		maxInFlight int32
+		taskID      int32
+	)
+
+	testEnv.Mux.HandleFunc(fmt.Sprintf(resourcePurgeURL, testGetResourceExpected.ID), func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+
+		var body struct {
+			URLs []string `json:"urls"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("unable to decode the request body: %v", err)
+		}
+
+		if len(body.URLs) > 10 {
+			t.Errorf("chunk of %d paths exceeds the limit", len(body.URLs))
+		}
+
+		mu.Lock()
+		received = append(received, body.URLs...)
+		mu.Unlock()
+
+		// Slow requests make chunks run at the same time.
+		time.Sleep(5 * time.Millisecond)
+
+		if body.URLs[0] == "/file-20" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"message": "Invalid URL."}`)
+			return
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id": %d}`, atomic.AddInt32(&taskID, 1))
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	var paths []string
+	for i := 0; i < 45; i++ {
+		paths = append(paths, fmt.Sprintf("/file-%d", i), fmt.Sprintf("file-%d", i))
+	}
+
+	result, err := client.Resources.PurgeBatch(context.Background(), testGetResourceExpected.ID,
+		PurgeURLs(paths...), WithChunkSize(10), WithConcurrency(2))
+	if !IsValidation(err) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+
+	if len(result.Chunks) != 5 {
+		t.Fatalf("Expected: %d chunks, got %d", 5, len(result.Chunks))
+	}
+
+	if len(result.Succeeded()) != 4 {
+		t.Errorf("Expected: %d succeeded chunks, got %d", 4, len(result.Succeeded()))
+	}
+
+	failed := result.Failed()
+	if len(failed) != 1 || failed[0].Paths[0] != "/file-20" || failed[0].Task != nil {
+		t.Errorf("unexpected failed chunks: %+v", failed)
+	}
+
+	for _, chunk := range result.Succeeded() {
+		if chunk.Task == nil || chunk.Task.ID == 0 {
+			t.Errorf("purge task is missing in the chunk %+v", chunk)
+		}
+	}
+
+	if len(received) != 45 {
+		t.Errorf("Expected: %d purged paths, got %d", 45, len(received))
+	}
+
+	if maxInFlight > 2 {
+		t.Errorf("Expected: at most %d concurrent requests, got %d", 2, maxInFlight)
+	}
+}
+
+func TestResourcesService_PurgeBatch_All(t *testing.T) {
+	endpointCalled := false
+
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	handleOpts := &th.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         fmt.Sprintf(resourcePurgeURL, testGetResourceExpected.ID),
+		RawResponse: testCreatePurgeRawResponse,
+		RawRequest:  `{"paths": []}`,
+		Method:      http.MethodPost,
+		Status:      http.StatusCreated,
+		CallFlag:    &endpointCalled,
+	}
+
+	th.HandleReqWithBody(t, handleOpts)
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	result, err := client.Resources.PurgeBatch(context.Background(), testGetResourceExpected.ID, PurgeAll())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !endpointCalled {
+		t.Fatal("didn't purge cache")
+	}
+
+	if len(result.Chunks) != 1 || result.Chunks[0].Task.ID != 1234 {
+		t.Errorf("unexpected chunks: %+v", result.Chunks)
+	}
+}
+
+func TestResourcesService_PurgeBatch_EmptyPaths(t *testing.T) {
+	endpointCalled := false
+
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testEnv.Mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		endpointCalled = true
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	result, err := client.Resources.PurgeBatch(context.Background(), testGetResourceExpected.ID, PurgeURLs(" ", ""))
+	if err == nil || result != nil {
+		t.Errorf("expected error of the paths that are empty after normalization, got %+v", result)
+	}
+
+	if endpointCalled {
+		t.Error("request of empty paths has been sent")
+	}
+}
+
+func TestResourcesService_PrefetchBatch(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	var (
+		mu       sync.Mutex
+		received []string
+	)
+
+	testEnv.Mux.HandleFunc(fmt.Sprintf(resourcePrefetchURL, testGetResourceExpected.ID), func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Paths []string `json:"paths"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("unable to decode the request body: %v", err)
+		}
+
+		mu.Lock()
+		received = append(received, body.Paths...)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	var paths []string
+	for i := 0; i < 250; i++ {
+		paths = append(paths, fmt.Sprintf("/file-%d", i%120))
+	}
+
+	result, err := client.Resources.PrefetchBatch(context.Background(), testGetResourceExpected.ID, paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Chunks) != 2 {
+		t.Errorf("Expected: %d chunks, got %d", 2, len(result.Chunks))
+	}
+
+	if len(received) != 120 {
+		t.Errorf("Expected: %d prefetched paths, got %d", 120, len(received))
+	}
+}
+
+func TestResourcesService_PrefetchBatch_Canceled(t *testing.T) {
+	client := NewCommonClient()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := client.Resources.PrefetchBatch(ctx, testGetResourceExpected.ID, []string{"/a", "/b"}, WithChunkSize(1))
+	if err == nil {
+		t.Fatal("expected error for canceled context")
+	}
+
+	if len(result.Failed()) != 2 {
+		t.Errorf("Expected: %d failed chunks, got %d", 2, len(result.Failed()))
+	}
+}
@@ -0,0 +1,123 @@
+package gcore
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The list of the service names returned by CallInfo.
+const (
+	ServiceAuth            = "Auth"
+	ServiceAccount         = "Account"
+	ServiceResources       = "Resources"
+	ServiceRules           = "Rules"
+	ServiceOriginGroups    = "OriginGroups"
+	ServiceCertificates    = "Certificates"
+	ServiceClients         = "Clients"
+	ServiceServices        = "Services"
+	ServiceGeoRestrictions = "GeoRestrictions"
+	ServiceUnknown         = "Unknown"
+)
+
+// callInfoContextKey is used to store *CallInfo in the request context.
+type callInfoContextKey struct{}
+
+// CallInfo describes an API call made by Client. Client puts it into the
+// request context before passing the request to the middlewares and updates
+// it while the call is in progress, so middlewares can inspect it after
+// the next RoundTripFunc returns.
+type CallInfo struct {
+	// Service represents name of the service the call belongs to,
+	// e.g. "Resources" or "Rules".
+	Service string
+
+	// Endpoint represents path of the request with IDs replaced by
+	// "{id}" placeholders, e.g. "/resources/{id}/rules/{id}".
+	Endpoint string
+
+	// ResourceIDs represents IDs from the request path in order.
+	ResourceIDs []int
+
+	// Attempts represents number of HTTP requests sent for the call
+	// including the ones resent after the token has been rejected.
+	Attempts int
+
+	// Retries represents number of times the call has been retried
+	// according to the client's retry policy.
+	Retries int
+
+	// RateLimitWait represents total time the call waited for the client's
+	// rate limiter.
+	RateLimitWait time.Duration
+
+	// TokenRefreshes represents number of times the client got a new token
+	// during the call.
+	TokenRefreshes int
+}
+
+// CallInfoFromContext returns *CallInfo of the API call from given
+// request context or nil if there is none.
+func CallInfoFromContext(ctx context.Context) *CallInfo {
+	info, _ := ctx.Value(callInfoContextKey{}).(*CallInfo)
+
+	return info
+}
+
+// withCallInfo returns copy of the request with a new *CallInfo
+// in its context.
+func withCallInfo(req *http.Request) (*http.Request, *CallInfo) {
+	info := newCallInfo(req.URL.Path)
+
+	return req.WithContext(context.WithValue(req.Context(), callInfoContextKey{}, info)), info
+}
+
+// newCallInfo returns a reference to CallInfo for given request path.
+func newCallInfo(path string) *CallInfo {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	info := &CallInfo{}
+	for i, segment := range segments {
+		if id, err := strconv.Atoi(segment); err == nil {
+			info.ResourceIDs = append(info.ResourceIDs, id)
+			segments[i] = "{id}"
+		}
+	}
+
+	info.Endpoint = "/" + strings.Join(segments, "/")
+	info.Service = serviceName(segments)
+
+	return info
+}
+
+// serviceName returns name of the service by given path segments.
+func serviceName(segments []string) string {
+	has := func(i int, value string) bool {
+		return len(segments) > i && segments[i] == value
+	}
+
+	switch {
+	case has(0, "auth"):
+		return ServiceAuth
+	case has(0, "resources") && has(2, "rules"):
+		return ServiceRules
+	case has(0, "resources"), has(0, "purge_statuses"):
+		return ServiceResources
+	case has(0, "originGroups"):
+		return ServiceOriginGroups
+	case has(0, "sslData"):
+		return ServiceCertificates
+	case has(0, "clients") && has(1, "me"):
+		return ServiceAccount
+	case has(0, "clients") && has(2, "services"):
+		return ServiceServices
+	case has(0, "clients"), has(0, "users"):
+		return ServiceClients
+	case has(0, "admin"):
+		return ServiceGeoRestrictions
+	}
+
+	return ServiceUnknown
+}
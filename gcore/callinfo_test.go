@@ -0,0 +1,143 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	th "github.com/dstdfx/go-gcore/gcore/internal/testhelper"
+)
+
+func TestNewCallInfo(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected *CallInfo
+	}{
+		{
+			path:     loginURL,
+			expected: &CallInfo{Service: ServiceAuth, Endpoint: loginURL},
+		},
+		{
+			path: fmt.Sprintf(ruleURL, 10, 20),
+			expected: &CallInfo{
+				Service:     ServiceRules,
+				Endpoint:    "/resources/{id}/rules/{id}",
+				ResourceIDs: []int{10, 20},
+			},
+		},
+		{
+			path: fmt.Sprintf(resourcePurgeURL, 10),
+			expected: &CallInfo{
+				Service:     ServiceResources,
+				Endpoint:    "/resources/{id}/purge",
+				ResourceIDs: []int{10},
+			},
+		},
+		{
+			path: fmt.Sprintf(purgeStatusURL, 5),
+			expected: &CallInfo{
+				Service:     ServiceResources,
+				Endpoint:    "/purge_statuses/{id}",
+				ResourceIDs: []int{5},
+			},
+		},
+		{
+			path:     accountDetailsURL,
+			expected: &CallInfo{Service: ServiceAccount, Endpoint: accountDetailsURL},
+		},
+		{
+			path: fmt.Sprintf(serviceUpdateURL, 1, 2),
+			expected: &CallInfo{
+				Service:     ServiceServices,
+				Endpoint:    "/clients/{id}/services/{id}",
+				ResourceIDs: []int{1, 2},
+			},
+		},
+		{
+			path:     resellUsersURL,
+			expected: &CallInfo{Service: ServiceClients, Endpoint: resellUsersURL},
+		},
+		{
+			path:     certificatesURL,
+			expected: &CallInfo{Service: ServiceCertificates, Endpoint: certificatesURL},
+		},
+		{
+			path:     geoRestrictionsListRegionsURL,
+			expected: &CallInfo{Service: ServiceGeoRestrictions, Endpoint: geoRestrictionsListRegionsURL},
+		},
+		{
+			path:     originGroupsURL,
+			expected: &CallInfo{Service: ServiceOriginGroups, Endpoint: originGroupsURL},
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := newCallInfo(tc.path); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%s: Expected: %+v, got %+v", tc.path, tc.expected, got)
+		}
+	}
+}
+
+func TestClient_Do_CallInfo(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	calls := 0
+	testEnv.Mux.HandleFunc(fmt.Sprintf(resourceURL, 7), func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, testGetResourceRawResponse)
+	})
+
+	var info *CallInfo
+	capture := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			info = CallInfoFromContext(req.Context())
+			return resp, err
+		}
+	}
+
+	client, err := NewCommon(
+		WithBaseURL(testEnv.Server.URL),
+		WithCredentials(NewStaticCredentials("whatever", "whatever")),
+		WithRetryPolicy(testRetryPolicy()),
+		WithRateLimiter(NewTokenBucket(1000, 10)),
+		WithMiddleware(capture),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = client.Resources.Get(context.Background(), 7); err != nil {
+		t.Fatal(err)
+	}
+
+	if info == nil {
+		t.Fatal("call info is missing in the request context")
+	}
+
+	if info.Service != ServiceResources || info.Endpoint != "/resources/{id}" {
+		t.Errorf("unexpected call info: %+v", info)
+	}
+
+	if info.Attempts != 2 {
+		t.Errorf("Expected: %d attempts, got %d", 2, info.Attempts)
+	}
+
+	if info.Retries != 1 {
+		t.Errorf("Expected: %d retries, got %d", 1, info.Retries)
+	}
+
+	// The fake token is already expired, so the client gets a new one
+	// before every attempt.
+	if info.TokenRefreshes != 2 {
+		t.Errorf("Expected: %d token refreshes, got %d", 2, info.TokenRefreshes)
+	}
+}
@@ -0,0 +1,91 @@
+// Package cassette records interactions with G-Core API to files and replays
+// them in tests, so integration tests run deterministically without access
+// to the API:
+//
+//	recorder, err := cassette.New("testdata/resources.json", cassette.ModeAuto)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer recorder.Stop()
+//
+//	client := gcore.NewCommonClientWithCustomHTTP(&http.Client{Transport: recorder})
+//
+// Tokens, passwords and private keys are scrubbed before interactions are
+// written. Replayed requests are matched by method, path, query and
+// normalized JSON body, requests without a matching interaction fail.
+package cassette
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Version represents version of the cassette format.
+const Version = 1
+
+// Cassette represents recorded interactions.
+type Cassette struct {
+	Version      int            `json:"version"`
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction represents request and the response to it.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request represents recorded request.
+type Request struct {
+	Method string `json:"method"`
+
+	// URL represents path of the request with its query, the host isn't
+	// recorded, so the cassette can be replayed against any base URL.
+	URL string `json:"url"`
+
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response represents recorded response.
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Load reads the cassette from the file.
+func Load(path string) (*Cassette, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cassette{}
+	if err = json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("cassette: unable to parse %s: %w", path, err)
+	}
+
+	if c.Version != Version {
+		return nil, fmt.Errorf("cassette: unsupported version %d of %s", c.Version, path)
+	}
+
+	return c, nil
+}
+
+// Save writes the cassette to the file creating its directory if needed.
+func (c *Cassette) Save(path string) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
@@ -0,0 +1,54 @@
+package cassette
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCassette_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "cassette.json")
+
+	expected := &Cassette{
+		Version: Version,
+		Interactions: []*Interaction{{
+			Request: Request{
+				Method: http.MethodGet,
+				URL:    "/resources?limit=10",
+				Header: http.Header{"Accept": {"application/json"}},
+			},
+			Response: Response{
+				StatusCode: http.StatusOK,
+				Body:       `[]`,
+			},
+		}},
+	}
+
+	if err := expected.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestLoad_UnsupportedVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := os.WriteFile(path, []byte(`{"version": 2, "interactions": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Load(path)
+	if err == nil || !strings.Contains(err.Error(), "unsupported version 2") {
+		t.Errorf("expected unsupported version error, got: %v", err)
+	}
+}
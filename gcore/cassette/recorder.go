@@ -0,0 +1,287 @@
+package cassette
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Mode represents mode of the recorder.
+type Mode int
+
+// The list of the recorder modes.
+const (
+	// ModeReplay replays interactions of the existing cassette.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends requests to the API and records the interactions,
+	// the existing cassette is overwritten on Stop.
+	ModeRecord
+
+	// ModeAuto replays the cassette if its file exists and records it
+	// otherwise.
+	ModeAuto
+)
+
+// ErrNoInteraction is returned by the replaying recorder for requests
+// without a matching interaction.
+var ErrNoInteraction = errors.New("cassette: no interaction matches the request")
+
+// Option configures Recorder.
+type Option func(*options)
+
+// options represents configuration of Recorder.
+type options struct {
+	transport http.RoundTripper
+	fields    []string
+	scrubbers []func(*Interaction)
+}
+
+// WithTransport sets transport the recording recorder sends requests with,
+// http.DefaultTransport is used by default.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = transport
+	}
+}
+
+// WithScrubbedFields adds JSON fields of the bodies that are scrubbed in
+// addition to the default ones.
+func WithScrubbedFields(fields ...string) Option {
+	return func(o *options) {
+		o.fields = append(o.fields, fields...)
+	}
+}
+
+// WithScrubber adds function that removes secrets from the interactions
+// after the default scrubbing. It's applied to replayed requests as well,
+// so it must be deterministic.
+func WithScrubber(scrubber func(*Interaction)) Option {
+	return func(o *options) {
+		o.scrubbers = append(o.scrubbers, scrubber)
+	}
+}
+
+// Recorder represents http.RoundTripper that records interactions to
+// the cassette or replays them.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	scrubber  *scrubber
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// New returns a reference to a new recorder of the cassette file in given
+// mode. Recorded cassette is written by Stop.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	o := &options{transport: http.DefaultTransport}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if mode == ModeAuto {
+		mode = ModeRecord
+		if _, err := os.Stat(path); err == nil {
+			mode = ModeReplay
+		}
+	}
+
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: o.transport,
+		scrubber:  newScrubber(o.fields, o.scrubbers),
+		cassette:  &Cassette{Version: Version, Interactions: []*Interaction{}},
+	}
+
+	if mode == ModeReplay {
+		c, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = c
+		r.used = make([]bool, len(c.Interactions))
+	}
+
+	return r, nil
+}
+
+// Mode returns mode of the recorder, ModeAuto is resolved to ModeReplay or
+// ModeRecord.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// RoundTrip implements http.RoundTripper interface.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrip must not modify the request, its body is replaced
+	// in the clone.
+	req = req.Clone(req.Context())
+
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+
+	return r.record(req, body)
+}
+
+// record sends the request and records the interaction.
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := newInteraction(req, body)
+	interaction.Response = Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       string(respBody),
+	}
+	r.scrubber.scrub(interaction)
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay returns response of the first unused interaction matching
+// the request.
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	candidate := newInteraction(req, body)
+	r.scrubber.scrub(candidate)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !matches(&interaction.Request, &candidate.Request) {
+			continue
+		}
+		r.used[i] = true
+
+		respBody := interaction.Response.Body
+		resp := &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(respBody)),
+			ContentLength: int64(len(respBody)),
+			Request:       req,
+		}
+		if resp.Header == nil {
+			resp.Header = http.Header{}
+		}
+
+		return resp, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s in %s with body %q",
+		ErrNoInteraction, candidate.Request.Method, candidate.Request.URL, r.path, candidate.Request.Body)
+}
+
+// Unused returns interactions of the replayed cassette that haven't matched
+// any request.
+func (r *Recorder) Unused() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []*Interaction
+	for i, interaction := range r.cassette.Interactions {
+		if r.mode == ModeReplay && !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+
+	return unused
+}
+
+// Stop writes the recorded cassette to the file, it does nothing in
+// the replay mode.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.cassette.Save(r.path)
+}
+
+// newInteraction returns interaction with the request.
+func newInteraction(req *http.Request, body []byte) *Interaction {
+	return &Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.RequestURI(),
+			Header: req.Header.Clone(),
+			Body:   string(body),
+		},
+	}
+}
+
+// readBody returns body of the request and makes it readable again.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+// matches reports whether the recorded request matches the scrubbed one by
+// method, path, query and body.
+func matches(recorded, req *Request) bool {
+	if recorded.Method != req.Method || recorded.Body != req.Body {
+		return false
+	}
+
+	recordedURL, err := url.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+
+	reqURL, err := url.Parse(req.URL)
+	if err != nil {
+		return false
+	}
+
+	return recordedURL.Path == reqURL.Path &&
+		reflect.DeepEqual(recordedURL.Query(), reqURL.Query())
+}
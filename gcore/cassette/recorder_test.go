@@ -0,0 +1,222 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dstdfx/go-gcore/gcore"
+	"github.com/dstdfx/go-gcore/gcore/gcoretest"
+)
+
+// newTestClient returns common client that uses the recorder and signs in
+// to the server.
+func newTestClient(t *testing.T, recorder *Recorder, baseURL string) *gcore.CommonClient {
+	t.Helper()
+
+	client, err := gcore.NewCommon(
+		gcore.WithBaseURL(baseURL),
+		gcore.WithHTTPClient(&http.Client{Transport: recorder}),
+		gcore.WithCredentials(gcore.NewStaticCredentials(gcoretest.DefaultUsername, gcoretest.DefaultPassword)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+// recordTestCassette records creation and listing of an origin group with
+// the fake API and returns path of the cassette and the created group.
+func recordTestCassette(t *testing.T) (string, *gcore.OriginGroup) {
+	t.Helper()
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	server := gcoretest.NewServer()
+	defer server.Close()
+
+	recorder, err := New(path, ModeAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorder.Mode() != ModeRecord {
+		t.Fatalf("expected record mode of missing cassette, got %d", recorder.Mode())
+	}
+
+	client := newTestClient(t, recorder, server.URL())
+
+	group, _, err := client.OriginGroups.Create(ctx, &gcore.CreateOriginGroupBody{
+		Name:    "origins",
+		Origins: []gcore.Origin{{Source: "origin.example.com", Enabled: true}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = client.OriginGroups.List(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = recorder.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	return path, group
+}
+
+func TestRecorder_Record(t *testing.T) {
+	path, _ := recordTestCassette(t)
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(c.Interactions) != 3 {
+		t.Fatalf("expected 3 interactions, got %d", len(c.Interactions))
+	}
+
+	signIn := c.Interactions[0]
+	if signIn.Request.URL != "/auth/signin" ||
+		!strings.Contains(signIn.Request.Body, `"password":"REDACTED"`) ||
+		!strings.Contains(signIn.Response.Body, `"token":"REDACTED"`) {
+		t.Errorf("sign in hasn't been scrubbed: %+v", signIn)
+	}
+
+	if got := c.Interactions[1].Request.Header.Get("Authorization"); got != "Token REDACTED" {
+		t.Errorf("unexpected authorization header: %s", got)
+	}
+}
+
+func TestRecorder_Replay(t *testing.T) {
+	path, group := recordTestCassette(t)
+	ctx := context.Background()
+
+	recorder, err := New(path, ModeAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorder.Mode() != ModeReplay {
+		t.Fatalf("expected replay mode of existing cassette, got %d", recorder.Mode())
+	}
+
+	// The server has been closed, so the responses are replayed.
+	client := newTestClient(t, recorder, "http://127.0.0.1:1")
+
+	replayed, _, err := client.OriginGroups.Create(ctx, &gcore.CreateOriginGroupBody{
+		Name:    "origins",
+		Origins: []gcore.Origin{{Source: "origin.example.com", Enabled: true}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed.ID != group.ID {
+		t.Errorf("expected origin group %d, got %d", group.ID, replayed.ID)
+	}
+
+	if unused := recorder.Unused(); len(unused) != 1 || unused[0].Request.URL != "/originGroups" ||
+		unused[0].Request.Method != http.MethodGet {
+		t.Errorf("unexpected unused interactions: %+v", unused)
+	}
+
+	if _, _, err = client.OriginGroups.List(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = client.OriginGroups.List(ctx)
+	if !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("expected error of the request without interaction, got: %v", err)
+	}
+}
+
+func TestRecorder_Replay_Matching(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	c := &Cassette{
+		Version: Version,
+		Interactions: []*Interaction{{
+			Request: Request{
+				Method: http.MethodPost,
+				URL:    "/resources/1/purge?a=1&b=2",
+				Body:   `{"paths":["/b","/a"]}`,
+			},
+			Response: Response{StatusCode: http.StatusCreated, Body: `{"id": 7}`},
+		}},
+	}
+	if err := c.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name    string
+		url     string
+		body    string
+		matches bool
+	}{
+		{name: "reordered query and body", url: "/resources/1/purge?b=2&a=1", body: "{\n \"paths\": [\"/b\", \"/a\"]\n}", matches: true},
+		{name: "different body", url: "/resources/1/purge?a=1&b=2", body: `{"paths":["/a","/b"]}`},
+		{name: "different query", url: "/resources/1/purge?a=1", body: `{"paths":["/b","/a"]}`},
+		{name: "different path", url: "/resources/2/purge?a=1&b=2", body: `{"paths":["/b","/a"]}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder, err := New(path, ModeReplay)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "http://example.com"+tc.url, strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := recorder.RoundTrip(req)
+			if tc.matches {
+				if err != nil || resp.StatusCode != http.StatusCreated {
+					t.Errorf("expected recorded response, got: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrNoInteraction) {
+				t.Errorf("expected error of the request without interaction, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestRecorder_RoundTrip_Request(t *testing.T) {
+	server := gcoretest.NewServer()
+	defer server.Close()
+
+	recorder, err := New(filepath.Join(t.TempDir(), "cassette.json"), ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := io.NopCloser(strings.NewReader(`{"username": "user"}`))
+	req, err := http.NewRequest(http.MethodPost, server.URL()+"/auth/signin", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := recorder.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if req.Body != body {
+		t.Error("body of the request has been replaced")
+	}
+}
+
+func TestNew_MissingCassette(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay)
+	if err == nil {
+		t.Error("expected error of missing cassette")
+	}
+}
@@ -0,0 +1,134 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Redacted represents value that replaces the scrubbed secrets.
+const Redacted = "REDACTED"
+
+// scrubbedExpire represents expiration time of the scrubbed tokens, it's far
+// in the future so the clients don't renew the replayed tokens.
+const scrubbedExpire = "2100-01-01T00:00:00"
+
+// defaultScrubbedFields represents JSON fields of the request and response
+// bodies that are scrubbed by default: credentials of /auth/signin and
+// the clients, the issued tokens and private keys of the certificates.
+var defaultScrubbedFields = []string{"password", "token", "sslPrivateKey"}
+
+// scrubbedHeaders represents headers that are scrubbed, the authorization
+// scheme is kept.
+var scrubbedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// scrubber removes secrets from the interactions.
+type scrubber struct {
+	fields map[string]struct{}
+	custom []func(*Interaction)
+}
+
+// newScrubber returns a reference to a scrubber of the default fields and
+// given ones.
+func newScrubber(fields []string, custom []func(*Interaction)) *scrubber {
+	s := &scrubber{
+		fields: make(map[string]struct{}),
+		custom: custom,
+	}
+	for _, field := range append(append([]string{}, defaultScrubbedFields...), fields...) {
+		s.fields[strings.ToLower(field)] = struct{}{}
+	}
+
+	return s
+}
+
+// scrub removes secrets from the interaction and normalizes its JSON bodies.
+func (s *scrubber) scrub(i *Interaction) {
+	scrubHeader(i.Request.Header)
+	scrubHeader(i.Response.Header)
+	i.Request.Body = s.body(i.Request.Body)
+	i.Response.Body = s.body(i.Response.Body)
+
+	for _, f := range s.custom {
+		f(i)
+	}
+}
+
+// scrubHeader replaces values of the secret headers.
+func scrubHeader(header http.Header) {
+	for _, name := range scrubbedHeaders {
+		values := header.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+
+		scrubbed := make([]string, len(values))
+		for i, value := range values {
+			scrubbed[i] = Redacted
+			if scheme, _, ok := strings.Cut(value, " "); ok && name == "Authorization" {
+				scrubbed[i] = scheme + " " + Redacted
+			}
+		}
+		header[http.CanonicalHeaderKey(name)] = scrubbed
+	}
+}
+
+// body returns JSON body with the secret fields replaced and the keys sorted,
+// bodies that aren't JSON are returned as is.
+func (s *scrubber) body(body string) string {
+	if body == "" {
+		return body
+	}
+
+	// Numbers are decoded as json.Number to keep large IDs intact.
+	var tree interface{}
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&tree); err != nil {
+		return body
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return body
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s.value(tree)); err != nil {
+		return body
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// value returns the JSON value with the secret fields replaced.
+func (s *scrubber) value(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		tokenScrubbed := false
+		for key, item := range value {
+			if _, ok := s.fields[strings.ToLower(key)]; ok && item != nil {
+				value[key] = Redacted
+				tokenScrubbed = tokenScrubbed || key == "token"
+				continue
+			}
+			value[key] = s.value(item)
+		}
+
+		if _, ok := value["expire"]; ok && tokenScrubbed {
+			value["expire"] = scrubbedExpire
+		}
+
+		return value
+	case []interface{}:
+		for i, item := range value {
+			value[i] = s.value(item)
+		}
+
+		return value
+	default:
+		return v
+	}
+}
@@ -0,0 +1,97 @@
+package cassette
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestScrubber_Scrub(t *testing.T) {
+	interaction := &Interaction{
+		Request: Request{
+			Method: http.MethodPost,
+			URL:    "/auth/signin",
+			Header: http.Header{"Authorization": {"Token secret-token"}},
+			Body:   `{"username": "user@example.com", "password": "secret"}`,
+		},
+		Response: Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Set-Cookie": {"session=secret"}},
+			Body:       `{"token": "secret-token", "expire": "2020-01-01T00:00:00"}`,
+		},
+	}
+
+	newScrubber([]string{"username"}, []func(*Interaction){
+		func(i *Interaction) {
+			i.Request.URL = "/auth/signin?scrubbed=true"
+		},
+	}).scrub(interaction)
+
+	expectedRequestBody := `{"password":"REDACTED","username":"REDACTED"}`
+	if interaction.Request.Body != expectedRequestBody {
+		t.Errorf("expected request body %s, got %s", expectedRequestBody, interaction.Request.Body)
+	}
+
+	expectedResponseBody := `{"expire":"2100-01-01T00:00:00","token":"REDACTED"}`
+	if interaction.Response.Body != expectedResponseBody {
+		t.Errorf("expected response body %s, got %s", expectedResponseBody, interaction.Response.Body)
+	}
+
+	if got := interaction.Request.Header.Get("Authorization"); got != "Token REDACTED" {
+		t.Errorf("unexpected authorization header: %s", got)
+	}
+	if got := interaction.Response.Header.Get("Set-Cookie"); got != Redacted {
+		t.Errorf("unexpected cookie header: %s", got)
+	}
+	if interaction.Request.URL != "/auth/signin?scrubbed=true" {
+		t.Errorf("custom scrubber hasn't been applied: %s", interaction.Request.URL)
+	}
+}
+
+func TestScrubber_Body(t *testing.T) {
+	s := newScrubber(nil, nil)
+
+	testCases := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "private key",
+			body:     `{"name": "cert", "sslCertificate": "-----BEGIN CERTIFICATE-----", "sslPrivateKey": "key"}`,
+			expected: `{"name":"cert","sslCertificate":"-----BEGIN CERTIFICATE-----","sslPrivateKey":"REDACTED"}`,
+		},
+		{
+			name:     "nested",
+			body:     `[{"users": [{"email": "a@example.com", "password": "secret"}]}]`,
+			expected: `[{"users":[{"email":"a@example.com","password":"REDACTED"}]}]`,
+		},
+		{
+			name:     "null",
+			body:     `{"password": null, "url": "/a?b=c&d=e"}`,
+			expected: `{"password":null,"url":"/a?b=c&d=e"}`,
+		},
+		{
+			name:     "large number",
+			body:     `{"id": 9007199254740993, "weight": 1.50}`,
+			expected: `{"id":9007199254740993,"weight":1.50}`,
+		},
+		{
+			name:     "trailing data",
+			body:     `{"id": 1} {"id": 2}`,
+			expected: `{"id": 1} {"id": 2}`,
+		},
+		{
+			name:     "not JSON",
+			body:     `password=secret`,
+			expected: `password=secret`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := s.body(tc.body); got != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
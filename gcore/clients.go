@@ -2,9 +2,10 @@ package gcore
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
-	"strings"
 )
 
 const (
@@ -34,6 +35,34 @@ type ClientAccount struct {
 	UtilizationLevel int     `json:"utilization_level"`
 	Reseller         int     `json:"reseller"`
 	Cname            string  `json:"cname,omitempty"`
+
+	// Extra represents fields the library doesn't model yet by their keys.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface,
+// unknown fields are stored in Extra.
+func (c *ClientAccount) UnmarshalJSON(b []byte) error {
+	type clientAccount ClientAccount
+
+	var alias clientAccount
+	extra, err := unmarshalWithExtra(b, &alias)
+	if err != nil {
+		return err
+	}
+
+	*c = ClientAccount(alias)
+	c.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface,
+// fields from Extra are added unless ClientAccount has a field for them.
+func (c ClientAccount) MarshalJSON() ([]byte, error) {
+	type clientAccount ClientAccount
+
+	return marshalWithExtra(clientAccount(c), c.Extra)
 }
 
 // CreateClientBody represents request body for create client.
@@ -53,6 +82,30 @@ type UpdateClientBody struct {
 	Phone       string `json:"phone"`
 	Email       string `json:"email"`
 	Seller      int    `json:"seller,omitempty"`
+
+	// Extra represents fields the library doesn't model yet by their keys,
+	// they are sent as is.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler interface,
+// fields from Extra are added unless UpdateClientBody has a field for them.
+func (u UpdateClientBody) MarshalJSON() ([]byte, error) {
+	type updateClientBody UpdateClientBody
+
+	return marshalWithExtra(updateClientBody(u), u.Extra)
+}
+
+// UpdateBody returns request body that updates the client to its current
+// state including the fields kept in Extra.
+func (c *ClientAccount) UpdateBody() *UpdateClientBody {
+	return &UpdateClientBody{
+		Name:        c.Name,
+		CompanyName: c.CompanyName,
+		Phone:       c.Phone,
+		Email:       c.Email,
+		Extra:       c.Extra,
+	}
 }
 
 // ListOpts represents list of additional options to filter client's list by.
@@ -63,6 +116,17 @@ type ListOpts struct {
 	Deleted     bool   `param:"deleted,omitempty"`
 	CDN         string `param:"cdn,omitempty"`
 	Activated   bool   `param:"activated,omitempty"`
+
+	// Ordering represents field to sort the clients by, "-" prefix
+	// reverses the order, e.g. "-created".
+	Ordering string `param:"ordering,omitempty"`
+
+	// Fields represents fields of the clients returned by the API,
+	// all fields are returned if it's empty.
+	Fields []string `param:"fields,omitempty"`
+
+	Limit  int `param:"limit,omitempty"`
+	Offset int `param:"offset,omitempty"`
 }
 
 // Create method creates a new client, the client will be activated automatically.
@@ -101,32 +165,33 @@ func (s *ClientsService) Get(ctx context.Context, clientID int) (*ClientAccount,
 	return clientAccount, resp, nil
 }
 
-// List method gets a list of all Clients assigned to a Reseller.
+// List method gets a list of Clients assigned to a Reseller filtered by given
+// opts. All clients are returned unless opts.Limit is set.
 func (s *ClientsService) List(ctx context.Context, opts ListOpts) ([]*ClientAccount, *http.Response, error) {
-
-	url := resellClientsURL
-	queryParams, err := BuildQueryParameters(opts)
+	page, resp, err := s.ListPage(ctx, opts)
 	if err != nil {
-		return nil, nil, err
-	}
-
-	if queryParams != "" {
-		url = strings.Join([]string{url, queryParams}, "?")
+		return nil, resp, err
 	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, nil, err
-	}
+	return page.Results, resp, nil
+}
 
-	clients := make([]*ClientAccount, 0)
+// ListPage method returns page of the clients filtered by given opts along
+// with total number of the matching clients.
+func (s *ClientsService) ListPage(ctx context.Context, opts ListOpts) (*Page[*ClientAccount], *http.Response, error) {
+	return listPage[*ClientAccount](ctx, s.client, resellClientsURL, opts, opts.Limit, opts.Offset)
+}
 
-	resp, err := s.client.Do(req, &clients)
-	if err != nil {
-		return nil, resp, err
-	}
+// ListAll method returns iterator over all clients filtered by given opts,
+// the pages are requested as the iteration goes.
+func (s *ClientsService) ListAll(ctx context.Context, opts ListOpts) iter.Seq2[*ClientAccount, error] {
+	return listAll(ctx, opts.Limit, opts.Offset, func(ctx context.Context, limit, offset int) (*Page[*ClientAccount], error) {
+		pageOpts := opts
+		pageOpts.Limit, pageOpts.Offset = limit, offset
+		page, _, err := s.ListPage(ctx, pageOpts)
 
-	return clients, resp, nil
+		return page, err
+	})
 }
 
 // Update method edits data of the client.
@@ -167,8 +232,10 @@ func (s *ClientsService) GetCommonClient(ctx context.Context, userID int) (*Comm
 		return nil, resp, err
 	}
 
-	commonClient := NewCommonClient(s.client.log)
-	commonClient.Token = token
+	commonClient, err := NewCommon(WithSlogLogger(s.client.logger), WithToken(token))
+	if err != nil {
+		return nil, resp, err
+	}
 
 	return commonClient, resp, nil
 }
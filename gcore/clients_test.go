@@ -390,6 +390,51 @@ func TestClientsService_List(t *testing.T) {
 	}
 }
 
+func TestClientsService_ListPage(t *testing.T) {
+	endpointCalled := false
+
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	handleOpts := &th.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         resellClientsURL,
+		RawResponse: `{"count": 10, "results": ` + testListClientsRawResponse + `}`,
+		QueryParams: map[string]string{
+			"companyName": "Site",
+			"ordering":    "-created",
+			"limit":       "1",
+			"offset":      "2",
+		},
+		Method:   http.MethodGet,
+		Status:   http.StatusOK,
+		CallFlag: &endpointCalled,
+	}
+
+	th.HandleReqWithoutBody(t, handleOpts)
+
+	client := NewResellerClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	got, _, err := client.Clients.ListPage(context.Background(), ListOpts{CompanyName: "Site", Ordering: "-created", Limit: 1, Offset: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !endpointCalled {
+		t.Fatal("didn't get a page of clients")
+	}
+
+	expected := &Page[*ClientAccount]{Count: 10, Results: testListClientsExpected, Limit: 1, Offset: 2}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected: %+v, got %+v\n", expected, got)
+	}
+	if !got.HasNext() || got.NextOffset() != 2+len(testListClientsExpected) {
+		t.Errorf("expected the next page at %d", 2+len(testListClientsExpected))
+	}
+}
+
 func TestClientsService_Update(t *testing.T) {
 	endpointCalled := false
 
@@ -0,0 +1,272 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// envUsername represents environment variable with G-Core username.
+	envUsername = "GCORE_USERNAME"
+
+	// envPassword represents environment variable with G-Core password.
+	envPassword = "GCORE_PASSWORD"
+
+	// envAPIToken represents environment variable with permanent G-Core
+	// API token.
+	envAPIToken = "GCORE_API_TOKEN"
+
+	// envCredentialsFile represents environment variable with path to
+	// the credentials file.
+	envCredentialsFile = "GCORE_CREDENTIALS_FILE"
+
+	// envProfile represents environment variable with name of the profile
+	// in the credentials file.
+	envProfile = "GCORE_PROFILE"
+
+	// defaultCredentialsFile represents default path to the credentials
+	// file relative to the user's home directory.
+	defaultCredentialsFile = ".gcore/credentials.yaml"
+
+	// defaultProfile represents default name of the profile in the
+	// credentials file.
+	defaultProfile = "default"
+)
+
+// The list of the possible authorization schemes of the Token.
+const (
+	// TokenAuthScheme is used for temporary tokens from /auth/signin.
+	TokenAuthScheme = "Token"
+
+	// APIKeyAuthScheme is used for permanent API tokens.
+	APIKeyAuthScheme = "APIKey"
+)
+
+// ErrNoCredentials is returned by credentials providers that have no
+// credentials to provide.
+var ErrNoCredentials = errors.New("gcore: no credentials found")
+
+// Credentials represents secrets to access G-Core API. Either username and
+// password or permanent API token must be set.
+type Credentials struct {
+	Username string `json:"username" yaml:"username"`
+	Password string `json:"password" yaml:"password"`
+	APIToken string `json:"api_token" yaml:"api_token"`
+}
+
+// empty checks if credentials have nothing to authenticate with.
+func (c *Credentials) empty() bool {
+	return c.APIToken == "" && (c.Username == "" || c.Password == "")
+}
+
+// CredentialsProvider represents a source of credentials for Client.
+// Client retrieves credentials every time it needs a new token.
+type CredentialsProvider interface {
+	Retrieve(ctx context.Context) (*Credentials, error)
+}
+
+// CredentialsProviderFunc is an adapter to use ordinary functions as
+// credentials providers.
+type CredentialsProviderFunc func(ctx context.Context) (*Credentials, error)
+
+// Retrieve implements CredentialsProvider interface.
+func (f CredentialsProviderFunc) Retrieve(ctx context.Context) (*Credentials, error) {
+	return f(ctx)
+}
+
+// StaticCredentials represents provider of the fixed credentials.
+type StaticCredentials struct {
+	Credentials Credentials
+}
+
+// NewStaticCredentials returns a reference to a provider of given
+// username and password.
+func NewStaticCredentials(username, password string) *StaticCredentials {
+	return &StaticCredentials{
+		Credentials: Credentials{
+			Username: username,
+			Password: password,
+		},
+	}
+}
+
+// NewAPITokenCredentials returns a reference to a provider of given
+// permanent API token.
+func NewAPITokenCredentials(token string) *StaticCredentials {
+	return &StaticCredentials{
+		Credentials: Credentials{
+			APIToken: token,
+		},
+	}
+}
+
+// Retrieve implements CredentialsProvider interface.
+func (p *StaticCredentials) Retrieve(_ context.Context) (*Credentials, error) {
+	creds := p.Credentials
+	if creds.empty() {
+		return nil, ErrNoCredentials
+	}
+
+	return &creds, nil
+}
+
+// EnvCredentials represents provider of the credentials from GCORE_USERNAME,
+// GCORE_PASSWORD and GCORE_API_TOKEN environment variables.
+type EnvCredentials struct{}
+
+// NewEnvCredentials returns a reference to a provider of the credentials
+// from environment variables.
+func NewEnvCredentials() *EnvCredentials {
+	return &EnvCredentials{}
+}
+
+// Retrieve implements CredentialsProvider interface.
+func (p *EnvCredentials) Retrieve(_ context.Context) (*Credentials, error) {
+	creds := &Credentials{
+		Username: os.Getenv(envUsername),
+		Password: os.Getenv(envPassword),
+		APIToken: os.Getenv(envAPIToken),
+	}
+	if creds.empty() {
+		return nil, ErrNoCredentials
+	}
+
+	return creds, nil
+}
+
+// credentialsFile represents content of the credentials file.
+type credentialsFile struct {
+	Profiles map[string]Credentials `json:"profiles" yaml:"profiles"`
+}
+
+// FileCredentials represents provider of the credentials from a YAML or JSON
+// file with named profiles:
+//
+//	profiles:
+//	  default:
+//	    username: user@example.com
+//	    password: secret
+//	  production:
+//	    api_token: 123$abc
+type FileCredentials struct {
+	// Path represents path to the file. GCORE_CREDENTIALS_FILE environment
+	// variable or ~/.gcore/credentials.yaml is used if it's empty.
+	Path string
+
+	// Profile represents name of the profile. GCORE_PROFILE environment
+	// variable or "default" is used if it's empty.
+	Profile string
+}
+
+// NewFileCredentials returns a reference to a provider of the credentials
+// from given profile of given file.
+func NewFileCredentials(path, profile string) *FileCredentials {
+	return &FileCredentials{
+		Path:    path,
+		Profile: profile,
+	}
+}
+
+// Retrieve implements CredentialsProvider interface.
+func (p *FileCredentials) Retrieve(_ context.Context) (*Credentials, error) {
+	path, err := p.path()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoCredentials
+		}
+		return nil, err
+	}
+
+	// YAML is a superset of JSON, so both formats are parsed the same way.
+	file := &credentialsFile{}
+	if err = yaml.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("gcore: unable to parse credentials file %s: %w", path, err)
+	}
+
+	profile := p.profile()
+	creds, ok := file.Profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("gcore: profile %q not found in %s: %w", profile, path, ErrNoCredentials)
+	}
+	if creds.empty() {
+		return nil, fmt.Errorf("gcore: profile %q in %s is incomplete: %w", profile, path, ErrNoCredentials)
+	}
+
+	return &creds, nil
+}
+
+// path returns path to the credentials file.
+func (p *FileCredentials) path() (string, error) {
+	if p.Path != "" {
+		return p.Path, nil
+	}
+
+	if path := os.Getenv(envCredentialsFile); path != "" {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, defaultCredentialsFile), nil
+}
+
+// profile returns name of the profile.
+func (p *FileCredentials) profile() string {
+	if p.Profile != "" {
+		return p.Profile
+	}
+
+	if profile := os.Getenv(envProfile); profile != "" {
+		return profile
+	}
+
+	return defaultProfile
+}
+
+// ChainCredentials represents provider that returns credentials of the
+// first provider that has them.
+type ChainCredentials struct {
+	Providers []CredentialsProvider
+}
+
+// NewChainCredentials returns a reference to a provider that tries given
+// providers in order.
+func NewChainCredentials(providers ...CredentialsProvider) *ChainCredentials {
+	return &ChainCredentials{
+		Providers: providers,
+	}
+}
+
+// NewDefaultCredentials returns a reference to a provider that looks for
+// credentials in environment variables and then in the credentials file.
+func NewDefaultCredentials() *ChainCredentials {
+	return NewChainCredentials(NewEnvCredentials(), &FileCredentials{})
+}
+
+// Retrieve implements CredentialsProvider interface.
+func (p *ChainCredentials) Retrieve(ctx context.Context) (*Credentials, error) {
+	for _, provider := range p.Providers {
+		creds, err := provider.Retrieve(ctx)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+
+		return creds, err
+	}
+
+	return nil, ErrNoCredentials
+}
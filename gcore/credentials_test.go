@@ -0,0 +1,202 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	th "github.com/dstdfx/go-gcore/gcore/internal/testhelper"
+)
+
+// Fixtures
+const (
+	testCredentialsFileYAML = `
+profiles:
+  default:
+    username: user@example.com
+    password: secret
+  production:
+    api_token: 123$abc
+`
+	testCredentialsFileJSON = `{
+  "profiles": {
+    "default": {"username": "user@example.com", "password": "secret"},
+    "production": {"api_token": "123$abc"}
+  }
+}`
+)
+
+// writeTestFile writes given content to a temporary file.
+func writeTestFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestFileCredentials(t *testing.T) {
+	files := map[string]string{
+		"credentials.yaml": testCredentialsFileYAML,
+		"credentials.json": testCredentialsFileJSON,
+	}
+
+	for name, content := range files {
+		path := writeTestFile(t, name, content)
+		t.Setenv(envProfile, "")
+
+		got, err := NewFileCredentials(path, "").Retrieve(context.Background())
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		expected := &Credentials{Username: "user@example.com", Password: "secret"}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s: Expected: %+v, got %+v", name, expected, got)
+		}
+
+		t.Setenv(envProfile, "production")
+
+		got, err = NewFileCredentials(path, "").Retrieve(context.Background())
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		expected = &Credentials{APIToken: "123$abc"}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s: Expected: %+v, got %+v", name, expected, got)
+		}
+
+		_, err = NewFileCredentials(path, "staging").Retrieve(context.Background())
+		if !errors.Is(err, ErrNoCredentials) {
+			t.Errorf("%s: expected no credentials error, got %v", name, err)
+		}
+	}
+}
+
+func TestFileCredentials_Missing(t *testing.T) {
+	t.Setenv(envCredentialsFile, filepath.Join(t.TempDir(), "missing.yaml"))
+
+	_, err := (&FileCredentials{}).Retrieve(context.Background())
+	if err != ErrNoCredentials {
+		t.Fatalf("Expected: %v, got %v", ErrNoCredentials, err)
+	}
+}
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv(envUsername, "")
+	t.Setenv(envPassword, "")
+	t.Setenv(envAPIToken, "")
+
+	if _, err := NewEnvCredentials().Retrieve(context.Background()); err != ErrNoCredentials {
+		t.Fatalf("Expected: %v, got %v", ErrNoCredentials, err)
+	}
+
+	t.Setenv(envUsername, "user@example.com")
+	t.Setenv(envPassword, "secret")
+
+	got, err := NewEnvCredentials().Retrieve(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &Credentials{Username: "user@example.com", Password: "secret"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected: %+v, got %+v", expected, got)
+	}
+}
+
+func TestChainCredentials(t *testing.T) {
+	t.Setenv(envUsername, "")
+	t.Setenv(envPassword, "")
+	t.Setenv(envAPIToken, "")
+
+	path := writeTestFile(t, "credentials.yaml", testCredentialsFileYAML)
+	provider := NewChainCredentials(NewEnvCredentials(), NewFileCredentials(path, "production"))
+
+	got, err := provider.Retrieve(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.APIToken != "123$abc" {
+		t.Errorf("Expected: %s, got %s", "123$abc", got.APIToken)
+	}
+
+	t.Setenv(envAPIToken, "from-env")
+
+	got, err = provider.Retrieve(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.APIToken != "from-env" {
+		t.Errorf("Expected: %s, got %s", "from-env", got.APIToken)
+	}
+
+	if _, err = NewChainCredentials().Retrieve(context.Background()); err != ErrNoCredentials {
+		t.Errorf("Expected: %v, got %v", ErrNoCredentials, err)
+	}
+}
+
+func TestClient_AuthenticateWithAPIToken(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testEnv.Mux.HandleFunc(accountDetailsURL, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "APIKey 123$abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, testAccountDetailResponse)
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+
+	err := client.AuthenticateWith(context.Background(), NewAPITokenCredentials("123$abc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if client.Token.Scheme != APIKeyAuthScheme {
+		t.Errorf("Expected: %s, got %s", APIKeyAuthScheme, client.Token.Scheme)
+	}
+
+	if _, _, err = client.Account.Details(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_AuthenticateWithProviderFunc(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	called := 0
+	provider := CredentialsProviderFunc(func(_ context.Context) (*Credentials, error) {
+		called++
+		return &Credentials{Username: "whatever", Password: "whatever"}, nil
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+
+	if err := client.AuthenticateWith(context.Background(), provider); err != nil {
+		t.Fatal(err)
+	}
+
+	if called != 1 {
+		t.Errorf("Expected: %d calls, got %d", 1, called)
+	}
+
+	if client.Token.Value != th.TestFakeToken {
+		t.Errorf("Expected: %s, got %s", th.TestFakeToken, client.Token.Value)
+	}
+}
@@ -0,0 +1,230 @@
+package gcore
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Diffable represents types that can be compared by Diff.
+type Diffable interface {
+	Resource | Rule | Options | OriginGroup
+}
+
+// ChangeType represents type of the change.
+type ChangeType string
+
+// The list of the possible change types.
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "changed"
+)
+
+// Change represents a single difference between two configurations.
+type Change struct {
+	// Path represents JSON path of the field, e.g. "options.gzipOn.value"
+	// or "secondaryHostnames[1]".
+	Path string `json:"path"`
+
+	Type ChangeType `json:"type"`
+
+	// Old represents the value before the change, it's nil for added fields.
+	Old interface{} `json:"old,omitempty"`
+
+	// New represents the value after the change, it's nil for removed fields.
+	New interface{} `json:"new,omitempty"`
+}
+
+// String returns the change as a line of the text diff.
+func (c Change) String() string {
+	switch c.Type {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s: %s", c.Path, diffValue(c.New))
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s: %s", c.Path, diffValue(c.Old))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Path, diffValue(c.Old), diffValue(c.New))
+	}
+}
+
+// Changes represents differences between two configurations ordered by
+// their paths. It's rendered as JSON by json.Marshal and as text by String.
+type Changes []Change
+
+// Empty reports whether the configurations are the same.
+func (c Changes) Empty() bool {
+	return len(c) == 0
+}
+
+// String returns the changes one per line.
+func (c Changes) String() string {
+	var b strings.Builder
+	for _, change := range c {
+		b.WriteString(change.String())
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// diffIgnoredFields represents server-managed fields that aren't compared
+// by the JSON paths without indexes. Rules of the resource are compared
+// separately with Diff of the rules.
+var diffIgnoredFields = map[reflect.Type]map[string]struct{}{
+	reflect.TypeOf(Resource{}): newStringSet("id", "created", "updated", "status", "client",
+		"companyName", "deleted", "preset_applied", "rules"),
+	reflect.TypeOf(Rule{}):        newStringSet("id", "preset_applied"),
+	reflect.TypeOf(OriginGroup{}): newStringSet("id", "origin_ids", "origins[].id"),
+	reflect.TypeOf(Options{}):     newStringSet(),
+}
+
+// Diff returns changes that turn configuration a into configuration b.
+// Fields are compared by their JSON representation including the fields kept
+// in Extra, null and missing fields are equal. Server-managed fields like ID,
+// creation and update time and status are ignored. Nil configuration is
+// the same as an empty one.
+func Diff[T Diffable](a, b *T) (Changes, error) {
+	oldValue, err := diffTree(a)
+	if err != nil {
+		return nil, err
+	}
+
+	newValue, err := diffTree(b)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &differ{ignored: diffIgnoredFields[reflect.TypeOf((*T)(nil)).Elem()]}
+	d.compare("", "", oldValue, newValue)
+
+	return d.changes, nil
+}
+
+// diffTree returns JSON representation of v decoded into maps and slices.
+func diffTree(v interface{}) (interface{}, error) {
+	if reflect.ValueOf(v).IsNil() {
+		return map[string]interface{}{}, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var tree interface{}
+	if err = json.Unmarshal(b, &tree); err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+// differ collects changes between two JSON trees.
+type differ struct {
+	ignored map[string]struct{}
+	changes Changes
+}
+
+// compare adds changes between old and new values of the path. The pattern
+// is the path without indexes that is matched against the ignored fields.
+func (d *differ) compare(path, pattern string, oldValue, newValue interface{}) {
+	if _, ok := d.ignored[pattern]; ok {
+		return
+	}
+
+	switch {
+	case oldValue == nil && newValue == nil:
+		return
+	case oldValue == nil:
+		d.changes = append(d.changes, Change{Path: path, Type: ChangeAdded, New: withoutNulls(newValue)})
+		return
+	case newValue == nil:
+		d.changes = append(d.changes, Change{Path: path, Type: ChangeRemoved, Old: withoutNulls(oldValue)})
+		return
+	}
+
+	oldObject, oldIsObject := oldValue.(map[string]interface{})
+	newObject, newIsObject := newValue.(map[string]interface{})
+	if oldIsObject && newIsObject {
+		keys := make([]string, 0, len(oldObject)+len(newObject))
+		for key := range oldObject {
+			keys = append(keys, key)
+		}
+		for key := range newObject {
+			if _, ok := oldObject[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			d.compare(joinDiffPath(path, key), joinDiffPath(pattern, key), oldObject[key], newObject[key])
+		}
+		return
+	}
+
+	oldArray, oldIsArray := oldValue.([]interface{})
+	newArray, newIsArray := newValue.([]interface{})
+	if oldIsArray && newIsArray {
+		for i := 0; i < len(oldArray) || i < len(newArray); i++ {
+			var oldItem, newItem interface{}
+			if i < len(oldArray) {
+				oldItem = oldArray[i]
+			}
+			if i < len(newArray) {
+				newItem = newArray[i]
+			}
+			d.compare(fmt.Sprintf("%s[%d]", path, i), pattern+"[]", oldItem, newItem)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(oldValue, newValue) {
+		d.changes = append(d.changes, Change{Path: path, Type: ChangeModified, Old: oldValue, New: newValue})
+	}
+}
+
+// withoutNulls returns the value without null fields of the objects
+// since they are the same as missing ones.
+func withoutNulls(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			if item != nil {
+				object[key] = withoutNulls(item)
+			}
+		}
+		return object
+	case []interface{}:
+		array := make([]interface{}, len(value))
+		for i, item := range value {
+			array[i] = withoutNulls(item)
+		}
+		return array
+	default:
+		return v
+	}
+}
+
+// joinDiffPath returns path of the object field.
+func joinDiffPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// diffValue returns compact JSON of the value.
+func diffValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(b)
+}
@@ -0,0 +1,145 @@
+package gcore
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiff_Resource(t *testing.T) {
+	a := &Resource{
+		ID:                 4478,
+		Status:             ResourceStatusActive,
+		Cname:              "cdn.site.com",
+		OriginGroup:        7260,
+		SecondaryHostnames: []string{"cdn1.site.com", "cdn2.site.com"},
+		Options: &Options{
+			GZIPOn:      &GZIPOn{Enabled: true, Value: false},
+			CacheExpire: &CacheExpire{Enabled: true, Value: 3600},
+		},
+		CreatedAt: NewTime(time.Date(2018, time.April, 9, 11, 31, 40, 0, time.UTC)),
+	}
+
+	b := &Resource{
+		ID:                 4479,
+		Status:             ResourceStatusProcessed,
+		Cname:              "cdn.site.com",
+		OriginGroup:        7261,
+		SecondaryHostnames: []string{"cdn1.site.com"},
+		Options: &Options{
+			GZIPOn:     &GZIPOn{Enabled: true, Value: true},
+			HostHeader: &HostHeader{Enabled: true, Value: "site.com"},
+			Extra:      map[string]json.RawMessage{"waap": json.RawMessage(`{"enabled":true}`)},
+		},
+		UpdatedAt: NewTime(time.Date(2018, time.April, 9, 11, 32, 31, 0, time.UTC)),
+	}
+
+	changes, err := Diff(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Changes{
+		{Path: "options.cache_expire", Type: ChangeRemoved, Old: map[string]interface{}{"enabled": true, "value": float64(3600)}},
+		{Path: "options.gzipOn.value", Type: ChangeModified, Old: false, New: true},
+		{Path: "options.hostHeader", Type: ChangeAdded, New: map[string]interface{}{"enabled": true, "value": "site.com"}},
+		{Path: "options.waap", Type: ChangeAdded, New: map[string]interface{}{"enabled": true}},
+		{Path: "originGroup", Type: ChangeModified, Old: float64(7260), New: float64(7261)},
+		{Path: "secondaryHostnames[1]", Type: ChangeRemoved, Old: "cdn2.site.com"},
+	}
+
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Expected: %v, got %v", expected, changes)
+	}
+
+	expectedText := `- options.cache_expire: {"enabled":true,"value":3600}
+~ options.gzipOn.value: false -> true
++ options.hostHeader: {"enabled":true,"value":"site.com"}
++ options.waap: {"enabled":true}
+~ originGroup: 7260 -> 7261
+- secondaryHostnames[1]: "cdn2.site.com"
+`
+	if changes.String() != expectedText {
+		t.Errorf("Expected: %s, got %s", expectedText, changes)
+	}
+
+	raw, err := json.Marshal(changes[1:2])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedJSON := `[{"path":"options.gzipOn.value","type":"changed","old":false,"new":true}]`
+	if string(raw) != expectedJSON {
+		t.Errorf("Expected: %s, got %s", expectedJSON, raw)
+	}
+}
+
+func TestDiff_OriginGroup(t *testing.T) {
+	a := &OriginGroup{
+		ID:        7272,
+		Name:      "origin",
+		Origins:   []Origin{{ID: 1, Source: "origin.site.com", Enabled: true}},
+		OriginIDs: []Origin{{ID: 1, Source: "origin.site.com", Enabled: true}},
+	}
+	b := &OriginGroup{
+		Name:    "origin",
+		Origins: []Origin{{Source: "origin.site.com", Enabled: true}},
+	}
+
+	changes, err := Diff(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !changes.Empty() {
+		t.Errorf("server-managed fields haven't been ignored: %s", changes)
+	}
+
+	b.UseNext = true
+	b.Origins = append(b.Origins, Origin{Source: "backup.site.com", Backup: true})
+
+	changes, err = Diff(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Changes{
+		{Path: "origins[1]", Type: ChangeAdded, New: map[string]interface{}{"backup": true, "source": "backup.site.com"}},
+		{Path: "useNext", Type: ChangeModified, Old: false, New: true},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Expected: %v, got %v", expected, changes)
+	}
+}
+
+func TestDiff_Nil(t *testing.T) {
+	rule := &Rule{ID: 1, Name: "images", Rule: "/images/.*", Options: Options{GZIPOn: &GZIPOn{Enabled: true}}}
+
+	changes, err := Diff(nil, rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paths := make([]string, 0, len(changes))
+	for _, change := range changes {
+		if change.Type != ChangeAdded {
+			t.Errorf("Expected: added, got %s", change)
+		}
+		paths = append(paths, change.Path)
+	}
+
+	expected := []string{"name", "options", "originGroup", "originProtocol", "rule", "ruleType", "weight"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("Expected: %v, got %v", expected, paths)
+	}
+
+	if !reflect.DeepEqual(changes[1].New, map[string]interface{}{"gzipOn": map[string]interface{}{"enabled": true, "value": false}}) {
+		t.Errorf("null options haven't been removed: %v", changes[1].New)
+	}
+
+	changes, err = Diff[Options](nil, nil)
+	if err != nil || !changes.Empty() {
+		t.Errorf("Expected: no changes, got %v, %v", changes, err)
+	}
+}
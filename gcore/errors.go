@@ -0,0 +1,236 @@
+package gcore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Error represents an error response returned by G-Core CDN API.
+// All service methods return it for 4xx and 5xx status codes, so callers can
+// use errors.As or one of the Is* helpers to inspect the failure.
+type Error struct {
+	// StatusCode represents HTTP status code of the response.
+	StatusCode int
+
+	// Method represents HTTP method of the failed request.
+	Method string
+
+	// URL represents URL of the failed request.
+	URL string
+
+	// Body represents raw body of the response.
+	Body []byte
+
+	// Message represents the error message returned by the API, if any.
+	Message string
+
+	// FieldErrors represents validation errors of the request fields
+	// returned by the API, if any.
+	FieldErrors map[string][]string
+
+	// Response represents the HTTP response that caused this error.
+	Response *http.Response
+}
+
+// errorPayload represents possible formats of G-Core API error responses.
+type errorPayload struct {
+	Message string          `json:"message"`
+	Detail  string          `json:"detail"`
+	Error   string          `json:"error"`
+	Errors  json.RawMessage `json:"errors"`
+}
+
+// Error implements error interface.
+func (e *Error) Error() string {
+	if len(e.Body) == 0 {
+		return fmt.Sprintf(
+			"gcore: got the %d error status code from the server",
+			e.StatusCode)
+	}
+
+	return fmt.Sprintf(
+		"gcore: got the %d error status code from the server with body: %s",
+		e.StatusCode,
+		string(e.Body))
+}
+
+// newError builds *Error from given response and its already read body.
+func newError(resp *http.Response, body []byte) *Error {
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		Body:       body,
+		Response:   resp,
+	}
+
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			apiErr.URL = resp.Request.URL.String()
+		}
+	}
+
+	payload := &errorPayload{}
+	if err := json.Unmarshal(body, payload); err != nil {
+		return apiErr
+	}
+
+	switch {
+	case payload.Message != "":
+		apiErr.Message = payload.Message
+	case payload.Detail != "":
+		apiErr.Message = payload.Detail
+	case payload.Error != "":
+		apiErr.Message = payload.Error
+	}
+
+	apiErr.FieldErrors = parseFieldErrors(payload.Errors)
+
+	return apiErr
+}
+
+// parseFieldErrors converts "errors" object of the error response to a map
+// of field errors. Each field can hold either a single message or a list of
+// messages.
+func parseFieldErrors(raw json.RawMessage) map[string][]string {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil
+	}
+
+	fieldErrors := make(map[string][]string, len(fields))
+	for field, value := range fields {
+		var messages []string
+		if err := json.Unmarshal(value, &messages); err == nil {
+			fieldErrors[field] = messages
+			continue
+		}
+
+		var message string
+		if err := json.Unmarshal(value, &message); err == nil {
+			fieldErrors[field] = []string{message}
+			continue
+		}
+
+		fieldErrors[field] = []string{string(value)}
+	}
+
+	return fieldErrors
+}
+
+// Fields returns sorted names of the fields that have validation errors.
+func (e *Error) Fields() []string {
+	fields := make([]string, 0, len(e.FieldErrors))
+	for field := range e.FieldErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	return fields
+}
+
+// FieldError returns validation messages for given field joined by "; ".
+func (e *Error) FieldError(field string) string {
+	return strings.Join(e.FieldErrors[field], "; ")
+}
+
+// hasStatus checks if given error is *Error with one of the given status codes.
+func hasStatus(err error, codes ...int) bool {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	for _, code := range codes {
+		if apiErr.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsNotFound checks if given error was caused by 404 response.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict checks if given error was caused by 409 response.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsUnauthorized checks if given error was caused by 401 response.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden checks if given error was caused by 403 response.
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsValidation checks if given error was caused by rejected request data,
+// that is 400 or 422 response or *ValidationError returned before
+// the request is sent.
+func IsValidation(err error) bool {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return true
+	}
+
+	return hasStatus(err, http.StatusBadRequest, http.StatusUnprocessableEntity)
+}
+
+// ValidationError represents invalid request data found by the client
+// before the request is sent.
+type ValidationError struct {
+	// FieldErrors represents validation errors by the JSON names of
+	// the fields, e.g. "country_acl.excepted_values".
+	FieldErrors map[string][]string
+}
+
+// Error implements error interface.
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.FieldErrors))
+	for field := range e.FieldErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	messages := make([]string, 0, len(fields))
+	for _, field := range fields {
+		messages = append(messages, field+": "+strings.Join(e.FieldErrors[field], ", "))
+	}
+
+	return "gcore: invalid request: " + strings.Join(messages, "; ")
+}
+
+// add appends error message of given field.
+func (e *ValidationError) add(field, format string, args ...interface{}) {
+	if e.FieldErrors == nil {
+		e.FieldErrors = make(map[string][]string)
+	}
+	e.FieldErrors[field] = append(e.FieldErrors[field], fmt.Sprintf(format, args...))
+}
+
+// errOrNil returns the error if it contains any field errors or nil.
+func (e *ValidationError) errOrNil() error {
+	if len(e.FieldErrors) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+// IsTooManyRequests checks if given error was caused by 429 response.
+func IsTooManyRequests(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
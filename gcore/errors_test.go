@@ -0,0 +1,129 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	th "github.com/dstdfx/go-gcore/gcore/internal/testhelper"
+)
+
+// Fixtures
+const (
+	testValidationErrorRawResponse = `{
+  "errors": {
+    "cname": ["This field is required."],
+    "originGroup": "Origin group doesn't exist."
+  }
+}`
+	testNotFoundErrorRawResponse = `{"message": "Not found."}`
+)
+
+func TestError_Validation(t *testing.T) {
+	endpointCalled := false
+
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	handleOpts := &th.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         resourcesURL,
+		RawResponse: testValidationErrorRawResponse,
+		RawRequest:  `{"cname":""}`,
+		Method:      http.MethodPost,
+		Status:      http.StatusUnprocessableEntity,
+		CallFlag:    &endpointCalled,
+	}
+
+	th.HandleReqWithBody(t, handleOpts)
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	_, _, err := client.Resources.Create(context.Background(), &CreateResourceBody{})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if !endpointCalled {
+		t.Fatal("didn't create a resource")
+	}
+
+	if !IsValidation(err) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *Error, got %T", err)
+	}
+
+	if apiErr.Method != http.MethodPost {
+		t.Errorf("Expected: %s, got %s", http.MethodPost, apiErr.Method)
+	}
+
+	expectedFields := []string{"cname", "originGroup"}
+	if !reflect.DeepEqual(apiErr.Fields(), expectedFields) {
+		t.Errorf("Expected: %v, got %v", expectedFields, apiErr.Fields())
+	}
+
+	if apiErr.FieldError("originGroup") != "Origin group doesn't exist." {
+		t.Errorf("unexpected originGroup error: %s", apiErr.FieldError("originGroup"))
+	}
+}
+
+func TestError_NotFound(t *testing.T) {
+	endpointCalled := false
+
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	handleOpts := &th.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         fmt.Sprintf(resourceURL, 1),
+		RawResponse: testNotFoundErrorRawResponse,
+		Method:      http.MethodGet,
+		Status:      http.StatusNotFound,
+		CallFlag:    &endpointCalled,
+	}
+
+	th.HandleReqWithoutBody(t, handleOpts)
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	_, _, err := client.Resources.Get(context.Background(), 1)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if !endpointCalled {
+		t.Fatal("didn't get a resource")
+	}
+
+	if !IsNotFound(err) || IsConflict(err) || IsValidation(err) {
+		t.Fatalf("expected only not found error, got %v", err)
+	}
+
+	wrapped := fmt.Errorf("teardown: %w", err)
+	if !IsNotFound(wrapped) {
+		t.Fatal("expected wrapped error to be recognized as not found")
+	}
+
+	var apiErr *Error
+	_ = errors.As(err, &apiErr)
+	if apiErr.Message != "Not found." {
+		t.Errorf("Expected: %s, got %s", "Not found.", apiErr.Message)
+	}
+
+	expectedMsg := "gcore: got the 404 error status code from the server with body: " +
+		testNotFoundErrorRawResponse
+	if err.Error() != expectedMsg {
+		t.Errorf("Expected: %s, got %s", expectedMsg, err.Error())
+	}
+}
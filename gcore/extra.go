@@ -0,0 +1,107 @@
+package gcore
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// knownFieldsCache caches JSON keys of the struct types by reflect.Type.
+var knownFieldsCache sync.Map
+
+// knownFields returns set of the lower-cased JSON keys of the struct fields,
+// the keys are lower-cased since encoding/json matches them case-insensitively.
+func knownFields(t reflect.Type) map[string]struct{} {
+	if fields, ok := knownFieldsCache.Load(t); ok {
+		return fields.(map[string]struct{})
+	}
+
+	fields := make(map[string]struct{}, t.NumField())
+	addKnownFields(fields, t)
+
+	knownFieldsCache.Store(t, fields)
+
+	return fields
+}
+
+// addKnownFields adds JSON keys of the struct fields to the set including
+// the fields of the embedded structs.
+func addKnownFields(fields map[string]struct{}, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			addKnownFields(fields, field.Type)
+			continue
+		}
+		if field.PkgPath != "" || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = struct{}{}
+	}
+}
+
+// isKnownField reports whether given key is known to the struct type.
+func isKnownField(t reflect.Type, key string) bool {
+	_, ok := knownFields(t)[strings.ToLower(key)]
+
+	return ok
+}
+
+// unmarshalWithExtra decodes JSON object into v that must be a pointer to
+// a struct without custom JSON methods and returns the object fields
+// unknown to the struct. Nil is returned if there are no unknown fields.
+func unmarshalWithExtra(b []byte, v interface{}) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(b, v); err != nil {
+		return nil, err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf(v).Elem()
+
+	var extra map[string]json.RawMessage
+	for key, value := range raw {
+		if isKnownField(t, key) {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]json.RawMessage)
+		}
+		extra[key] = value
+	}
+
+	return extra, nil
+}
+
+// marshalWithExtra encodes v that must be a struct without custom JSON
+// methods and adds the extra fields unknown to the struct.
+func marshalWithExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return b, err
+	}
+
+	var raw map[string]json.RawMessage
+	if err = json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf(v)
+	for key, value := range extra {
+		if isKnownField(t, key) {
+			continue
+		}
+		raw[key] = value
+	}
+
+	return json.Marshal(raw)
+}
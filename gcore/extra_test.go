@@ -0,0 +1,101 @@
+package gcore
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestExtra_RoundTrip(t *testing.T) {
+	raw := `{
+  "id": 7272,
+  "name": "whatever.ru_wiggly.gcdn.co",
+  "useNext": true,
+  "origins": [{"backup": false, "source": "whatever.ru", "enabled": true, "weight": 10}],
+  "proxy_next_upstream": ["error", "timeout"],
+  "auth": {"s3_type": "amazon"}
+}`
+
+	group := &OriginGroup{}
+	if err := json.Unmarshal([]byte(raw), group); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedExtra := map[string]json.RawMessage{
+		"proxy_next_upstream": json.RawMessage(`["error", "timeout"]`),
+		"auth":                json.RawMessage(`{"s3_type": "amazon"}`),
+	}
+	if !reflect.DeepEqual(group.Extra, expectedExtra) {
+		t.Errorf("Expected: %s, got %s", expectedExtra, group.Extra)
+	}
+
+	if !reflect.DeepEqual(group.Origins[0].Extra, map[string]json.RawMessage{"weight": json.RawMessage(`10`)}) {
+		t.Errorf("unknown fields of the origin have been lost: %s", group.Origins[0].Extra)
+	}
+
+	group.Name = "whatever2.ru_wiggly.gcdn.co"
+
+	b, err := json.Marshal(group.UpdateBody())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]interface{}
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"name":                "whatever2.ru_wiggly.gcdn.co",
+		"useNext":             true,
+		"origins":             []interface{}{map[string]interface{}{"backup": false, "source": "whatever.ru", "enabled": true, "weight": float64(10)}},
+		"proxy_next_upstream": []interface{}{"error", "timeout"},
+		"auth":                map[string]interface{}{"s3_type": "amazon"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected: %v, got %v", expected, got)
+	}
+}
+
+func TestExtra_KnownFieldsWin(t *testing.T) {
+	rule := &Rule{
+		Name: "images",
+		Extra: map[string]json.RawMessage{
+			"Name":    json.RawMessage(`"stale"`),
+			"comment": json.RawMessage(`"kept"`),
+		},
+	}
+
+	b, err := json.Marshal(rule.UpdateBody())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]interface{}
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got["name"] != "images" {
+		t.Errorf("Expected: name=%q, got %v", "images", got["name"])
+	}
+
+	if _, ok := got["Name"]; ok {
+		t.Errorf("extra field has overridden the known one: %s", b)
+	}
+
+	if got["comment"] != "kept" {
+		t.Errorf("Expected: comment=%q, got %v", "kept", got["comment"])
+	}
+}
+
+func TestExtra_Nil(t *testing.T) {
+	account := &Account{}
+	if err := json.Unmarshal([]byte(testAccountDetailResponse), account); err != nil {
+		t.Fatal(err)
+	}
+
+	if account.Extra != nil {
+		t.Errorf("Expected: no extra fields, got %s", account.Extra)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"log/slog"
 	"net"
 	"net/http"
 	"net/url"
@@ -60,8 +61,13 @@ const (
 
 // Client manages communication with G-Core CDN API.
 type Client struct {
+	// Mutex guards Token and credentials.
 	sync.Mutex
 
+	// authMu serializes sign ins, so concurrent requests don't get several
+	// tokens. Client's lock isn't held during the sign in request.
+	authMu sync.Mutex
+
 	// HTTP client used to communicate with the GC API.
 	client *http.Client
 
@@ -71,12 +77,29 @@ type Client struct {
 	// User agent for client.
 	UserAgent string
 
-	log log.GenericLogger
+	// logger represents structured logger of the client.
+	logger *slog.Logger
 
 	common service
 
 	// Token to communicate with G-Core API.
 	Token *Token
+
+	// credentials represents provider of the credentials that are used
+	// to renew the token.
+	credentials CredentialsProvider
+
+	// RetryPolicy represents configuration of retries for failed requests.
+	// Requests aren't retried if it's nil.
+	RetryPolicy *RetryPolicy
+
+	// RateLimiter limits rate of the requests that are sent by all services
+	// of the client. Requests aren't limited if it's nil.
+	RateLimiter RateLimiter
+
+	// Middlewares represents the chain of middlewares that wrap every
+	// request of the client, the first middleware is the outermost one.
+	Middlewares []Middleware
 }
 
 type service struct {
@@ -121,138 +144,73 @@ type AuthOptions struct {
 type Token struct {
 	Value  string `json:"token"`
 	Expire *Time  `json:"expire"`
+
+	// Scheme represents authorization scheme of the token,
+	// TokenAuthScheme is used if it's empty.
+	Scheme string `json:"-"`
 }
 
 // Authenticate gets API Token, if client already took a token, check if it's valid.
 // If it's not, get new one.
+// Client remembers given credentials to renew the token before it expires
+// or after it's rejected by the API.
 func (c *Client) Authenticate(ctx context.Context, authOpts AuthOptions) error {
-	req, err := c.NewRequest(ctx, http.MethodPost, loginURL, authOpts)
-	if err != nil {
-		return err
-	}
+	return c.AuthenticateWith(ctx, NewStaticCredentials(authOpts.Username, authOpts.Password))
+}
 
-	c.Lock()
-	defer c.Unlock()
-	if c.Token == nil || c.Token.Expire.Before(time.Now().UTC()) {
+// AuthenticateWith gets API Token with the credentials of given provider,
+// if client already took a token, check if it's valid. If it's not, get new one.
+// Client keeps the provider to renew the token before it expires
+// or after it's rejected by the API.
+func (c *Client) AuthenticateWith(ctx context.Context, provider CredentialsProvider) error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	token := c.currentToken()
+	if token == nil || token.expiresWithin(0) {
 		// Renew token if expired
-		token := &Token{}
-		_, err = c.Do(req, token)
-		if err != nil {
+		var err error
+		if token, err = c.signIn(ctx, provider); err != nil {
 			return err
 		}
-
-		c.Token = token
 	}
 
+	c.Lock()
+	c.Token = token
+	c.credentials = provider
+	c.Unlock()
+
 	return nil
 }
 
 // NewCommonClient creates basic G-Core client.
-func NewCommonClient(logger ...log.GenericLogger) *CommonClient {
-	baseURL, _ := url.Parse(defaultBaseURL)
-
-	c := &Client{
-		client:    NewHTTPClient(),
-		BaseURL:   baseURL,
-		UserAgent: defaultUserAgent,
-		log:       log.SelectLogger(logger...),
-	}
-	c.common.client = c
-
-	commonServices := CommonServices{}
-	commonServices.Account = (*AccountService)(&c.common)
-	commonServices.Resources = (*ResourcesService)(&c.common)
-	commonServices.OriginGroups = (*OriginGroupsService)(&c.common)
-	commonServices.Rules = (*RulesService)(&c.common)
-	commonServices.Certificates = (*CertService)(&c.common)
-
-	commonClient := &CommonClient{
-		Client:         c,
-		CommonServices: commonServices,
-	}
+func NewCommonClient(logger ...GenericLogger) *CommonClient {
+	commonClient, _ := NewCommon(WithLogger(log.SelectLogger(logger...)))
 
 	return commonClient
 }
 
 // NewCommonClientWithCustomHTTP creates basic G-Core client with custom HTTP client.
-func NewCommonClientWithCustomHTTP(customClient *http.Client, logger ...log.GenericLogger) *CommonClient {
-	baseURL, _ := url.Parse(defaultBaseURL)
-
-	if customClient == nil {
-		customClient = NewHTTPClient()
-	}
-
-	c := &Client{
-		client:    customClient,
-		BaseURL:   baseURL,
-		UserAgent: defaultUserAgent,
-		log:       log.SelectLogger(logger...),
-	}
-	c.common.client = c
-
-	commonServices := CommonServices{}
-	commonServices.Account = (*AccountService)(&c.common)
-	commonServices.Resources = (*ResourcesService)(&c.common)
-	commonServices.OriginGroups = (*OriginGroupsService)(&c.common)
-	commonServices.Rules = (*RulesService)(&c.common)
-	commonServices.Certificates = (*CertService)(&c.common)
-
-	commonClient := &CommonClient{
-		Client:         c,
-		CommonServices: commonServices,
-	}
+func NewCommonClientWithCustomHTTP(customClient *http.Client, logger ...GenericLogger) *CommonClient {
+	commonClient, _ := NewCommon(
+		WithHTTPClient(customClient),
+		WithLogger(log.SelectLogger(logger...)))
 
 	return commonClient
 }
 
 // NewResellerClient creates reseller G-Core client.
-func NewResellerClient(logger ...log.GenericLogger) *ResellerClient {
-	baseURL, _ := url.Parse(defaultBaseURL)
-
-	c := &Client{
-		client:    NewHTTPClient(),
-		BaseURL:   baseURL,
-		UserAgent: defaultUserAgent,
-		log:       log.SelectLogger(logger...),
-	}
-	c.common.client = c
-
-	resellerServices := ResellerServices{}
-	resellerServices.Clients = (*ClientsService)(&c.common)
-	resellerServices.GeoRestrictions = (*GeoRestrictionsService)(&c.common)
-	resellerServices.Services = (*ServicesService)(&c.common)
-	resellClient := &ResellerClient{
-		Client:           c,
-		ResellerServices: resellerServices,
-	}
+func NewResellerClient(logger ...GenericLogger) *ResellerClient {
+	resellClient, _ := NewReseller(WithLogger(log.SelectLogger(logger...)))
 
 	return resellClient
 }
 
 // NewResellerClientWithCustomHTTP creates reseller G-Core client with custom HTTP client.
-func NewResellerClientWithCustomHTTP(customClient *http.Client, logger ...log.GenericLogger) *ResellerClient {
-	baseURL, _ := url.Parse(defaultBaseURL)
-
-	if customClient == nil {
-		customClient = NewHTTPClient()
-	}
-
-	c := &Client{
-		client:    customClient,
-		BaseURL:   baseURL,
-		UserAgent: defaultUserAgent,
-		log:       log.SelectLogger(logger...),
-	}
-	c.common.client = c
-
-	resellerServices := ResellerServices{}
-	resellerServices.Clients = (*ClientsService)(&c.common)
-	resellerServices.GeoRestrictions = (*GeoRestrictionsService)(&c.common)
-	resellerServices.Services = (*ServicesService)(&c.common)
-	resellClient := &ResellerClient{
-		Client:           c,
-		ResellerServices: resellerServices,
-	}
+func NewResellerClientWithCustomHTTP(customClient *http.Client, logger ...GenericLogger) *ResellerClient {
+	resellClient, _ := NewReseller(
+		WithHTTPClient(customClient),
+		WithLogger(log.SelectLogger(logger...)))
 
 	return resellClient
 }
@@ -261,6 +219,20 @@ func NewResellerClientWithCustomHTTP(customClient *http.Client, logger ...log.Ge
 func (c *Client) NewRequest(ctx context.Context,
 	method, urlStr string, body interface{}) (*http.Request, error) {
 
+	req, err := c.newRequest(ctx, method, urlStr, body)
+	if err != nil {
+		return nil, err
+	}
+
+	setAuthHeader(req, c.currentToken())
+
+	return req, nil
+}
+
+// newRequest method returns new request without authorization header.
+func (c *Client) newRequest(ctx context.Context,
+	method, urlStr string, body interface{}) (*http.Request, error) {
+
 	rel, err := url.Parse(urlStr)
 	if err != nil {
 		return nil, err
@@ -283,10 +255,6 @@ func (c *Client) NewRequest(ctx context.Context,
 
 	req = req.WithContext(ctx)
 
-	if c.Token != nil {
-		req.Header.Add("Authorization", "Token "+c.Token.Value)
-	}
-
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("User-Agent", c.UserAgent)
@@ -295,52 +263,118 @@ func (c *Client) NewRequest(ctx context.Context,
 }
 
 // Do method executes request and checks response body.
+// Failed requests are retried according to the client's RetryPolicy.
+// Client renews its token before it expires or after it's rejected if
+// the client has been authenticated with credentials.
+// The request is passed through the client's middlewares.
 func (c *Client) Do(req *http.Request, to interface{}) (*http.Response, error) {
-	c.log.Debugf("REQ  %v %v", req.Method, req.URL)
+	req, _ = withCallInfo(req)
+
+	resp, err := c.chain(c.doWithRetry)(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if to != nil {
+		if err = ExtractResult(resp, to); err != nil {
+			return resp, err
+		}
+	}
+
+	return resp, nil
+}
+
+// send method executes a single attempt of the request and checks
+// response status code.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	info := CallInfoFromContext(req.Context())
 
+	if c.RateLimiter != nil {
+		start := time.Now()
+		err := c.RateLimiter.Wait(req.Context(), req)
+		if info != nil {
+			info.RateLimitWait += time.Since(start)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	attempt := 0
+	if info != nil {
+		info.Attempts++
+		attempt = info.Attempts
+	}
+
+	ctx := req.Context()
+	logBodies := c.logger.Enabled(ctx, LevelBody)
+	if logBodies && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			reqBody, _ := ioutil.ReadAll(body)
+			body.Close()
+			c.logBody(req, "gcore: request body", reqBody)
+		}
+	}
+
+	start := time.Now()
 	resp, err := c.client.Do(req)
 	if err != nil {
-		c.log.Errorf("Request failed with error: %s", err)
+		c.logger.LogAttrs(ctx, slog.LevelError, "gcore: request failed",
+			append(requestAttrs(req, attempt, time.Since(start)), slog.Any("error", err))...)
 		return nil, err
 	}
 
-	c.log.Debugf("RESP   %v %v %v", req.Method, req.URL, resp.StatusCode)
+	if c.RateLimiter != nil {
+		c.RateLimiter.Observe(req, resp)
+	}
 
-	if resp.StatusCode >= http.StatusBadRequest &&
-		resp.StatusCode <= http.StatusNetworkAuthenticationRequired {
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "gcore: request",
+		append(requestAttrs(req, attempt, time.Since(start)), slog.Int("status", resp.StatusCode))...)
 
-		var respErr error
+	isError := resp.StatusCode >= http.StatusBadRequest &&
+		resp.StatusCode <= http.StatusNetworkAuthenticationRequired
 
-		if resp.Body != nil {
+	var body []byte
+	if (isError || logBodies) && resp.Body != nil {
+		body, _ = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
 
-			body, _ := ioutil.ReadAll(resp.Body)
-			defer resp.Body.Close()
+		// To able to read response twice
+		resp.Body = ioutil.NopCloser(bytes.NewBuffer(body))
 
-			// To able to read response twice
-			rdr2 := ioutil.NopCloser(bytes.NewBuffer(body))
-			resp.Body = rdr2
+		c.logBody(req, "gcore: response body", body)
+	}
 
-			c.log.Debugf("RESP BODY  %s", string(body))
-			respErr = fmt.Errorf(
-				"gcore: got the %d error status code from the server with body: %s",
-				resp.StatusCode,
-				string(body))
-		} else {
-			respErr = fmt.Errorf(
-				"gcore: got the %d error status code from the server",
-				resp.StatusCode)
-		}
+	if isError {
+		return resp, newError(resp, body)
+	}
 
-		return resp, respErr
+	return resp, nil
+}
+
+// requestAttrs returns common attributes of the request log records.
+func requestAttrs(req *http.Request, attempt int, duration time.Duration) []slog.Attr {
+	return []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Duration("duration", duration),
+		slog.Int("attempt", attempt),
+		slog.String("request_id", req.Header.Get(RequestIDHeader)),
 	}
+}
 
-	if to != nil {
-		if err = ExtractResult(resp, to); err != nil {
-			return resp, err
-		}
+// logBody logs request or response body with LevelBody level,
+// sensitive fields are redacted.
+func (c *Client) logBody(req *http.Request, msg string, body []byte) {
+	if len(body) == 0 {
+		return
 	}
 
-	return resp, nil
+	c.logger.LogAttrs(req.Context(), LevelBody, msg,
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.String("request_id", req.Header.Get(RequestIDHeader)),
+		slog.String("body", redactBody(body)))
 }
 
 // ExtractResult reads response body and unmarshal it to given interface
@@ -397,6 +431,13 @@ func BuildQueryParameters(opts interface{}) (string, error) {
 				params.Add(tags[0], strconv.FormatInt(fieldValue.Int(), 10))
 			case reflect.Bool:
 				params.Add(tags[0], strconv.FormatBool(fieldValue.Bool()))
+			case reflect.Slice:
+				// Lists are sent as comma separated values, e.g. fields=id,cname.
+				values := make([]string, 0, fieldValue.Len())
+				for j := 0; j < fieldValue.Len(); j++ {
+					values = append(values, fmt.Sprint(fieldValue.Index(j).Interface()))
+				}
+				params.Add(tags[0], strings.Join(values, ","))
 			}
 		}
 	}
@@ -406,8 +447,11 @@ func BuildQueryParameters(opts interface{}) (string, error) {
 
 // isZero checks if provided value is zero.
 func isZero(v reflect.Value) bool {
-	if v.Kind() == reflect.Ptr {
+	switch v.Kind() {
+	case reflect.Ptr:
 		return v.IsNil()
+	case reflect.Slice:
+		return v.Len() == 0
 	}
 	z := reflect.Zero(v.Type())
 
@@ -0,0 +1,177 @@
+// Package gcoreotel provides OpenTelemetry instrumentation for go-gcore clients.
+//
+// It creates a client span per API call and records latency and error
+// metrics per endpoint:
+//
+//	client, err := gcore.NewCommon(gcoreotel.WithInstrumentation())
+package gcoreotel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dstdfx/go-gcore/gcore"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// instrumentationName represents name of the tracer and the meter.
+	instrumentationName = "github.com/dstdfx/go-gcore/gcore/gcoreotel"
+
+	// durationMetricName represents name of the request duration histogram.
+	durationMetricName = "gcore.client.request.duration"
+
+	// errorsMetricName represents name of the failed requests counter.
+	errorsMetricName = "gcore.client.request.errors"
+)
+
+// The list of the attributes set by the instrumentation.
+const (
+	ServiceKey        = attribute.Key("gcore.service")
+	EndpointKey       = attribute.Key("gcore.endpoint")
+	ResourceIDsKey    = attribute.Key("gcore.resource_ids")
+	AttemptsKey       = attribute.Key("gcore.attempts")
+	TokenRefreshesKey = attribute.Key("gcore.token_refreshes")
+	MethodKey         = attribute.Key("http.request.method")
+	StatusCodeKey     = attribute.Key("http.response.status_code")
+	ServerAddressKey  = attribute.Key("server.address")
+	ErrorTypeKey      = attribute.Key("error.type")
+)
+
+// Option configures the instrumentation.
+type Option func(*config)
+
+// config represents configuration of the instrumentation.
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// WithTracerProvider sets tracer provider, the global one is used by default.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets meter provider, the global one is used by default.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// WithPropagators sets propagators to inject trace context into request
+// headers, the global ones are used by default.
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = propagators
+	}
+}
+
+// WithInstrumentation returns client option that adds instrumentation
+// middleware to the client.
+func WithInstrumentation(opts ...Option) gcore.Option {
+	return gcore.WithMiddleware(Middleware(opts...))
+}
+
+// Middleware returns middleware that traces API calls and records their
+// metrics. It should be the outermost middleware of the client to measure
+// the whole call including retries.
+func Middleware(opts ...Option) gcore.Middleware {
+	cfg := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	tracer := cfg.tracerProvider.Tracer(instrumentationName)
+	meter := cfg.meterProvider.Meter(instrumentationName)
+
+	// Instruments errors are ignored, no-op instruments are returned then.
+	duration, _ := meter.Float64Histogram(durationMetricName,
+		metric.WithDescription("Duration of G-Core API calls including retries."),
+		metric.WithUnit("s"))
+	errorsCount, _ := meter.Int64Counter(errorsMetricName,
+		metric.WithDescription("Number of failed G-Core API calls."),
+		metric.WithUnit("{call}"))
+
+	return func(next gcore.RoundTripFunc) gcore.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			info := gcore.CallInfoFromContext(req.Context())
+			if info == nil {
+				return next(req)
+			}
+
+			attrs := []attribute.KeyValue{
+				ServiceKey.String(info.Service),
+				EndpointKey.String(info.Endpoint),
+				MethodKey.String(req.Method),
+			}
+
+			ctx, span := tracer.Start(req.Context(),
+				info.Service+" "+req.Method+" "+info.Endpoint,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+				trace.WithAttributes(
+					ResourceIDsKey.IntSlice(info.ResourceIDs),
+					ServerAddressKey.String(req.URL.Host),
+				))
+			defer span.End()
+
+			req = req.WithContext(ctx)
+			cfg.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			start := time.Now()
+			resp, err := next(req)
+			elapsed := time.Since(start).Seconds()
+
+			span.SetAttributes(
+				AttemptsKey.Int(info.Attempts),
+				TokenRefreshesKey.Int(info.TokenRefreshes),
+			)
+
+			if resp != nil {
+				span.SetAttributes(StatusCodeKey.Int(resp.StatusCode))
+				attrs = append(attrs, StatusCodeKey.Int(resp.StatusCode))
+			}
+
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				attrs = append(attrs, ErrorTypeKey.String(errorType(resp, err)))
+				errorsCount.Add(ctx, 1, metric.WithAttributes(attrs...))
+			}
+
+			duration.Record(ctx, elapsed, metric.WithAttributes(attrs...))
+
+			return resp, err
+		}
+	}
+}
+
+// errorType returns low-cardinality description of the error.
+func errorType(resp *http.Response, err error) string {
+	switch {
+	case resp != nil:
+		return strconv.Itoa(resp.StatusCode)
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	}
+
+	return "network"
+}
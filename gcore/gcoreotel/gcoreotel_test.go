@@ -0,0 +1,166 @@
+package gcoreotel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/dstdfx/go-gcore/gcore"
+	th "github.com/dstdfx/go-gcore/gcore/internal/testhelper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newTestClient returns client instrumented with in-memory exporters.
+func newTestClient(t *testing.T, serverURL string) (*gcore.CommonClient, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	recorder := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	client, err := gcore.NewCommon(
+		gcore.WithBaseURL(serverURL),
+		gcore.WithToken(&gcore.Token{Value: th.TestFakeToken}),
+		gcore.WithRetryPolicy(&gcore.RetryPolicy{
+			MaxAttempts:          2,
+			RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+		}),
+		WithInstrumentation(
+			WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))),
+			WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+			WithPropagators(propagation.TraceContext{}),
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client, recorder, reader
+}
+
+// attributeValue returns value of the attribute with given key.
+func attributeValue(attrs []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+
+	return attribute.Value{}, false
+}
+
+func TestMiddleware_Span(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	calls := 0
+	var traceparent string
+	testEnv.Mux.HandleFunc("/resources/42/rules/7", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		traceparent = r.Header.Get("Traceparent")
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": 7}`)
+	})
+
+	client, recorder, _ := newTestClient(t, testEnv.Server.URL)
+
+	if _, _, err := client.Rules.Get(context.Background(), 42, 7); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected: %d spans, got %d", 1, len(spans))
+	}
+
+	span := spans[0]
+	if span.Name() != "Rules GET /resources/{id}/rules/{id}" {
+		t.Errorf("unexpected span name %q", span.Name())
+	}
+
+	if traceparent == "" {
+		t.Error("trace context wasn't propagated")
+	}
+
+	attrs := span.Attributes()
+	if v, _ := attributeValue(attrs, ServiceKey); v.AsString() != gcore.ServiceRules {
+		t.Errorf("Expected: %s, got %s", gcore.ServiceRules, v.AsString())
+	}
+
+	if v, _ := attributeValue(attrs, ResourceIDsKey); fmt.Sprint(v.AsInt64Slice()) != "[42 7]" {
+		t.Errorf("Expected: %s, got %v", "[42 7]", v.AsInt64Slice())
+	}
+
+	if v, _ := attributeValue(attrs, AttemptsKey); v.AsInt64() != 2 {
+		t.Errorf("Expected: %d attempts, got %d", 2, v.AsInt64())
+	}
+
+	if v, _ := attributeValue(attrs, StatusCodeKey); v.AsInt64() != http.StatusOK {
+		t.Errorf("Expected: %d, got %d", http.StatusOK, v.AsInt64())
+	}
+}
+
+func TestMiddleware_Error(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testEnv.Mux.HandleFunc("/resources/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	client, recorder, reader := newTestClient(t, testEnv.Server.URL)
+
+	if _, _, err := client.Resources.Get(context.Background(), 1); !gcore.IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected: %d spans, got %d", 1, len(spans))
+	}
+
+	if spans[0].Status().Code != codes.Error {
+		t.Errorf("Expected: %v, got %v", codes.Error, spans[0].Status().Code)
+	}
+
+	rm := metricdata.ResourceMetrics{}
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	found := map[string]bool{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			found[m.Name] = true
+
+			if m.Name != errorsMetricName {
+				continue
+			}
+
+			sum := m.Data.(metricdata.Sum[int64])
+			if len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != 1 {
+				t.Errorf("unexpected errors data points: %+v", sum.DataPoints)
+				continue
+			}
+
+			endpoint, _ := sum.DataPoints[0].Attributes.Value(EndpointKey)
+			if endpoint.AsString() != "/resources/{id}" {
+				t.Errorf("Expected: %s, got %s", "/resources/{id}", endpoint.AsString())
+			}
+		}
+	}
+
+	for _, name := range []string{durationMetricName, errorsMetricName} {
+		if !found[name] {
+			t.Errorf("metric %s wasn't recorded", name)
+		}
+	}
+}
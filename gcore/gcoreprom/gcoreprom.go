@@ -0,0 +1,199 @@
+// Package gcoreprom provides Prometheus metrics collector for go-gcore clients.
+//
+// The collector counts requests, errors by status class, retries, rate limiter
+// waits and token refreshes per service and records latency histograms:
+//
+//	client := gcore.NewCommonClient()
+//	collector, err := gcoreprom.Register(prometheus.DefaultRegisterer, client.Client)
+package gcoreprom
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/dstdfx/go-gcore/gcore"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// namespace represents namespace of the metrics.
+	namespace = "gcore"
+
+	// subsystem represents subsystem of the metrics.
+	subsystem = "client"
+)
+
+// The list of the metrics labels.
+const (
+	ServiceLabel   = "service"
+	MethodLabel    = "method"
+	CodeClassLabel = "code_class"
+)
+
+// codeClassNetwork represents status class of the calls that didn't get
+// a response.
+const codeClassNetwork = "network"
+
+// Collector represents Prometheus collector of the API client usage metrics.
+type Collector struct {
+	requests       *prometheus.CounterVec
+	errors         *prometheus.CounterVec
+	retries        *prometheus.CounterVec
+	rateLimitWait  *prometheus.CounterVec
+	tokenRefreshes *prometheus.CounterVec
+	duration       *prometheus.HistogramVec
+}
+
+// NewCollector returns a reference to a new collector. Histogram buckets
+// are prometheus.DefBuckets if none are given.
+func NewCollector(buckets ...float64) *Collector {
+	if len(buckets) == 0 {
+		buckets = prometheus.DefBuckets
+	}
+
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "requests_total",
+			Help:      "Number of G-Core API calls.",
+		}, []string{ServiceLabel, MethodLabel, CodeClassLabel}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "errors_total",
+			Help:      "Number of failed G-Core API calls by status class.",
+		}, []string{ServiceLabel, CodeClassLabel}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "retries_total",
+			Help:      "Number of retried G-Core API requests.",
+		}, []string{ServiceLabel}),
+		rateLimitWait: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "rate_limit_wait_seconds_total",
+			Help:      "Time G-Core API calls waited for the client-side rate limiter.",
+		}, []string{ServiceLabel}),
+		tokenRefreshes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "token_refreshes_total",
+			Help:      "Number of tokens got by G-Core API client.",
+		}, []string{ServiceLabel}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of G-Core API calls including retries.",
+			Buckets:   buckets,
+		}, []string{ServiceLabel, MethodLabel}),
+	}
+}
+
+// Register creates a collector, registers it with given registerer and
+// instruments given client with it. Use Instrument to add more clients to
+// the same collector.
+func Register(registerer prometheus.Registerer, client *gcore.Client) (*Collector, error) {
+	collector := NewCollector()
+	if err := registerer.Register(collector); err != nil {
+		return nil, err
+	}
+
+	collector.Instrument(client)
+
+	return collector, nil
+}
+
+// Describe implements prometheus.Collector interface.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.errors.Describe(ch)
+	c.retries.Describe(ch)
+	c.rateLimitWait.Describe(ch)
+	c.tokenRefreshes.Describe(ch)
+	c.duration.Describe(ch)
+}
+
+// Collect implements prometheus.Collector interface.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.errors.Collect(ch)
+	c.retries.Collect(ch)
+	c.rateLimitWait.Collect(ch)
+	c.tokenRefreshes.Collect(ch)
+	c.duration.Collect(ch)
+}
+
+// Instrument adds the collector's middleware to the client as the outermost
+// one. It changes client.Middlewares without synchronization, so it must be
+// called before the first request of the client and never concurrently with
+// its calls.
+func (c *Collector) Instrument(client *gcore.Client) {
+	client.Middlewares = append([]gcore.Middleware{c.Middleware()}, client.Middlewares...)
+}
+
+// Option returns client option that adds the collector's middleware
+// to the client.
+func (c *Collector) Option() gcore.Option {
+	return gcore.WithMiddleware(c.Middleware())
+}
+
+// Middleware returns middleware that records metrics of every API call.
+func (c *Collector) Middleware() gcore.Middleware {
+	return func(next gcore.RoundTripFunc) gcore.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			info := gcore.CallInfoFromContext(req.Context())
+			if info == nil {
+				return next(req)
+			}
+
+			start := time.Now()
+			resp, err := next(req)
+			elapsed := time.Since(start).Seconds()
+
+			codeClass := codeClassNetwork
+			if resp != nil {
+				codeClass = statusClass(resp.StatusCode)
+			}
+
+			c.requests.WithLabelValues(info.Service, req.Method, codeClass).Inc()
+			c.duration.WithLabelValues(info.Service, req.Method).Observe(elapsed)
+
+			if err != nil {
+				c.errors.WithLabelValues(info.Service, codeClass).Inc()
+			}
+
+			if info.Retries > 0 {
+				c.retries.WithLabelValues(info.Service).Add(float64(info.Retries))
+			}
+
+			if info.RateLimitWait > 0 {
+				c.rateLimitWait.WithLabelValues(info.Service).Add(info.RateLimitWait.Seconds())
+			}
+
+			if info.TokenRefreshes > 0 {
+				c.tokenRefreshes.WithLabelValues(info.Service).Add(float64(info.TokenRefreshes))
+			}
+
+			return resp, err
+		}
+	}
+}
+
+// statusClass returns class of given status code, e.g. "4xx".
+func statusClass(code int) string {
+	switch {
+	case code >= 500:
+		return "5xx"
+	case code >= 400:
+		return "4xx"
+	case code >= 300:
+		return "3xx"
+	case code >= 200:
+		return "2xx"
+	}
+
+	return "1xx"
+}
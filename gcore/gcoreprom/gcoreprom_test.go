@@ -0,0 +1,156 @@
+package gcoreprom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dstdfx/go-gcore/gcore"
+	th "github.com/dstdfx/go-gcore/gcore/internal/testhelper"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// newTestClient returns client instrumented with a new collector registered
+// in a new registry.
+func newTestClient(t *testing.T, serverURL string) (*gcore.CommonClient, *prometheus.Registry) {
+	client, err := gcore.NewCommon(
+		gcore.WithBaseURL(serverURL),
+		gcore.WithToken(&gcore.Token{Value: th.TestFakeToken}),
+		gcore.WithRetryPolicy(&gcore.RetryPolicy{
+			MaxAttempts:          3,
+			RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registry := prometheus.NewRegistry()
+	if _, err = Register(registry, client.Client); err != nil {
+		t.Fatal(err)
+	}
+
+	return client, registry
+}
+
+func TestCollector(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	calls := 0
+	testEnv.Mux.HandleFunc("/resources/42/rules/7", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": 7}`)
+	})
+	testEnv.Mux.HandleFunc("/resources/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	client, registry := newTestClient(t, testEnv.Server.URL)
+
+	if _, _, err := client.Rules.Get(context.Background(), 42, 7); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := client.Resources.Get(context.Background(), 1); !gcore.IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	expected := `
+# HELP gcore_client_errors_total Number of failed G-Core API calls by status class.
+# TYPE gcore_client_errors_total counter
+gcore_client_errors_total{code_class="4xx",service="Resources"} 1
+# HELP gcore_client_requests_total Number of G-Core API calls.
+# TYPE gcore_client_requests_total counter
+gcore_client_requests_total{code_class="2xx",method="GET",service="Rules"} 1
+gcore_client_requests_total{code_class="4xx",method="GET",service="Resources"} 1
+# HELP gcore_client_retries_total Number of retried G-Core API requests.
+# TYPE gcore_client_retries_total counter
+gcore_client_retries_total{service="Rules"} 1
+`
+	err := testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"gcore_client_errors_total",
+		"gcore_client_requests_total",
+		"gcore_client_retries_total")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count := testutil.CollectAndCount(registry, "gcore_client_request_duration_seconds"); count != 2 {
+		t.Errorf("Expected: %d duration series, got %d", 2, count)
+	}
+}
+
+func TestCollector_Reauthentication(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testEnv.Mux.HandleFunc("/resources/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "APIKey fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": 1}`)
+	})
+
+	client, err := gcore.NewCommon(
+		gcore.WithBaseURL(testEnv.Server.URL),
+		gcore.WithToken(&gcore.Token{Value: "stale", Scheme: gcore.APIKeyAuthScheme}),
+		gcore.WithCredentials(gcore.NewAPITokenCredentials("fresh")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registry := prometheus.NewRegistry()
+	if _, err = Register(registry, client.Client); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = client.Resources.Get(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := testutil.CollectAndCount(registry, "gcore_client_retries_total"); count != 0 {
+		t.Errorf("Expected: no retries, got %d series", count)
+	}
+}
+
+func TestCollector_Register_Duplicate(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	client := gcore.NewCommonClient()
+
+	if _, err := Register(registry, client.Client); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Register(registry, client.Client); err == nil {
+		t.Error("expected error on duplicate registration")
+	}
+}
+
+func TestStatusClass(t *testing.T) {
+	testCases := map[int]string{
+		http.StatusOK:                  "2xx",
+		http.StatusNoContent:           "2xx",
+		http.StatusFound:               "3xx",
+		http.StatusNotFound:            "4xx",
+		http.StatusTooManyRequests:     "4xx",
+		http.StatusInternalServerError: "5xx",
+	}
+
+	for code, expected := range testCases {
+		if got := statusClass(code); got != expected {
+			t.Errorf("%d: Expected: %s, got %s", code, expected, got)
+		}
+	}
+}
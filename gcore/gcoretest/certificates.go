@@ -0,0 +1,198 @@
+package gcoretest
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// The PEM markers the certificates and the private keys are checked for.
+const (
+	certificateMarker = "-----BEGIN CERTIFICATE-----"
+	privateKeyMarker  = "PRIVATE KEY-----"
+)
+
+// AddCertificate stores the certificate as is and returns its copy with
+// assigned ID if it doesn't have one.
+func (s *Server) AddCertificate(cert *gcore.CertSSL) *gcore.CertSSL {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := clone(cert)
+	if stored.ID == 0 {
+		stored.ID = s.newID()
+	}
+	s.certificates[stored.ID] = stored
+
+	return clone(stored)
+}
+
+// Certificate returns copy of the stored certificate by its ID.
+func (s *Server) Certificate(id int) (*gcore.CertSSL, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cert, ok := s.certificates[id]
+	if !ok {
+		return nil, false
+	}
+
+	return clone(s.withRelatedResources(cert)), true
+}
+
+// listCertificates handles GET /sslData.
+func (s *Server) listCertificates(w http.ResponseWriter, r *http.Request) {
+	q, ok := parseListQuery(w, r)
+	if !ok {
+		return
+	}
+	name := r.URL.Query().Get("name")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	certs := make([]*gcore.CertSSL, 0, len(s.certificates))
+	for _, id := range sortedIDs(s.certificates) {
+		if cert := s.certificates[id]; name == "" || cert.Name == name {
+			certs = append(certs, s.withRelatedResources(cert))
+		}
+	}
+
+	writeList(w, q, certs, map[string]func(a, b *gcore.CertSSL) int{
+		"id":   byID(func(c *gcore.CertSSL) int { return c.ID }),
+		"name": byString(func(c *gcore.CertSSL) string { return c.Name }),
+	})
+}
+
+// getCertificate handles GET /sslData/{id}.
+func (s *Server) getCertificate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cert, ok := s.findCertificate(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.withRelatedResources(cert))
+}
+
+// createCertificate handles POST /sslData. Details of the certificate are
+// filled if it can be parsed, otherwise only PEM markers are checked.
+func (s *Server) createCertificate(w http.ResponseWriter, r *http.Request) {
+	var body gcore.AddCertBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v := &validator{}
+	if body.Name == "" {
+		v.add("name", "This field is required.")
+	}
+	for _, cert := range s.certificates {
+		if body.Name != "" && cert.Name == body.Name && !cert.Deleted {
+			v.add("name", "SSL certificate with this name already exists.")
+			break
+		}
+	}
+	if !strings.Contains(body.Certificate, certificateMarker) {
+		v.add("sslCertificate", "Invalid certificate.")
+	}
+	if !strings.Contains(body.PrivateKey, privateKeyMarker) {
+		v.add("sslPrivateKey", "Invalid private key.")
+	}
+	if v.write(w) {
+		return
+	}
+
+	cert := &gcore.CertSSL{
+		ID:               s.newID(),
+		Name:             body.Name,
+		CertificateChain: body.Certificate,
+	}
+	fillCertificate(cert, body.Certificate)
+	s.certificates[cert.ID] = cert
+
+	writeJSON(w, http.StatusCreated, cert)
+}
+
+// deleteCertificate handles DELETE /sslData/{id}, certificates used by
+// resources can't be deleted.
+func (s *Server) deleteCertificate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cert, ok := s.findCertificate(w, r)
+	if !ok {
+		return
+	}
+
+	if s.withRelatedResources(cert).HasRelatedResources {
+		writeError(w, http.StatusBadRequest, "SSL certificate is used by CDN resources.", nil)
+		return
+	}
+
+	delete(s.certificates, cert.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// fillCertificate fills details of the certificate from its PEM data.
+func fillCertificate(cert *gcore.CertSSL, data string) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return
+	}
+
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return
+	}
+
+	cert.CertIssuer = parsed.Issuer.CommonName
+	cert.CertSubjectCn = parsed.Subject.CommonName
+	cert.ValidityNotBefore = gcore.NewTime(parsed.NotBefore.UTC().Truncate(time.Second))
+	cert.ValidityNotAfter = gcore.NewTime(parsed.NotAfter.UTC().Truncate(time.Second))
+	if len(parsed.DNSNames) > 0 {
+		alt := strings.Join(parsed.DNSNames, ",")
+		cert.CertSubjectAlt = &alt
+	}
+}
+
+// withRelatedResources sets HasRelatedResources of the certificate.
+// It must be called with the lock held.
+func (s *Server) withRelatedResources(cert *gcore.CertSSL) *gcore.CertSSL {
+	cert.HasRelatedResources = false
+	for _, resource := range s.resources {
+		if resource.SslData != nil && *resource.SslData == cert.ID && !resource.Deleted {
+			cert.HasRelatedResources = true
+			break
+		}
+	}
+
+	return cert
+}
+
+// findCertificate returns the certificate by the path ID or writes 404
+// response. It must be called with the lock held.
+func (s *Server) findCertificate(w http.ResponseWriter, r *http.Request) (*gcore.CertSSL, bool) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return nil, false
+	}
+
+	cert, ok := s.certificates[id]
+	if !ok {
+		writeNotFound(w)
+		return nil, false
+	}
+
+	return cert, true
+}
@@ -0,0 +1,122 @@
+package gcoretest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// newTestCertificate returns self-signed certificate and its private key
+// in PEM format.
+func newTestCertificate(t *testing.T, cn string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     []string{cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestServer_Certificates(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	certificate, privateKey := newTestCertificate(t, "cdn.example.com")
+
+	cert, _, err := client.Certificates.Add(ctx, &gcore.AddCertBody{
+		Name:        "cdn",
+		Certificate: certificate,
+		PrivateKey:  privateKey,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cert.CertSubjectCn != "cdn.example.com" || cert.ValidityNotAfter == nil {
+		t.Errorf("expected details of the certificate, got: %+v", cert)
+	}
+
+	group, _, err := client.OriginGroups.Create(ctx, &gcore.CreateOriginGroupBody{
+		Name:    "origins",
+		Origins: []gcore.Origin{{Source: "origin.example.com"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource, _, err := client.Resources.Create(ctx, &gcore.CreateResourceBody{
+		Cname:         "cdn.example.com",
+		OriginGroupID: &group.ID,
+		SslData:       &cert.ID,
+		SslEnabled:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, _, err := client.Certificates.Get(ctx, cert.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.HasRelatedResources {
+		t.Error("expected certificate to have related resources")
+	}
+
+	if _, err = client.Certificates.Delete(ctx, cert.ID); !gcore.IsValidation(err) {
+		t.Errorf("expected error of used certificate, got: %v", err)
+	}
+
+	if _, err = client.Resources.Delete(ctx, resource.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.Certificates.Delete(ctx, cert.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = client.Certificates.Get(ctx, cert.ID)
+	if !gcore.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestServer_Certificates_Validation(t *testing.T) {
+	_, client := newTestClient(t)
+
+	_, _, err := client.Certificates.Add(context.Background(), &gcore.AddCertBody{
+		Name:        "cdn",
+		Certificate: "certificate",
+		PrivateKey:  "key",
+	})
+	if !gcore.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
@@ -0,0 +1,411 @@
+package gcoretest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// The list of the statuses of the clients and the services.
+const (
+	clientStatusTrial = "trial"
+	serviceStatusNew  = "new"
+)
+
+// defaultServices represents names of the services of the created clients.
+var defaultServices = []string{"CDN", "STORAGE", "STREAMING"}
+
+// defaultRegions returns billing regions of the fake.
+func defaultRegions() []*gcore.Region {
+	return []*gcore.Region{
+		{ID: 1, Name: "na", Description: "North America"},
+		{ID: 2, Name: "eu", Description: "Europe", Required: true},
+		{ID: 3, Name: "cis", Description: "CIS"},
+		{ID: 4, Name: "asia", Description: "Asia"},
+		{ID: 5, Name: "au", Description: "Australia"},
+		{ID: 6, Name: "latam", Description: "Latin America"},
+	}
+}
+
+// AddClient stores the client as is and returns its copy with assigned ID
+// if it doesn't have one. The client gets the default services unless it
+// already has them.
+func (s *Server) AddClient(client *gcore.ClientAccount) *gcore.ClientAccount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := clone(client)
+	if stored.ID == 0 {
+		stored.ID = s.newID()
+	}
+	s.clients[stored.ID] = stored
+	s.addServices(stored.ID)
+
+	return clone(stored)
+}
+
+// Client returns copy of the stored client by its ID.
+func (s *Server) Client(id int) (*gcore.ClientAccount, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	client, ok := s.clients[id]
+	if !ok {
+		return nil, false
+	}
+
+	return clone(client), true
+}
+
+// accountDetails handles GET /clients/me.
+func (s *Server) accountDetails(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, &gcore.Account{
+		ID:          AccountID,
+		CurrentUser: AccountID,
+		Users: []gcore.User{{
+			ID:     AccountID,
+			Email:  s.username,
+			Client: AccountID,
+			Lang:   "en",
+			Groups: []*gcore.Group{{ID: 1, Name: "Administrators"}},
+		}},
+		Cname: "cl-1.gcdn.co",
+	})
+}
+
+// listClients handles GET /clients, clients are filtered by email, name and
+// companyName query parameters.
+func (s *Server) listClients(w http.ResponseWriter, r *http.Request) {
+	q, ok := parseListQuery(w, r)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clients := make([]*gcore.ClientAccount, 0, len(s.clients))
+	for _, id := range sortedIDs(s.clients) {
+		client := s.clients[id]
+
+		switch {
+		case query.Get("email") != "" && !strings.EqualFold(client.Email, query.Get("email")),
+			query.Get("name") != "" && client.Name != query.Get("name"),
+			query.Get("companyName") != "" && client.CompanyName != query.Get("companyName"):
+			continue
+		}
+
+		clients = append(clients, client)
+	}
+
+	writeList(w, q, clients, map[string]func(a, b *gcore.ClientAccount) int{
+		"id":   byID(func(c *gcore.ClientAccount) int { return c.ID }),
+		"name": byString(func(c *gcore.ClientAccount) string { return c.Name }),
+	})
+}
+
+// getClient handles GET /clients/{id}.
+func (s *Server) getClient(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	client, ok := s.findClient(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, client)
+}
+
+// createClient handles POST /users that creates a client with a single
+// user.
+func (s *Server) createClient(w http.ResponseWriter, r *http.Request) {
+	var body gcore.CreateClientBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v := &validator{}
+	if body.Email == "" {
+		v.add("email", "This field is required.")
+	} else if s.emailTaken(body.Email, 0) {
+		v.add("email", "User with this email already exists.")
+	}
+	if body.Password == "" {
+		v.add("password", "This field is required.")
+	}
+	if v.write(w) {
+		return
+	}
+
+	id := s.newID()
+	user := &gcore.User{
+		ID:       s.newID(),
+		Email:    body.Email,
+		Name:     body.Name,
+		Client:   id,
+		Company:  body.Company,
+		Lang:     "en",
+		Phone:    body.Phone,
+		Reseller: AccountID,
+		Groups:   []*gcore.Group{},
+	}
+	client := &gcore.ClientAccount{
+		ID:          id,
+		Client:      id,
+		Users:       []*gcore.User{user},
+		CurrentUser: user.ID,
+		Email:       body.Email,
+		Phone:       body.Phone,
+		Name:        body.Name,
+		Status:      clientStatusTrial,
+		Created:     now(),
+		Updated:     now(),
+		CompanyName: body.Company,
+		Reseller:    AccountID,
+	}
+	s.clients[id] = client
+	s.addServices(id)
+
+	writeJSON(w, http.StatusCreated, client)
+}
+
+// updateClient handles PUT /clients/{id}.
+func (s *Server) updateClient(w http.ResponseWriter, r *http.Request) {
+	var body gcore.UpdateClientBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	client, ok := s.findClient(w, r)
+	if !ok {
+		return
+	}
+
+	v := &validator{}
+	if body.Email == "" {
+		v.add("email", "This field may not be blank.")
+	} else if s.emailTaken(body.Email, client.ID) {
+		v.add("email", "User with this email already exists.")
+	}
+	if v.write(w) {
+		return
+	}
+
+	client.Name = body.Name
+	client.CompanyName = body.CompanyName
+	client.Phone = body.Phone
+	client.Email = body.Email
+	client.Updated = now()
+
+	writeJSON(w, http.StatusOK, client)
+}
+
+// userToken handles GET /users/{id}/token that returns token of the user of
+// a client.
+func (s *Server) userToken(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, client := range s.clients {
+		for _, user := range client.Users {
+			if user.ID == id {
+				writeJSON(w, http.StatusOK, s.issueToken())
+				return
+			}
+		}
+	}
+
+	writeNotFound(w)
+}
+
+// listServices handles GET /clients/{id}/services.
+func (s *Server) listServices(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	client, ok := s.findClient(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.services[client.ID])
+}
+
+// updateService handles PATCH /clients/{id}/services/{serviceID}.
+func (s *Server) updateService(w http.ResponseWriter, r *http.Request) {
+	var body gcore.UpdateServiceBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	client, ok := s.findClient(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := pathID(w, r, "serviceID")
+	if !ok {
+		return
+	}
+
+	for _, service := range s.services[client.ID] {
+		if service.ID != id {
+			continue
+		}
+
+		service.Enabled = body.Enabled
+		if body.Status != "" {
+			service.Status = body.Status
+		}
+		if service.Enabled && service.Start == nil {
+			service.Start = now()
+		}
+
+		writeJSON(w, http.StatusOK, service)
+		return
+	}
+
+	writeNotFound(w)
+}
+
+// listRegions handles GET /admin/billing_regions.
+func (s *Server) listRegions(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, s.regions)
+}
+
+// getRestrictions handles GET /admin/clients/{id}, it responds with 404 until
+// the restrictions of the client are set.
+func (s *Server) getRestrictions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	client, ok := s.findClient(w, r)
+	if !ok {
+		return
+	}
+
+	restrictions, ok := s.restrictions[client.ID]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, restrictions)
+}
+
+// setRestrictions handles POST /admin/clients/{id}.
+func (s *Server) setRestrictions(w http.ResponseWriter, r *http.Request) {
+	var body gcore.GeoRestrictions
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	client, ok := s.findClient(w, r)
+	if !ok {
+		return
+	}
+
+	v := &validator{}
+	for _, id := range body.RegionList {
+		if !s.regionExists(id) {
+			v.add("region_list", "Billing region with this id doesn't exist.")
+			break
+		}
+	}
+	if v.write(w) {
+		return
+	}
+
+	if body.RegionList == nil {
+		body.RegionList = []int{}
+	}
+	s.restrictions[client.ID] = &body
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// addServices adds the default services to the client unless it has
+// services. It must be called with the lock held.
+func (s *Server) addServices(clientID int) {
+	if _, ok := s.services[clientID]; ok {
+		return
+	}
+
+	services := make([]*gcore.Service, 0, len(defaultServices))
+	for _, name := range defaultServices {
+		services = append(services, &gcore.Service{
+			ID:     s.newID(),
+			Name:   name,
+			Client: clientID,
+			Status: serviceStatusNew,
+		})
+	}
+	s.services[clientID] = services
+}
+
+// emailTaken reports whether another client has the email. It must be
+// called with the lock held.
+func (s *Server) emailTaken(email string, exceptID int) bool {
+	for _, client := range s.clients {
+		if client.ID != exceptID && strings.EqualFold(client.Email, email) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// regionExists reports whether the billing region exists. It must be
+// called with the lock held.
+func (s *Server) regionExists(id int) bool {
+	for _, region := range s.regions {
+		if region.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findClient returns the client by the path ID or writes 404 response.
+// It must be called with the lock held.
+func (s *Server) findClient(w http.ResponseWriter, r *http.Request) (*gcore.ClientAccount, bool) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return nil, false
+	}
+
+	client, ok := s.clients[id]
+	if !ok {
+		writeNotFound(w)
+		return nil, false
+	}
+
+	return client, true
+}
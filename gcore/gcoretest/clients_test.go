@@ -0,0 +1,125 @@
+package gcoretest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+func TestServer_Clients(t *testing.T) {
+	_, client := newTestResellerClient(t)
+	ctx := context.Background()
+
+	created, _, err := client.Clients.Create(ctx, &gcore.CreateClientBody{
+		UserType: "common",
+		Name:     "John",
+		Company:  "Example",
+		Email:    "john@example.com",
+		Password: "secret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Status != "trial" || len(created.Users) != 1 || created.Reseller != AccountID {
+		t.Errorf("unexpected client: %+v", created)
+	}
+
+	_, _, err = client.Clients.Create(ctx, &gcore.CreateClientBody{
+		Email:    "john@example.com",
+		Password: "secret",
+	})
+	if !gcore.IsValidation(err) {
+		t.Errorf("expected validation error of duplicate email, got: %v", err)
+	}
+
+	body := created.UpdateBody()
+	body.Phone = "+1000000"
+
+	updated, _, err := client.Clients.Update(ctx, created.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Phone != "+1000000" {
+		t.Errorf("unexpected updated client: %+v", updated)
+	}
+
+	clients, _, err := client.Clients.List(ctx, gcore.ListOpts{Email: "john@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clients) != 1 || clients[0].ID != created.ID {
+		t.Errorf("unexpected clients: %+v", clients)
+	}
+
+	_, _, err = client.Clients.Get(ctx, created.ID+1000)
+	if !gcore.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestServer_Services(t *testing.T) {
+	server, client := newTestResellerClient(t)
+	ctx := context.Background()
+
+	account := server.AddClient(&gcore.ClientAccount{Email: "jane@example.com"})
+
+	services, _, err := client.Services.List(ctx, account.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(services) != len(defaultServices) {
+		t.Fatalf("unexpected services: %+v", services)
+	}
+
+	updated, _, err := client.Services.Update(ctx, account.ID, services[0].ID, &gcore.UpdateServiceBody{
+		Enabled: true,
+		Status:  "active",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !updated.Enabled || updated.Status != "active" || updated.Start == nil {
+		t.Errorf("unexpected updated service: %+v", updated)
+	}
+}
+
+func TestServer_GeoRestrictions(t *testing.T) {
+	server, client := newTestResellerClient(t)
+	ctx := context.Background()
+
+	account := server.AddClient(&gcore.ClientAccount{Email: "jane@example.com"})
+
+	_, _, err := client.GeoRestrictions.GetRestrictions(ctx, account.ID)
+	if !gcore.IsNotFound(err) {
+		t.Errorf("expected not found error before restrictions are set, got: %v", err)
+	}
+
+	regions, _, err := client.GeoRestrictions.ListRegions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.GeoRestrictions.SetRestrictions(ctx, account.ID, &gcore.GeoRestrictions{
+		IsIn:       true,
+		RegionList: []int{regions[0].ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restrictions, _, err := client.GeoRestrictions.GetRestrictions(ctx, account.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !restrictions.IsIn || len(restrictions.RegionList) != 1 {
+		t.Errorf("unexpected restrictions: %+v", restrictions)
+	}
+
+	_, err = client.GeoRestrictions.SetRestrictions(ctx, account.ID, &gcore.GeoRestrictions{
+		RegionList: []int{1000},
+	})
+	if !gcore.IsValidation(err) {
+		t.Errorf("expected validation error of unknown region, got: %v", err)
+	}
+}
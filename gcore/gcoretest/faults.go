@@ -0,0 +1,126 @@
+package gcoretest
+
+import (
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+)
+
+// Fault represents error response the fake returns instead of handling
+// matching requests.
+type Fault struct {
+	// Method represents method of the matching requests, any method matches
+	// if it's empty.
+	Method string
+
+	// Path represents path pattern of the matching requests in the syntax of
+	// path.Match, e.g. "/resources/*", any path matches if it's empty.
+	Path string
+
+	// Status represents status code of the response.
+	Status int
+
+	// Body represents body of the response, the error payload with the status
+	// text is used if it's empty.
+	Body string
+
+	// Header represents additional headers of the response.
+	Header http.Header
+
+	// Times represents number of the requests the fault is returned for,
+	// it's returned for all requests if it's zero.
+	Times int
+}
+
+// matches reports whether the fault is returned for the request.
+func (f *Fault) matches(r *http.Request) bool {
+	if f.Method != "" && f.Method != r.Method {
+		return false
+	}
+	if f.Path == "" {
+		return true
+	}
+
+	ok, err := path.Match(f.Path, r.URL.Path)
+
+	return err == nil && ok
+}
+
+// write writes the fault response.
+func (f *Fault) write(w http.ResponseWriter) {
+	for key, values := range f.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+
+	if f.Body == "" {
+		writeError(w, f.Status, http.StatusText(f.Status), nil)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(f.Status)
+	_, _ = w.Write([]byte(f.Body))
+}
+
+// InjectFault makes the fake return the fault for matching requests.
+// Faults are checked in the order they've been injected.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault)
+}
+
+// InjectRateLimit makes the fake respond to given number of the next
+// requests with 429 and Retry-After header set to retryAfter.
+func (s *Server) InjectRateLimit(times int, retryAfter time.Duration) {
+	header := http.Header{}
+	header.Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+
+	s.InjectFault(Fault{
+		Status: http.StatusTooManyRequests,
+		Body:   `{"message": "Request was throttled."}`,
+		Header: header,
+		Times:  times,
+	})
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// SetLatency sets delay of all responses of the fake.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency = d
+}
+
+// takeFault returns the first fault matching the request and decrements
+// its counter. It must be called with the lock held.
+func (s *Server) takeFault(r *http.Request) *Fault {
+	for i, fault := range s.faults {
+		if !fault.matches(r) {
+			continue
+		}
+
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+
+		return fault
+	}
+
+	return nil
+}
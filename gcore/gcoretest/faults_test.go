@@ -0,0 +1,90 @@
+package gcoretest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+func TestServer_InjectFault(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	server.InjectFault(Fault{
+		Method: http.MethodGet,
+		Path:   "/resources/*",
+		Status: http.StatusForbidden,
+		Times:  1,
+	})
+
+	_, _, err := client.Resources.Get(ctx, 1)
+	if !gcore.IsForbidden(err) {
+		t.Fatalf("expected forbidden error, got: %v", err)
+	}
+
+	_, _, err = client.Resources.Get(ctx, 1)
+	if !gcore.IsNotFound(err) {
+		t.Fatalf("expected not found error after the fault, got: %v", err)
+	}
+}
+
+func TestServer_InjectFault_Body(t *testing.T) {
+	server, client := newTestClient(t)
+
+	server.InjectFault(Fault{
+		Path:   "/originGroups",
+		Status: http.StatusConflict,
+		Body:   `{"message": "Origin group is locked."}`,
+	})
+	defer server.ClearFaults()
+
+	_, _, err := client.OriginGroups.List(context.Background())
+
+	var apiErr *gcore.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict ||
+		apiErr.Message != "Origin group is locked." {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestServer_InjectRateLimit(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client, err := server.CommonClient(gcore.WithRetryPolicy(gcore.DefaultRetryPolicy()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Sign in first, so the rate limit applies to the listed request.
+	if _, _, err := client.Resources.List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	server.InjectRateLimit(2, 0)
+
+	if _, _, err := client.Resources.List(context.Background()); err != nil {
+		t.Fatalf("expected the request to be retried, got: %v", err)
+	}
+
+	if got := len(server.Requests()); got != 5 {
+		t.Errorf("expected 5 requests, got %d", got)
+	}
+}
+
+func TestServer_SetLatency(t *testing.T) {
+	server, client := newTestClient(t, WithAPIToken("token"))
+	server.SetLatency(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, _, err := client.Resources.List(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded error, got: %v", err)
+	}
+}
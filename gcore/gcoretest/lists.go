@@ -0,0 +1,84 @@
+package gcoretest
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// listQuery represents ordering and pagination of a list request.
+type listQuery struct {
+	ordering string
+	limit    int
+	offset   int
+
+	// paginated reports whether the response is a paginated object,
+	// the API returns a plain array unless limit or offset is set.
+	paginated bool
+}
+
+// parseListQuery parses ordering and pagination of the request, it responds
+// with 400 if they are invalid.
+func parseListQuery(w http.ResponseWriter, r *http.Request) (listQuery, bool) {
+	query := r.URL.Query()
+
+	limit, errLimit := queryInt(query.Get("limit"))
+	offset, errOffset := queryInt(query.Get("offset"))
+	if errLimit != nil || errOffset != nil {
+		writeError(w, http.StatusBadRequest, "Invalid query parameters.", nil)
+		return listQuery{}, false
+	}
+
+	return listQuery{
+		ordering:  query.Get("ordering"),
+		limit:     limit,
+		offset:    offset,
+		paginated: query.Has("limit") || query.Has("offset"),
+	}, true
+}
+
+// byID compares the objects by their IDs.
+func byID[T any](id func(T) int) func(a, b T) int {
+	return func(a, b T) int {
+		return cmp.Compare(id(a), id(b))
+	}
+}
+
+// byString compares the objects by a string field.
+func byString[T any](field func(T) string) func(a, b T) int {
+	return func(a, b T) int {
+		return cmp.Compare(field(a), field(b))
+	}
+}
+
+// writeList orders the objects by the known orderings, takes the requested
+// page and writes it. Unknown orderings are ignored like the API does,
+// the fields parameter isn't supported, so all fields are written.
+func writeList[T any](w http.ResponseWriter, q listQuery, objects []T, orderings map[string]func(a, b T) int) {
+	name, desc := strings.CutPrefix(q.ordering, "-")
+	if compare, ok := orderings[name]; ok {
+		slices.SortStableFunc(objects, func(a, b T) int {
+			if desc {
+				return compare(b, a)
+			}
+			return compare(a, b)
+		})
+	}
+
+	if !q.paginated {
+		writeJSON(w, http.StatusOK, objects)
+		return
+	}
+
+	count := len(objects)
+	objects = objects[min(q.offset, count):]
+	if q.limit > 0 {
+		objects = objects[:min(q.limit, len(objects))]
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"count":   count,
+		"results": objects,
+	})
+}
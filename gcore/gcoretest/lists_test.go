@@ -0,0 +1,84 @@
+package gcoretest
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+func TestServer_ListResources_Filters(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	first := server.AddResource(&gcore.Resource{Cname: "b.example.com", OriginGroup: 1, Status: "active"})
+	second := server.AddResource(&gcore.Resource{Cname: "a.example.com", OriginGroup: 2, Status: "suspended"})
+	third := server.AddResource(&gcore.Resource{Cname: "c.example.com", OriginGroup: 1, Status: "active", Deleted: true})
+
+	testCases := []struct {
+		name     string
+		opts     gcore.ListResourcesOpts
+		expected []int
+	}{
+		{name: "cname", opts: gcore.ListResourcesOpts{Cname: "a.example.com"}, expected: []int{second.ID}},
+		{name: "status", opts: gcore.ListResourcesOpts{Status: "suspended"}, expected: []int{second.ID}},
+		{name: "origin group", opts: gcore.ListResourcesOpts{OriginGroup: 1}, expected: []int{first.ID, third.ID}},
+		{name: "deleted", opts: gcore.ListResourcesOpts{Deleted: true}, expected: []int{third.ID}},
+		{name: "ordering", opts: gcore.ListResourcesOpts{Ordering: "cname"}, expected: []int{second.ID, first.ID, third.ID}},
+		{name: "reversed", opts: gcore.ListResourcesOpts{Ordering: "-id"}, expected: []int{third.ID, second.ID, first.ID}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resources, _, err := client.Resources.ListWithOpts(ctx, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := make([]int, 0, len(resources))
+			for _, resource := range resources {
+				ids = append(ids, resource.ID)
+			}
+			if !reflect.DeepEqual(ids, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, ids)
+			}
+		})
+	}
+}
+
+func TestServer_ListPage(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		server.AddOriginGroup(&gcore.OriginGroup{Name: name, Origins: []gcore.Origin{{Source: name + ".example.com"}}})
+	}
+
+	page, _, err := client.OriginGroups.ListPage(ctx, gcore.ListOriginGroupsOpts{Ordering: "-name", Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Count != 5 || len(page.Results) != 2 || page.Results[0].Name != "d" || !page.HasNext() {
+		t.Errorf("unexpected page: %+v", page)
+	}
+
+	var names []string
+	for group, err := range client.OriginGroups.ListAll(ctx, gcore.ListOriginGroupsOpts{Limit: 2}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, group.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"a", "b", "c", "d", "e"}) {
+		t.Errorf("unexpected origin groups: %v", names)
+	}
+
+	groups, _, err := client.OriginGroups.ListWithOpts(ctx, gcore.ListOriginGroupsOpts{Name: "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || groups[0].Name != "c" {
+		t.Errorf("unexpected origin groups: %+v", groups)
+	}
+}
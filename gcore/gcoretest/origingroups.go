@@ -0,0 +1,216 @@
+package gcoretest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// AddOriginGroup stores the origin group as is and returns its copy with
+// assigned ID if it doesn't have one.
+func (s *Server) AddOriginGroup(group *gcore.OriginGroup) *gcore.OriginGroup {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := clone(group)
+	if stored.ID == 0 {
+		stored.ID = s.newID()
+	}
+	s.originGroups[stored.ID] = stored
+
+	return clone(stored)
+}
+
+// OriginGroup returns copy of the stored origin group by its ID.
+func (s *Server) OriginGroup(id int) (*gcore.OriginGroup, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	group, ok := s.originGroups[id]
+	if !ok {
+		return nil, false
+	}
+
+	return clone(group), true
+}
+
+// listOriginGroups handles GET /originGroups.
+func (s *Server) listOriginGroups(w http.ResponseWriter, r *http.Request) {
+	q, ok := parseListQuery(w, r)
+	if !ok {
+		return
+	}
+	name := r.URL.Query().Get("name")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	groups := make([]*gcore.OriginGroup, 0, len(s.originGroups))
+	for _, id := range sortedIDs(s.originGroups) {
+		if group := s.originGroups[id]; name == "" || group.Name == name {
+			groups = append(groups, group)
+		}
+	}
+
+	writeList(w, q, groups, map[string]func(a, b *gcore.OriginGroup) int{
+		"id":   byID(func(g *gcore.OriginGroup) int { return g.ID }),
+		"name": byString(func(g *gcore.OriginGroup) string { return g.Name }),
+	})
+}
+
+// getOriginGroup handles GET /originGroups/{id}.
+func (s *Server) getOriginGroup(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	group, ok := s.findOriginGroup(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, group)
+}
+
+// createOriginGroup handles POST /originGroups.
+func (s *Server) createOriginGroup(w http.ResponseWriter, r *http.Request) {
+	var body gcore.CreateOriginGroupBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if validateOriginGroup(body.Name, body.Origins).write(w) {
+		return
+	}
+
+	group := &gcore.OriginGroup{
+		ID:      s.newID(),
+		Name:    body.Name,
+		UseNext: body.UseNext,
+		Origins: s.assignOriginIDs(body.Origins),
+	}
+	s.originGroups[group.ID] = group
+
+	writeJSON(w, http.StatusCreated, group)
+}
+
+// updateOriginGroup handles PUT /originGroups/{id}.
+func (s *Server) updateOriginGroup(w http.ResponseWriter, r *http.Request) {
+	var body gcore.UpdateOriginGroupBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	group, ok := s.findOriginGroup(w, r)
+	if !ok {
+		return
+	}
+
+	if validateOriginGroup(body.Name, body.Origins).write(w) {
+		return
+	}
+
+	group.Name = body.Name
+	group.UseNext = body.UseNext
+	group.Origins = s.assignOriginIDs(body.Origins)
+
+	writeJSON(w, http.StatusOK, group)
+}
+
+// deleteOriginGroup handles DELETE /originGroups/{id}, origin groups used by
+// resources or rules can't be deleted.
+func (s *Server) deleteOriginGroup(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	group, ok := s.findOriginGroup(w, r)
+	if !ok {
+		return
+	}
+
+	if s.originGroupUsed(group.ID) {
+		writeError(w, http.StatusBadRequest,
+			"Origin group "+strconv.Itoa(group.ID)+" is used by CDN resources or rules.", nil)
+		return
+	}
+
+	delete(s.originGroups, group.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validateOriginGroup returns validator with errors of the origin group.
+func validateOriginGroup(name string, origins []gcore.Origin) *validator {
+	v := &validator{}
+	if name == "" {
+		v.add("name", "This field is required.")
+	}
+	if len(origins) == 0 {
+		v.add("origins", "This list may not be empty.")
+	}
+	for _, origin := range origins {
+		if origin.Source == "" {
+			v.add("origins", "Source of the origin is required.")
+			break
+		}
+	}
+
+	return v
+}
+
+// assignOriginIDs returns copy of the origins with IDs assigned to the new
+// ones. It must be called with the lock held.
+func (s *Server) assignOriginIDs(origins []gcore.Origin) []gcore.Origin {
+	assigned := make([]gcore.Origin, len(origins))
+	for i, origin := range origins {
+		if origin.ID == 0 {
+			origin.ID = s.newID()
+		}
+		assigned[i] = origin
+	}
+
+	return assigned
+}
+
+// originGroupUsed reports whether the origin group is used by a resource or
+// a rule. It must be called with the lock held.
+func (s *Server) originGroupUsed(id int) bool {
+	for _, resource := range s.resources {
+		if resource.OriginGroup == id && !resource.Deleted {
+			return true
+		}
+	}
+
+	for _, rules := range s.rules {
+		for _, rule := range rules {
+			if rule.OriginGroup == id {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// findOriginGroup returns the origin group by the path ID or writes 404
+// response. It must be called with the lock held.
+func (s *Server) findOriginGroup(w http.ResponseWriter, r *http.Request) (*gcore.OriginGroup, bool) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return nil, false
+	}
+
+	group, ok := s.originGroups[id]
+	if !ok {
+		writeNotFound(w)
+		return nil, false
+	}
+
+	return group, true
+}
@@ -0,0 +1,61 @@
+package gcoretest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+func TestServer_OriginGroups(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	group, _, err := client.OriginGroups.Create(ctx, &gcore.CreateOriginGroupBody{
+		Name:    "origins",
+		Origins: []gcore.Origin{{Source: "a.example.com", Enabled: true}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if group.ID == 0 || group.Origins[0].ID == 0 {
+		t.Errorf("expected IDs to be assigned, got: %+v", group)
+	}
+
+	body := group.UpdateBody()
+	body.Origins = append(body.Origins, gcore.Origin{Source: "b.example.com", Backup: true})
+
+	updated, _, err := client.OriginGroups.Update(ctx, group.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updated.Origins) != 2 || updated.Origins[0].ID != group.Origins[0].ID {
+		t.Errorf("unexpected updated origin group: %+v", updated)
+	}
+
+	if _, err = client.OriginGroups.Delete(ctx, group.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = client.OriginGroups.Get(ctx, group.ID)
+	if !gcore.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestServer_OriginGroups_Validation(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	_, _, err := client.OriginGroups.Create(ctx, &gcore.CreateOriginGroupBody{Name: "empty"})
+	if !gcore.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+
+	resource := createTestResource(t, client, "cdn.example.com")
+
+	_, err = client.OriginGroups.Delete(ctx, resource.OriginGroup)
+	if !gcore.IsValidation(err) {
+		t.Errorf("expected error of used origin group, got: %v", err)
+	}
+}
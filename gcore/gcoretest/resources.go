@@ -0,0 +1,545 @@
+package gcoretest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// The list of the resource statuses.
+const (
+	resourceStatusActive    = "active"
+	resourceStatusSuspended = "suspended"
+)
+
+// defaultOriginProtocol represents origin protocol of the resources created
+// without it.
+const defaultOriginProtocol = "HTTP"
+
+// AddResource stores the resource as is and returns its copy with assigned
+// ID if it doesn't have one. It allows seeding the fake with the resources
+// that can't be created through the API like the deleted ones.
+func (s *Server) AddResource(resource *gcore.Resource) *gcore.Resource {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := clone(resource)
+	if stored.ID == 0 {
+		stored.ID = s.newID()
+	}
+	s.resources[stored.ID] = stored
+
+	return clone(stored)
+}
+
+// Resource returns copy of the stored resource by its ID.
+func (s *Server) Resource(id int) (*gcore.Resource, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resource, ok := s.resources[id]
+	if !ok {
+		return nil, false
+	}
+
+	return clone(resource), true
+}
+
+// listResources handles GET /resources.
+func (s *Server) listResources(w http.ResponseWriter, r *http.Request) {
+	q, ok := parseListQuery(w, r)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+
+	originGroup, err := queryInt(query.Get("originGroup"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid query parameters.", nil)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resources := make([]*gcore.Resource, 0, len(s.resources))
+	for _, id := range sortedIDs(s.resources) {
+		resource := s.resources[id]
+
+		switch {
+		case query.Get("cname") != "" && resource.Cname != query.Get("cname"),
+			query.Get("status") != "" && resource.Status != query.Get("status"),
+			query.Has("deleted") && strconv.FormatBool(resource.Deleted) != query.Get("deleted"),
+			originGroup != 0 && resource.OriginGroup != originGroup:
+			continue
+		}
+
+		resources = append(resources, resource)
+	}
+
+	writeList(w, q, resources, map[string]func(a, b *gcore.Resource) int{
+		"id":    byID(func(r *gcore.Resource) int { return r.ID }),
+		"cname": byString(func(r *gcore.Resource) string { return r.Cname }),
+	})
+}
+
+// getResource handles GET /resources/{id}.
+func (s *Server) getResource(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resource, ok := s.findResource(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resource)
+}
+
+// createResource handles POST /resources.
+func (s *Server) createResource(w http.ResponseWriter, r *http.Request) {
+	var body gcore.CreateResourceBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v := &validator{}
+	if body.Cname == "" {
+		v.add("cname", "This field is required.")
+	} else if s.cnameTaken(body.Cname, 0) {
+		v.add("cname", "CDN resource with this cname already exists.")
+	}
+	if body.OriginGroupID == nil && body.Origin == "" {
+		v.add("originGroup", "Either origin or originGroup should be specified.")
+	}
+	if body.OriginGroupID != nil {
+		s.checkOriginGroup(v, "originGroup", *body.OriginGroupID)
+	}
+	if body.SslData != nil {
+		s.checkCertificate(v, "sslData", *body.SslData)
+	}
+	v.options(body.Options)
+	if v.write(w) {
+		return
+	}
+
+	resource := &gcore.Resource{
+		ID:                 s.newID(),
+		Active:             true,
+		Enabled:            true,
+		Status:             resourceStatusActive,
+		Client:             AccountID,
+		Cname:              body.Cname,
+		SecondaryHostnames: nonNilStrings(body.SecondaryHostnames),
+		Options:            body.Options,
+		OriginProtocol:     body.OriginProtocol,
+		Rules:              []gcore.Rule{},
+		CreatedAt:          now(),
+		UpdatedAt:          now(),
+		SslData:            body.SslData,
+		SslEnabled:         body.SslEnabled,
+	}
+	if resource.OriginProtocol == "" {
+		resource.OriginProtocol = defaultOriginProtocol
+	}
+	if resource.Options == nil {
+		resource.Options = &gcore.Options{}
+	}
+
+	if body.OriginGroupID != nil {
+		resource.OriginGroup = *body.OriginGroupID
+	} else {
+		// The API creates an origin group for the resource created with
+		// a single origin.
+		group := &gcore.OriginGroup{
+			ID:      s.newID(),
+			Name:    body.Cname + "_" + strconv.Itoa(s.nextID),
+			Origins: []gcore.Origin{{ID: s.newID(), Enabled: true, Source: body.Origin}},
+		}
+		s.originGroups[group.ID] = group
+		resource.OriginGroup = group.ID
+	}
+
+	s.resources[resource.ID] = resource
+
+	writeJSON(w, http.StatusCreated, resource)
+}
+
+// updateResource handles PUT /resources/{id}. Options that are missing or
+// null in the request stay unchanged.
+func (s *Server) updateResource(w http.ResponseWriter, r *http.Request) {
+	var raw map[string]json.RawMessage
+	if !decode(w, r, &raw) {
+		return
+	}
+
+	var body gcore.UpdateResourceBody
+	if !decodeRaw(w, raw, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resource, ok := s.findResource(w, r)
+	if !ok {
+		return
+	}
+
+	v := &validator{}
+	if body.OriginGroup != 0 {
+		s.checkOriginGroup(v, "originGroup", body.OriginGroup)
+	}
+	if body.SslData != nil {
+		s.checkCertificate(v, "sslData", *body.SslData)
+	}
+	v.options(body.Options)
+	if v.write(w) {
+		return
+	}
+
+	if body.Active != nil {
+		resource.Active = *body.Active
+		resource.Status = resourceStatusActive
+		if !resource.Active {
+			resource.Status = resourceStatusSuspended
+		}
+	}
+	if body.Enabled != nil {
+		resource.Enabled = *body.Enabled
+	}
+	if body.OriginGroup != 0 {
+		resource.OriginGroup = body.OriginGroup
+	}
+	if _, ok := raw["secondaryHostnames"]; ok {
+		resource.SecondaryHostnames = nonNilStrings(body.SecondaryHostnames)
+	}
+	if body.OriginProtocol != "" {
+		resource.OriginProtocol = body.OriginProtocol
+	}
+	if body.SslData != nil {
+		resource.SslData = body.SslData
+	}
+	if body.SslEnabled != nil {
+		resource.SslEnabled = *body.SslEnabled
+	}
+	if body.Options != nil {
+		resource.Options = mergeOptions(resource.Options, body.Options)
+	}
+	updateExtra(resource.Extra, raw)
+	resource.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, resource)
+}
+
+// deleteResource handles DELETE /resources/{id}.
+func (s *Server) deleteResource(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resource, ok := s.findResource(w, r)
+	if !ok {
+		return
+	}
+
+	delete(s.resources, resource.ID)
+	delete(s.rules, resource.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// purge handles POST /resources/{id}/purge.
+func (s *Server) purge(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		URLs  *[]string `json:"urls"`
+		Paths []string  `json:"paths"`
+	}
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resource, ok := s.findResource(w, r)
+	if !ok {
+		return
+	}
+
+	purge := &gcore.PurgeStatus{
+		ID:       s.newID(),
+		Name:     resource.Cname,
+		Created:  now(),
+		Status:   gcore.PurgeStatusSuccessful,
+		Resource: gcore.PurgeStatusResource{ID: resource.ID, Cname: resource.Cname},
+		URLs:     []string{},
+	}
+
+	v := &validator{}
+	switch {
+	case body.URLs != nil:
+		if len(*body.URLs) == 0 {
+			v.add("urls", "This list may not be empty.")
+		}
+		purge.PurgeType = gcore.PurgeTypeURLs
+		purge.URLs = *body.URLs
+	case len(body.Paths) > 0:
+		purge.PurgeType = gcore.PurgeTypePatterns
+		purge.URLs = body.Paths
+	default:
+		purge.PurgeType = gcore.PurgeTypeAll
+	}
+	if v.write(w) {
+		return
+	}
+
+	if s.purgeDuration > 0 {
+		purge.Status = gcore.PurgeStatusInProgress
+		s.purgeDeadlines[purge.ID] = time.Now().Add(s.purgeDuration)
+	}
+	s.purges[purge.ID] = purge
+
+	writeJSON(w, http.StatusCreated, &gcore.PurgeTask{ID: purge.ID})
+}
+
+// prefetch handles POST /resources/{id}/prefetch.
+func (s *Server) prefetch(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Paths []string `json:"paths"`
+	}
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.findResource(w, r); !ok {
+		return
+	}
+
+	v := &validator{}
+	if len(body.Paths) == 0 {
+		v.add("paths", "This list may not be empty.")
+	}
+	if v.write(w) {
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// listPurges handles GET /purge_statuses.
+func (s *Server) listPurges(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var from, to time.Time
+	for name, t := range map[string]*time.Time{"from_created": &from, "to_created": &to} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := parseDate(value)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid query parameters.",
+				map[string][]string{name: {"Enter a valid date/time."}})
+			return
+		}
+		*t = parsed
+	}
+
+	q, ok := parseListQuery(w, r)
+	if !ok {
+		return
+	}
+	// Purge history is always paginated.
+	q.paginated = true
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := make([]*gcore.PurgeStatus, 0, len(s.purges))
+	for _, id := range sortedIDs(s.purges) {
+		purge := s.purgeStatus(id)
+
+		switch {
+		case query.Get("cname") != "" && purge.Resource.Cname != query.Get("cname"),
+			query.Get("purge_type") != "" && purge.PurgeType != query.Get("purge_type"),
+			query.Get("status") != "" && purge.Status != query.Get("status"),
+			!from.IsZero() && purge.Created.Before(from),
+			!to.IsZero() && purge.Created.After(to):
+			continue
+		}
+
+		results = append(results, purge)
+	}
+
+	writeList(w, q, results, nil)
+}
+
+// getPurge handles GET /purge_statuses/{id}.
+func (s *Server) getPurge(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok = s.purges[id]; !ok {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.purgeStatus(id))
+}
+
+// purgeStatus returns the purge by its ID completing it if its duration has
+// passed. It must be called with the lock held.
+func (s *Server) purgeStatus(id int) *gcore.PurgeStatus {
+	purge := s.purges[id]
+
+	if deadline, ok := s.purgeDeadlines[id]; ok && !time.Now().Before(deadline) {
+		purge.Status = gcore.PurgeStatusSuccessful
+		delete(s.purgeDeadlines, id)
+	}
+
+	return purge
+}
+
+// findResource returns the resource by the path ID or writes 404 response.
+// It must be called with the lock held.
+func (s *Server) findResource(w http.ResponseWriter, r *http.Request) (*gcore.Resource, bool) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return nil, false
+	}
+
+	resource, ok := s.resources[id]
+	if !ok {
+		writeNotFound(w)
+		return nil, false
+	}
+
+	return resource, true
+}
+
+// cnameTaken reports whether another resource has the cname. It must be
+// called with the lock held.
+func (s *Server) cnameTaken(cname string, exceptID int) bool {
+	for _, resource := range s.resources {
+		if resource.ID != exceptID && !resource.Deleted && resource.Cname == cname {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mergeOptions returns options with the non-null options of the update
+// applied to the current ones.
+func mergeOptions(current, update *gcore.Options) *gcore.Options {
+	merged := map[string]json.RawMessage{}
+	for _, options := range []*gcore.Options{current, update} {
+		if options == nil {
+			continue
+		}
+
+		var fields map[string]json.RawMessage
+		b, _ := json.Marshal(options)
+		_ = json.Unmarshal(b, &fields)
+
+		for key, value := range fields {
+			if string(value) != "null" {
+				merged[key] = value
+			}
+		}
+	}
+
+	b, _ := json.Marshal(merged)
+	result := &gcore.Options{}
+	_ = json.Unmarshal(b, result)
+
+	return result
+}
+
+// updateExtra replaces values of the unmodeled fields by the ones of
+// the request.
+func updateExtra(extra, raw map[string]json.RawMessage) {
+	for key := range extra {
+		if value, ok := raw[key]; ok {
+			extra[key] = value
+		}
+	}
+}
+
+// decodeRaw decodes the request fields into v or writes 400 response.
+func decodeRaw(w http.ResponseWriter, raw map[string]json.RawMessage, v interface{}) bool {
+	b, err := json.Marshal(raw)
+	if err == nil {
+		err = json.Unmarshal(b, v)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "JSON parse error - "+err.Error(), nil)
+		return false
+	}
+
+	return true
+}
+
+// parseDate parses date of the query parameters.
+func parseDate(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, dateFormat, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, errors.New("invalid date")
+}
+
+// queryInt parses non-negative integer query parameter, it's zero if
+// the parameter is empty.
+func queryInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err == nil && n < 0 {
+		err = errors.New("negative value")
+	}
+
+	return n, err
+}
+
+// nonNilStrings returns an empty slice instead of nil, so it's encoded as
+// an empty JSON array like in the API responses.
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+
+	return s
+}
+
+// sortedIDs returns keys of the objects in ascending order.
+func sortedIDs[T any](objects map[int]T) []int {
+	ids := make([]int, 0, len(objects))
+	for id := range objects {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	return ids
+}
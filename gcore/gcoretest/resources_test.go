@@ -0,0 +1,204 @@
+package gcoretest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// createTestResource creates origin group and resource with given cname.
+func createTestResource(t *testing.T, client *gcore.CommonClient, cname string) *gcore.Resource {
+	t.Helper()
+	ctx := context.Background()
+
+	group, _, err := client.OriginGroups.Create(ctx, &gcore.CreateOriginGroupBody{
+		Name:    cname,
+		Origins: []gcore.Origin{{Source: "origin.example.com", Enabled: true}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource, _, err := client.Resources.Create(ctx, &gcore.CreateResourceBody{
+		Cname:         cname,
+		OriginGroupID: &group.ID,
+		Options: &gcore.Options{
+			GZIPOn: &gcore.GZIPOn{Enabled: true, Value: true},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resource
+}
+
+func TestServer_Resources(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	resource := createTestResource(t, client, "cdn.example.com")
+	if resource.Status != "active" || resource.OriginProtocol != "HTTP" || resource.Client != AccountID {
+		t.Errorf("unexpected resource: %+v", resource)
+	}
+
+	got, _, err := client.Resources.Get(ctx, resource.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, resource) {
+		t.Errorf("expected %+v, got %+v", resource, got)
+	}
+
+	body := resource.UpdateBody()
+	body.SecondaryHostnames = []string{"static.example.com"}
+	body.Options = &gcore.Options{
+		HostHeader: &gcore.HostHeader{Enabled: true, Value: "origin.example.com"},
+	}
+
+	updated, _, err := client.Resources.Update(ctx, resource.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Options.GZIPOn == nil || updated.Options.HostHeader == nil {
+		t.Errorf("expected options to be merged, got: %+v", updated.Options)
+	}
+	if !reflect.DeepEqual(updated.SecondaryHostnames, []string{"static.example.com"}) {
+		t.Errorf("unexpected secondary hostnames: %v", updated.SecondaryHostnames)
+	}
+
+	suspended, _, err := client.Resources.Suspend(ctx, resource.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if suspended.Active || suspended.Status != "suspended" {
+		t.Errorf("unexpected suspended resource: %+v", suspended)
+	}
+
+	if _, err = client.Resources.Delete(ctx, resource.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := server.Resource(resource.ID); ok {
+		t.Error("resource hasn't been deleted")
+	}
+
+	_, _, err = client.Resources.Get(ctx, resource.ID)
+	if !gcore.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestServer_Resources_Origin(t *testing.T) {
+	server, client := newTestClient(t)
+
+	resource, _, err := client.Resources.Create(context.Background(), &gcore.CreateResourceBody{
+		Cname:  "cdn.example.com",
+		Origin: "origin.example.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	group, ok := server.OriginGroup(resource.OriginGroup)
+	if !ok || len(group.Origins) != 1 || group.Origins[0].Source != "origin.example.com" {
+		t.Errorf("unexpected origin group of the resource: %+v", group)
+	}
+}
+
+func TestServer_Resources_Validation(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	resource := createTestResource(t, client, "cdn.example.com")
+	missing := 1
+
+	_, _, err := client.Resources.Create(ctx, &gcore.CreateResourceBody{
+		Cname:         resource.Cname,
+		OriginGroupID: &missing,
+		SslData:       &missing,
+	})
+	if !gcore.IsValidation(err) {
+		t.Fatalf("expected validation error, got: %v", err)
+	}
+
+	var apiErr *gcore.Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *gcore.Error, got: %T", err)
+	}
+	for _, field := range []string{"cname", "originGroup", "sslData"} {
+		if len(apiErr.FieldErrors[field]) == 0 {
+			t.Errorf("expected error of %q field, got: %v", field, apiErr.FieldErrors)
+		}
+	}
+}
+
+func TestServer_Purge(t *testing.T) {
+	_, client := newTestClient(t, WithPurgeDuration(time.Hour))
+	ctx := context.Background()
+
+	resource := createTestResource(t, client, "cdn.example.com")
+
+	task, _, err := client.Resources.CreatePurge(ctx, resource.ID, gcore.PurgeURLs("/index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.Resources.Purge(ctx, resource.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	status, _, err := client.Resources.GetPurgeStatus(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != gcore.PurgeStatusInProgress || status.PurgeType != gcore.PurgeTypeURLs ||
+		!reflect.DeepEqual(status.URLs, []string{"/index.html"}) {
+		t.Errorf("unexpected purge status: %+v", status)
+	}
+
+	statuses, _, err := client.Resources.PurgeStatuses(ctx, gcore.PurgeStatusesOpts{
+		Cname:     resource.Cname,
+		PurgeType: gcore.PurgeTypeAll,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 || statuses[0].PurgeType != gcore.PurgeTypeAll {
+		t.Errorf("unexpected purge statuses: %+v", statuses)
+	}
+
+	statuses, _, err = client.Resources.PurgeStatuses(ctx, gcore.PurgeStatusesOpts{Limit: 1, Offset: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 || statuses[0].ID == task.ID {
+		t.Errorf("unexpected page of purge statuses: %+v", statuses)
+	}
+
+	if _, err = client.Resources.Prefetch(ctx, resource.ID, nil); !gcore.IsValidation(err) {
+		t.Errorf("expected validation error of empty prefetch, got: %v", err)
+	}
+}
+
+func TestServer_Purge_Completed(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	resource := createTestResource(t, client, "cdn.example.com")
+
+	task, _, err := client.Resources.CreatePurge(ctx, resource.ID, gcore.PurgePatterns("/static/*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := client.Resources.WaitForPurge(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != gcore.PurgeStatusSuccessful || status.PurgeType != gcore.PurgeTypePatterns {
+		t.Errorf("unexpected purge status: %+v", status)
+	}
+}
@@ -0,0 +1,219 @@
+package gcoretest
+
+import (
+	"net/http"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// AddRule stores the rule of the resource as is and returns its copy with
+// assigned ID if it doesn't have one.
+func (s *Server) AddRule(resourceID int, rule *gcore.Rule) *gcore.Rule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := clone(rule)
+	if stored.ID == 0 {
+		stored.ID = s.newID()
+	}
+	s.resourceRules(resourceID)[stored.ID] = stored
+
+	return clone(stored)
+}
+
+// Rule returns copy of the stored rule of the resource by its ID.
+func (s *Server) Rule(resourceID, ruleID int) (*gcore.Rule, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule, ok := s.rules[resourceID][ruleID]
+	if !ok {
+		return nil, false
+	}
+
+	return clone(rule), true
+}
+
+// listRules handles GET /resources/{id}/rules.
+func (s *Server) listRules(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resource, ok := s.findResource(w, r)
+	if !ok {
+		return
+	}
+
+	rules := s.rules[resource.ID]
+	list := make([]*gcore.Rule, 0, len(rules))
+	for _, id := range sortedIDs(rules) {
+		list = append(list, rules[id])
+	}
+
+	writeJSON(w, http.StatusOK, list)
+}
+
+// getRule handles GET /resources/{id}/rules/{ruleID}.
+func (s *Server) getRule(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule, ok := s.findRule(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, rule)
+}
+
+// createRule handles POST /resources/{id}/rules.
+func (s *Server) createRule(w http.ResponseWriter, r *http.Request) {
+	var body gcore.CreateRuleBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resource, ok := s.findResource(w, r)
+	if !ok {
+		return
+	}
+
+	v := &validator{}
+	if body.Name == "" {
+		v.add("name", "This field is required.")
+	}
+	if body.Rule == "" {
+		v.add("rule", "This field is required.")
+	}
+	v.options(&body.Options)
+	if v.write(w) {
+		return
+	}
+
+	rule := &gcore.Rule{
+		ID:       s.newID(),
+		Rule:     body.Rule,
+		Name:     body.Name,
+		RuleType: body.RuleType,
+		Options:  body.Options,
+		Weight:   1,
+	}
+	s.resourceRules(resource.ID)[rule.ID] = rule
+
+	writeJSON(w, http.StatusCreated, rule)
+}
+
+// updateRule handles PUT and PATCH /resources/{id}/rules/{ruleID}. Fields
+// missing in the request stay unchanged, options of PUT request replace
+// the current ones while options of PATCH request are merged with them.
+func (s *Server) updateRule(w http.ResponseWriter, r *http.Request) {
+	var body gcore.UpdateRuleBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule, ok := s.findRule(w, r)
+	if !ok {
+		return
+	}
+
+	v := &validator{}
+	if body.Name != nil && *body.Name == "" {
+		v.add("name", "This field may not be blank.")
+	}
+	if body.Rule != nil && *body.Rule == "" {
+		v.add("rule", "This field may not be blank.")
+	}
+	if body.OriginGroup != nil && *body.OriginGroup != 0 {
+		s.checkOriginGroup(v, "originGroup", *body.OriginGroup)
+	}
+	v.options(body.Options)
+	if v.write(w) {
+		return
+	}
+
+	if body.Rule != nil {
+		rule.Rule = *body.Rule
+	}
+	if body.Name != nil {
+		rule.Name = *body.Name
+	}
+	if body.RuleType != nil {
+		rule.RuleType = *body.RuleType
+	}
+	if body.Weight != nil {
+		rule.Weight = *body.Weight
+	}
+	if body.OriginGroup != nil {
+		rule.OriginGroup = *body.OriginGroup
+	}
+	if body.OriginProtocol != nil {
+		rule.OriginProtocol = *body.OriginProtocol
+	}
+	if body.Options != nil {
+		if r.Method == http.MethodPatch {
+			rule.Options = *mergeOptions(&rule.Options, body.Options)
+		} else {
+			rule.Options = *body.Options
+		}
+	}
+
+	writeJSON(w, http.StatusOK, rule)
+}
+
+// deleteRule handles DELETE /resources/{id}/rules/{ruleID}.
+func (s *Server) deleteRule(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule, ok := s.findRule(w, r)
+	if !ok {
+		return
+	}
+
+	for _, rules := range s.rules {
+		delete(rules, rule.ID)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// findRule returns the rule by the path IDs or writes 404 response.
+// It must be called with the lock held.
+func (s *Server) findRule(w http.ResponseWriter, r *http.Request) (*gcore.Rule, bool) {
+	resource, ok := s.findResource(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	id, ok := pathID(w, r, "ruleID")
+	if !ok {
+		return nil, false
+	}
+
+	rule, ok := s.rules[resource.ID][id]
+	if !ok {
+		writeNotFound(w)
+		return nil, false
+	}
+
+	return rule, true
+}
+
+// resourceRules returns rules of the resource by their IDs. It must be
+// called with the lock held.
+func (s *Server) resourceRules(resourceID int) map[int]*gcore.Rule {
+	rules, ok := s.rules[resourceID]
+	if !ok {
+		rules = make(map[int]*gcore.Rule)
+		s.rules[resourceID] = rules
+	}
+
+	return rules
+}
@@ -0,0 +1,90 @@
+package gcoretest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+func TestServer_Rules(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	resource := createTestResource(t, client, "cdn.example.com")
+
+	rule, _, err := client.Rules.Create(ctx, resource.ID, &gcore.CreateRuleBody{
+		Name: "images",
+		Rule: "/images/*",
+		Options: gcore.Options{
+			GZIPOn: &gcore.GZIPOn{Enabled: true, Value: true},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	weight := 5
+	patched, _, err := client.Rules.Patch(ctx, resource.ID, rule.ID, &gcore.UpdateRuleBody{
+		Weight: &weight,
+		Options: &gcore.Options{
+			HostHeader: &gcore.HostHeader{Enabled: true, Value: "images.example.com"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patched.Weight != weight || patched.Name != "images" ||
+		patched.Options.GZIPOn == nil || patched.Options.HostHeader == nil {
+		t.Errorf("unexpected patched rule: %+v", patched)
+	}
+
+	updated, _, err := client.Rules.Update(ctx, resource.ID, rule.ID, &gcore.UpdateRuleBody{
+		Options: &gcore.Options{
+			HostHeader: &gcore.HostHeader{Enabled: true, Value: "images.example.com"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Options.GZIPOn != nil {
+		t.Errorf("expected options to be replaced, got: %+v", updated.Options)
+	}
+
+	rules, _, err := client.Rules.List(ctx, resource.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 1 || rules[0].ID != rule.ID {
+		t.Errorf("unexpected rules: %+v", rules)
+	}
+
+	if _, err = client.Rules.Delete(ctx, resource.ID, rule.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := server.Rule(resource.ID, rule.ID); ok {
+		t.Error("rule hasn't been deleted")
+	}
+
+	_, _, err = client.Rules.Get(ctx, resource.ID, rule.ID)
+	if !gcore.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestServer_Rules_Validation(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	_, _, err := client.Rules.List(ctx, 1)
+	if !gcore.IsNotFound(err) {
+		t.Errorf("expected not found error of missing resource, got: %v", err)
+	}
+
+	resource := createTestResource(t, client, "cdn.example.com")
+
+	_, _, err = client.Rules.Create(ctx, resource.ID, &gcore.CreateRuleBody{Rule: "/images/*"})
+	if !gcore.IsValidation(err) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
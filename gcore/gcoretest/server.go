@@ -0,0 +1,411 @@
+// Package gcoretest provides an in-memory fake of G-Core CDN API for tests
+// of the code that uses go-gcore.
+//
+// The fake keeps state of resources, rules, origin groups, certificates,
+// purges, reseller clients, their services and geo restrictions, assigns
+// IDs, validates requests and responds with 401, 404 and 400 errors like
+// the API does. Latency, errors and 429 responses can be injected:
+//
+//	server := gcoretest.NewServer()
+//	defer server.Close()
+//
+//	client, err := server.CommonClient()
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//
+//	server.InjectRateLimit(1, time.Second)
+//	resources, _, err := client.Resources.List(ctx)
+package gcoretest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// The default configuration of the fake.
+const (
+	// DefaultUsername represents username accepted by the fake by default.
+	DefaultUsername = "user@example.com"
+
+	// DefaultPassword represents password accepted by the fake by default.
+	DefaultPassword = "password"
+
+	// AccountID represents ID of the account of the common client.
+	AccountID = 1
+
+	// tokenLifetime represents lifetime of the tokens issued by the fake.
+	tokenLifetime = time.Hour
+
+	// dateFormat represents format of the dates in the responses.
+	dateFormat = "2006-01-02T15:04:05"
+)
+
+// Option configures Server.
+type Option func(*Server)
+
+// WithCredentials sets username and password accepted by /auth/signin.
+func WithCredentials(username, password string) Option {
+	return func(s *Server) {
+		s.username = username
+		s.password = password
+	}
+}
+
+// WithAPIToken sets permanent API token accepted with APIKey
+// authorization scheme.
+func WithAPIToken(token string) Option {
+	return func(s *Server) {
+		s.apiToken = token
+	}
+}
+
+// WithPurgeDuration sets time purges stay in progress, purges are completed
+// immediately by default.
+func WithPurgeDuration(d time.Duration) Option {
+	return func(s *Server) {
+		s.purgeDuration = d
+	}
+}
+
+// Request represents request received by the fake.
+type Request struct {
+	Method string
+
+	// Path represents path of the request with its query.
+	Path string
+}
+
+// Server represents fake G-Core CDN API server.
+type Server struct {
+	server *httptest.Server
+	mux    *http.ServeMux
+
+	mu sync.Mutex
+
+	username      string
+	password      string
+	apiToken      string
+	purgeDuration time.Duration
+
+	tokens         map[string]time.Time
+	requests       []Request
+	latency        time.Duration
+	faults         []*Fault
+	nextID         int
+	purgeDeadlines map[int]time.Time
+
+	resources    map[int]*gcore.Resource
+	rules        map[int]map[int]*gcore.Rule
+	originGroups map[int]*gcore.OriginGroup
+	certificates map[int]*gcore.CertSSL
+	purges       map[int]*gcore.PurgeStatus
+	clients      map[int]*gcore.ClientAccount
+	services     map[int][]*gcore.Service
+	restrictions map[int]*gcore.GeoRestrictions
+	regions      []*gcore.Region
+}
+
+// NewServer starts and returns a new fake server, it must be closed with
+// Close.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		mux:            http.NewServeMux(),
+		username:       DefaultUsername,
+		password:       DefaultPassword,
+		tokens:         make(map[string]time.Time),
+		nextID:         100,
+		purgeDeadlines: make(map[int]time.Time),
+		resources:      make(map[int]*gcore.Resource),
+		rules:          make(map[int]map[int]*gcore.Rule),
+		originGroups:   make(map[int]*gcore.OriginGroup),
+		certificates:   make(map[int]*gcore.CertSSL),
+		purges:         make(map[int]*gcore.PurgeStatus),
+		clients:        make(map[int]*gcore.ClientAccount),
+		services:       make(map[int][]*gcore.Service),
+		restrictions:   make(map[int]*gcore.GeoRestrictions),
+		regions:        defaultRegions(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.routes()
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// URL returns base URL of the fake.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close shuts down the fake.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// CommonClient returns client of the common account that uses the fake and
+// signs in with its credentials. Given options are applied after the default
+// ones.
+func (s *Server) CommonClient(opts ...gcore.Option) (*gcore.CommonClient, error) {
+	return gcore.NewCommon(append(s.clientOptions(), opts...)...)
+}
+
+// ResellerClient returns client of the reseller account that uses the fake
+// and signs in with its credentials. Given options are applied after
+// the default ones.
+func (s *Server) ResellerClient(opts ...gcore.Option) (*gcore.ResellerClient, error) {
+	return gcore.NewReseller(append(s.clientOptions(), opts...)...)
+}
+
+// clientOptions returns options of the clients that use the fake.
+func (s *Server) clientOptions() []gcore.Option {
+	return []gcore.Option{
+		gcore.WithBaseURL(s.URL()),
+		gcore.WithCredentials(gcore.NewStaticCredentials(s.username, s.password)),
+	}
+}
+
+// ExpireTokens makes all issued tokens invalid, so the clients have to
+// sign in again.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens = make(map[string]time.Time)
+}
+
+// Requests returns requests received by the fake in their order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// serveHTTP records the request, applies the latency and the faults,
+// checks authorization and routes the request.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.RequestURI()})
+	latency := s.latency
+	fault := s.takeFault(r)
+	s.mu.Unlock()
+
+	if latency > 0 {
+		// The body is read first, so the server notices canceled requests
+		// while they are delayed.
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if fault != nil {
+		fault.write(w)
+		return
+	}
+
+	if r.URL.Path != "/auth/signin" && !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "Authentication credentials were not provided.", nil)
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// authorized reports whether the request has a valid token.
+func (s *Server) authorized(r *http.Request) bool {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch scheme {
+	case gcore.TokenAuthScheme:
+		expire, ok := s.tokens[token]
+		return ok && time.Now().Before(expire)
+	case gcore.APIKeyAuthScheme:
+		return s.apiToken != "" && token == s.apiToken
+	}
+
+	return false
+}
+
+// issueToken returns a new token. It must be called with the lock held.
+func (s *Server) issueToken() *tokenResponse {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	value := hex.EncodeToString(b)
+
+	expire := time.Now().UTC().Add(tokenLifetime)
+	s.tokens[value] = expire
+
+	return &tokenResponse{Token: value, Expire: expire.Format(dateFormat)}
+}
+
+// tokenResponse represents response of /auth/signin.
+type tokenResponse struct {
+	Token  string `json:"token"`
+	Expire string `json:"expire"`
+}
+
+// signIn handles POST /auth/signin.
+func (s *Server) signIn(w http.ResponseWriter, r *http.Request) {
+	var body gcore.AuthOptions
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if body.Username != s.username || body.Password != s.password {
+		writeError(w, http.StatusUnauthorized, "Invalid username or password.", nil)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.issueToken())
+}
+
+// newID returns a new object ID. It must be called with the lock held.
+func (s *Server) newID() int {
+	s.nextID++
+
+	return s.nextID
+}
+
+// routes registers handlers of the API endpoints.
+func (s *Server) routes() {
+	handlers := map[string]http.HandlerFunc{
+		"POST /auth/signin": s.signIn,
+
+		"GET /resources":                        s.listResources,
+		"POST /resources":                       s.createResource,
+		"GET /resources/{id}":                   s.getResource,
+		"PUT /resources/{id}":                   s.updateResource,
+		"DELETE /resources/{id}":                s.deleteResource,
+		"POST /resources/{id}/purge":            s.purge,
+		"POST /resources/{id}/prefetch":         s.prefetch,
+		"GET /resources/{id}/rules":             s.listRules,
+		"POST /resources/{id}/rules":            s.createRule,
+		"GET /resources/{id}/rules/{ruleID}":    s.getRule,
+		"PUT /resources/{id}/rules/{ruleID}":    s.updateRule,
+		"PATCH /resources/{id}/rules/{ruleID}":  s.updateRule,
+		"DELETE /resources/{id}/rules/{ruleID}": s.deleteRule,
+		"GET /purge_statuses":                   s.listPurges,
+		"GET /purge_statuses/{id}":              s.getPurge,
+
+		"GET /originGroups":         s.listOriginGroups,
+		"POST /originGroups":        s.createOriginGroup,
+		"GET /originGroups/{id}":    s.getOriginGroup,
+		"PUT /originGroups/{id}":    s.updateOriginGroup,
+		"DELETE /originGroups/{id}": s.deleteOriginGroup,
+
+		"GET /sslData":         s.listCertificates,
+		"POST /sslData":        s.createCertificate,
+		"GET /sslData/{id}":    s.getCertificate,
+		"DELETE /sslData/{id}": s.deleteCertificate,
+
+		"GET /clients/me":                          s.accountDetails,
+		"GET /clients":                             s.listClients,
+		"GET /clients/{id}":                        s.getClient,
+		"PUT /clients/{id}":                        s.updateClient,
+		"POST /users":                              s.createClient,
+		"GET /users/{id}/token":                    s.userToken,
+		"GET /clients/{id}/services":               s.listServices,
+		"PATCH /clients/{id}/services/{serviceID}": s.updateService,
+		"GET /admin/billing_regions":               s.listRegions,
+		"GET /admin/clients/{id}":                  s.getRestrictions,
+		"POST /admin/clients/{id}":                 s.setRestrictions,
+	}
+
+	for pattern, handler := range handlers {
+		s.mux.HandleFunc(pattern, handler)
+	}
+}
+
+// now returns the current time truncated to seconds since the API
+// doesn't return fractions of seconds.
+func now() *gcore.Time {
+	return gcore.NewTime(time.Now().UTC().Truncate(time.Second))
+}
+
+// pathID returns integer path value by its name or writes 404 response.
+func pathID(w http.ResponseWriter, r *http.Request, name string) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue(name))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Not found.", nil)
+		return 0, false
+	}
+
+	return id, true
+}
+
+// decode decodes the request body or writes 400 response.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "JSON parse error - "+err.Error(), nil)
+		return false
+	}
+
+	return true
+}
+
+// writeJSON writes the value as JSON response with given status code.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes the error response in the format of the API.
+func writeError(w http.ResponseWriter, status int, message string, fieldErrors map[string][]string) {
+	body := map[string]interface{}{"message": message}
+	if len(fieldErrors) > 0 {
+		body["errors"] = fieldErrors
+	}
+
+	writeJSON(w, status, body)
+}
+
+// writeNotFound writes 404 response.
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "Not found.", nil)
+}
+
+// clone returns a deep copy of the value through JSON, so the stored
+// objects aren't changed by the callers.
+func clone[T any](v *T) *T {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	copied := new(T)
+	if err = json.Unmarshal(b, copied); err != nil {
+		panic(err)
+	}
+
+	return copied
+}
@@ -0,0 +1,132 @@
+package gcoretest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// newTestClient returns the fake and its common client.
+func newTestClient(t *testing.T, opts ...Option) (*Server, *gcore.CommonClient) {
+	t.Helper()
+
+	server := NewServer(opts...)
+	t.Cleanup(server.Close)
+
+	client, err := server.CommonClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return server, client
+}
+
+// newTestResellerClient returns the fake and its reseller client.
+func newTestResellerClient(t *testing.T) (*Server, *gcore.ResellerClient) {
+	t.Helper()
+
+	server := NewServer()
+	t.Cleanup(server.Close)
+
+	client, err := server.ResellerClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return server, client
+}
+
+func TestServer_SignIn(t *testing.T) {
+	server, client := newTestClient(t)
+
+	account, _, err := client.Account.Details(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.ID != AccountID || account.Users[0].Email != DefaultUsername {
+		t.Errorf("unexpected account: %+v", account)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 2 || requests[0].Path != "/auth/signin" || requests[1].Path != "/clients/me" {
+		t.Errorf("unexpected requests: %v", requests)
+	}
+}
+
+func TestServer_SignIn_InvalidCredentials(t *testing.T) {
+	server := NewServer(WithCredentials("admin@example.com", "secret"))
+	defer server.Close()
+
+	client, err := gcore.NewCommon(gcore.WithBaseURL(server.URL()),
+		gcore.WithCredentials(gcore.NewStaticCredentials("admin@example.com", "wrong")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = client.Account.Details(context.Background())
+	if !gcore.IsUnauthorized(err) {
+		t.Errorf("expected unauthorized error, got: %v", err)
+	}
+}
+
+func TestServer_Unauthorized(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	resp, err := http.Get(server.URL() + "/resources")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected %d status code, got %d", http.StatusUnauthorized, resp.StatusCode)
+	}
+}
+
+func TestServer_ExpireTokens(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	if _, _, err := client.Resources.List(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	server.ExpireTokens()
+
+	if _, _, err := client.Resources.List(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	signIns := 0
+	for _, request := range server.Requests() {
+		if request.Path == "/auth/signin" {
+			signIns++
+		}
+	}
+	if signIns != 2 {
+		t.Errorf("expected 2 sign ins, got %d", signIns)
+	}
+}
+
+func TestServer_APIToken(t *testing.T) {
+	server := NewServer(WithAPIToken("permanent"))
+	defer server.Close()
+
+	client, err := server.CommonClient(gcore.WithCredentials(gcore.NewAPITokenCredentials("permanent")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = client.OriginGroups.List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, request := range server.Requests() {
+		if request.Path == "/auth/signin" {
+			t.Error("client with API token shouldn't sign in")
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package gcoretest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// validator collects validation errors of the request fields.
+type validator struct {
+	errors map[string][]string
+}
+
+// add adds error of the field.
+func (v *validator) add(field, message string) {
+	if v.errors == nil {
+		v.errors = make(map[string][]string)
+	}
+	v.errors[field] = append(v.errors[field], message)
+}
+
+// options adds errors of the options, the options may be nil.
+func (v *validator) options(options *gcore.Options) {
+	if options == nil {
+		return
+	}
+
+	var validationErr *gcore.ValidationError
+	if err := options.Validate(); errors.As(err, &validationErr) {
+		for field, messages := range validationErr.FieldErrors {
+			for _, message := range messages {
+				v.add(field, message)
+			}
+		}
+	}
+}
+
+// write writes 400 response if there are errors and reports whether it's
+// been written.
+func (v *validator) write(w http.ResponseWriter) bool {
+	if len(v.errors) == 0 {
+		return false
+	}
+
+	writeError(w, http.StatusBadRequest, "Validation error.", v.errors)
+
+	return true
+}
+
+// checkOriginGroup adds error of the field if the origin group doesn't
+// exist. It must be called with the lock held.
+func (s *Server) checkOriginGroup(v *validator, field string, id int) {
+	if _, ok := s.originGroups[id]; !ok {
+		v.add(field, "Origin group with this id doesn't exist.")
+	}
+}
+
+// checkCertificate adds error of the field if the certificate doesn't
+// exist. It must be called with the lock held.
+func (s *Server) checkCertificate(v *validator, field string, id int) {
+	if cert, ok := s.certificates[id]; !ok || cert.Deleted {
+		v.add(field, "SSL certificate with this id doesn't exist.")
+	}
+}
@@ -2,6 +2,7 @@ package gcore
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -29,6 +30,34 @@ type Region struct {
 
 	// Required flag indicates if the region is might be excluded from the content delivery.
 	Required bool `json:"required"`
+
+	// Extra represents fields the library doesn't model yet by their keys.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface,
+// unknown fields are stored in Extra.
+func (r *Region) UnmarshalJSON(b []byte) error {
+	type region Region
+
+	var alias region
+	extra, err := unmarshalWithExtra(b, &alias)
+	if err != nil {
+		return err
+	}
+
+	*r = Region(alias)
+	r.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface,
+// fields from Extra are added unless Region has a field for them.
+func (r Region) MarshalJSON() ([]byte, error) {
+	type region Region
+
+	return marshalWithExtra(region(r), r.Extra)
 }
 
 // GeoRestrictions represents the list of regions that are utilized
@@ -43,6 +72,34 @@ type GeoRestrictions struct {
 	// in the content delivery for a client.
 	// Numbers 1-7 represent a region.
 	RegionList []int `json:"region_list"`
+
+	// Extra represents fields the library doesn't model yet by their keys.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface,
+// unknown fields are stored in Extra.
+func (g *GeoRestrictions) UnmarshalJSON(b []byte) error {
+	type geoRestrictions GeoRestrictions
+
+	var alias geoRestrictions
+	extra, err := unmarshalWithExtra(b, &alias)
+	if err != nil {
+		return err
+	}
+
+	*g = GeoRestrictions(alias)
+	g.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface,
+// fields from Extra are added unless GeoRestrictions has a field for them.
+func (g GeoRestrictions) MarshalJSON() ([]byte, error) {
+	type geoRestrictions GeoRestrictions
+
+	return marshalWithExtra(geoRestrictions(g), g.Extra)
 }
 
 // ListRegions method returns the regions that are available for content delivery.
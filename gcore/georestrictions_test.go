@@ -259,8 +259,8 @@ func TestGeoRestrictionsService_GetRestrictions_NoRestrictions(t *testing.T) {
 	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
 
 	_, _, err := client.GeoRestrictions.GetRestrictions(context.Background(), 2)
-	if err == nil {
-		t.Fatal(err)
+	if !IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
 	}
 
 	if !endpointCalled {
@@ -333,8 +333,8 @@ func TestGeoRestrictionsService_SetRestrictions_ExcludeRequired(t *testing.T) {
 	}
 
 	_, err := client.GeoRestrictions.SetRestrictions(context.Background(), 1, body)
-	if err == nil {
-		t.Fatal(err)
+	if !IsConflict(err) {
+		t.Fatalf("expected conflict error, got %v", err)
 	}
 
 	if !endpointCalled {
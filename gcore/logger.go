@@ -0,0 +1,204 @@
+package gcore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	log "github.com/dstdfx/go-gcore/gcore/internal/logger"
+)
+
+// GenericLogger represents common printf-style interface for different loggers.
+type GenericLogger = log.GenericLogger
+
+// VoidLogger represents nil implementation of GenericLogger.
+type VoidLogger = log.VoidLogger
+
+// LevelBody represents level of the records with request and response bodies.
+// It's lower than slog.LevelDebug, so bodies are logged only if the handler
+// is explicitly configured to handle it.
+const LevelBody = slog.LevelDebug - 4
+
+// redactedValue replaces values of the sensitive fields in the logged bodies.
+const redactedValue = "[REDACTED]"
+
+// redactedFields represents the list of the body fields that are never logged.
+var redactedFields = []string{"password", "token", "sslPrivateKey"}
+
+// GenericLoggerHandler represents slog.Handler that writes records
+// to GenericLogger. Attributes are appended to the message
+// as key=value pairs.
+type GenericLoggerHandler struct {
+	logger GenericLogger
+	level  slog.Leveler
+	attrs  []slog.Attr
+	groups []string
+}
+
+// NewGenericLoggerHandler returns a reference to a new GenericLoggerHandler
+// that handles records with slog.LevelDebug and higher levels. Records with
+// LevelBody are written as debug ones if a lower level is given.
+func NewGenericLoggerHandler(logger GenericLogger, level ...slog.Leveler) *GenericLoggerHandler {
+	var minLevel slog.Leveler = slog.LevelDebug
+	if len(level) > 0 {
+		minLevel = level[0]
+	}
+
+	return &GenericLoggerHandler{logger: logger, level: minLevel}
+}
+
+// Enabled implements slog.Handler interface.
+func (h *GenericLoggerHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle implements slog.Handler interface.
+func (h *GenericLoggerHandler) Handle(_ context.Context, record slog.Record) error {
+	var msg strings.Builder
+	msg.WriteString(record.Message)
+
+	prefix := ""
+	if len(h.groups) > 0 {
+		prefix = strings.Join(h.groups, ".") + "."
+	}
+	for _, attr := range h.attrs {
+		writeAttr(&msg, "", attr)
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		writeAttr(&msg, prefix, attr)
+		return true
+	})
+
+	switch {
+	case record.Level >= slog.LevelError:
+		h.logger.Error(msg.String())
+	case record.Level >= slog.LevelWarn:
+		h.logger.Warn(msg.String())
+	case record.Level >= slog.LevelInfo:
+		h.logger.Info(msg.String())
+	default:
+		h.logger.Debug(msg.String())
+	}
+
+	return nil
+}
+
+// WithAttrs implements slog.Handler interface.
+func (h *GenericLoggerHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	prefix := ""
+	if len(h.groups) > 0 {
+		prefix = strings.Join(h.groups, ".") + "."
+	}
+
+	handler := *h
+	handler.attrs = make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	handler.attrs = append(handler.attrs, h.attrs...)
+	for _, attr := range attrs {
+		handler.attrs = append(handler.attrs, slog.Attr{Key: prefix + attr.Key, Value: attr.Value})
+	}
+
+	return &handler
+}
+
+// WithGroup implements slog.Handler interface.
+func (h *GenericLoggerHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	handler := *h
+	handler.groups = append(append([]string{}, h.groups...), name)
+
+	return &handler
+}
+
+// writeAttr appends the attribute to the message as key=value pair.
+func writeAttr(msg *strings.Builder, prefix string, attr slog.Attr) {
+	value := attr.Value.Resolve()
+	if value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if attr.Key != "" {
+			groupPrefix += attr.Key + "."
+		}
+		for _, groupAttr := range value.Group() {
+			writeAttr(msg, groupPrefix, groupAttr)
+		}
+
+		return
+	}
+
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	fmt.Fprintf(msg, " %s%s=%v", prefix, attr.Key, value)
+}
+
+// newSlogLogger returns *slog.Logger that writes to given GenericLogger.
+func newSlogLogger(logger GenericLogger) *slog.Logger {
+	if _, ok := logger.(*VoidLogger); ok || logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+
+	return slog.New(NewGenericLoggerHandler(logger))
+}
+
+// redactBody returns copy of the JSON body with values of the sensitive
+// fields replaced. Bodies that aren't valid JSON are replaced completely
+// if they mention any of the fields.
+func redactBody(body []byte) string {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		lowerBody := strings.ToLower(string(body))
+		for _, field := range redactedFields {
+			if strings.Contains(lowerBody, strings.ToLower(field)) {
+				return redactedValue
+			}
+		}
+
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return redactedValue
+	}
+
+	return string(redacted)
+}
+
+// redactValue replaces values of the sensitive fields in the decoded JSON.
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, fieldValue := range v {
+			if isRedactedField(key) {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactValue(fieldValue)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+
+	return value
+}
+
+// isRedactedField reports whether value of the field mustn't be logged.
+func isRedactedField(key string) bool {
+	for _, field := range redactedFields {
+		if strings.EqualFold(key, field) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,160 @@
+package gcore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// decodeRecords returns records written by slog.JSONHandler.
+func decodeRecords(t *testing.T, output *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+
+	decoder := json.NewDecoder(output)
+	for decoder.More() {
+		record := map[string]interface{}{}
+		if err := decoder.Decode(&record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestClient_Do_SlogLogger(t *testing.T) {
+	authServer := newTestAuthServer(time.Hour)
+	defer authServer.server.Close()
+
+	output := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(output, &slog.HandlerOptions{Level: LevelBody}))
+
+	client, err := NewCommon(
+		WithBaseURL(authServer.server.URL),
+		WithCredentials(NewStaticCredentials("user", "secret-password")),
+		WithSlogLogger(logger),
+		WithMiddleware(RequestIDMiddleware("")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = client.Resources.List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(output.String(), "secret-password") || strings.Contains(output.String(), "token-1") {
+		t.Fatalf("sensitive data has been logged: %s", output.String())
+	}
+
+	var requests, bodies []map[string]interface{}
+	for _, record := range decodeRecords(t, output) {
+		switch record[slog.MessageKey] {
+		case "gcore: request":
+			requests = append(requests, record)
+		case "gcore: request body", "gcore: response body":
+			bodies = append(bodies, record)
+		}
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("Expected: %d request records, got %d", 2, len(requests))
+	}
+
+	record := requests[1]
+	for _, key := range []string{"duration", "request_id"} {
+		if record[key] == nil || record[key] == "" {
+			t.Errorf("%s is missing in the record %v", key, record)
+		}
+	}
+
+	expected := map[string]interface{}{
+		"method":  "GET",
+		"path":    resourcesURL,
+		"status":  float64(200),
+		"attempt": float64(1),
+	}
+	for key, value := range expected {
+		if !reflect.DeepEqual(record[key], value) {
+			t.Errorf("%s: Expected: %v, got %v", key, value, record[key])
+		}
+	}
+
+	// Sign in request and response bodies and the resources list body.
+	if len(bodies) != 3 {
+		t.Fatalf("Expected: %d body records, got %d", 3, len(bodies))
+	}
+
+	if body := bodies[0]["body"]; body != `{"password":"[REDACTED]","username":"user"}` {
+		t.Errorf("unexpected request body %v", body)
+	}
+}
+
+func TestClient_Do_SlogLogger_BodyLevel(t *testing.T) {
+	authServer := newTestAuthServer(time.Hour)
+	defer authServer.server.Close()
+
+	output := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(output, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client, err := NewCommon(
+		WithBaseURL(authServer.server.URL),
+		WithCredentials(NewStaticCredentials("user", "secret-password")),
+		WithSlogLogger(logger),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = client.Resources.List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(output.String(), "body") {
+		t.Errorf("bodies have been logged with debug level: %s", output.String())
+	}
+}
+
+func TestGenericLoggerHandler(t *testing.T) {
+	logger := &testLogger{}
+	slogLogger := slog.New(NewGenericLoggerHandler(logger)).With(slog.String("client", "test"))
+
+	slogLogger.Debug("debug message", slog.Int("attempt", 1))
+	slogLogger.WithGroup("req").Info("info message", slog.String("method", "GET"))
+	slogLogger.Warn("warn message")
+	slogLogger.Error("error message", slog.Group("resp", slog.Int("status", 500)))
+	slogLogger.Log(context.Background(), LevelBody, "body message")
+
+	expected := []string{
+		"DEBUG debug message client=test attempt=1",
+		"INFO info message client=test req.method=GET",
+		"WARN warn message client=test",
+		"ERROR error message client=test resp.status=500",
+	}
+
+	if !reflect.DeepEqual(logger.messages, expected) {
+		t.Errorf("Expected: %q, got %q", expected, logger.messages)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	testCases := map[string]string{
+		`{"username": "user", "password": "secret"}`:                   `{"password":"[REDACTED]","username":"user"}`,
+		`{"token": "secret", "expire": "2019-01-01T00:00:00.000000Z"}`: `{"expire":"2019-01-01T00:00:00.000000Z","token":"[REDACTED]"}`,
+		`[{"id": 1, "sslPrivateKey": "secret"}]`:                       `[{"id":1,"sslPrivateKey":"[REDACTED]"}]`,
+		`{"data": {"Password": "secret"}}`:                             `{"data":{"Password":"[REDACTED]"}}`,
+		`not json with password=secret`:                                redactedValue,
+		`not json`:                                                     `not json`,
+	}
+
+	for body, expected := range testCases {
+		if got := redactBody([]byte(body)); got != expected {
+			t.Errorf("%s: Expected: %s, got %s", body, expected, got)
+		}
+	}
+}
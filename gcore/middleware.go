@@ -0,0 +1,109 @@
+package gcore
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// RequestIDHeader represents default header to pass request ID in.
+const RequestIDHeader = "X-Request-ID"
+
+// RoundTripFunc executes API request and returns its response.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps RoundTripFunc to add behavior to every request of
+// the client, e.g. inject headers or log requests.
+// The error returned by next is *Error for 4xx and 5xx responses.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// WithMiddleware appends given middlewares to the client's chain.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(o *options) error {
+		o.middlewares = append(o.middlewares, middlewares...)
+
+		return nil
+	}
+}
+
+// chain wraps given RoundTripFunc with the client's middlewares,
+// the first middleware is the outermost one.
+func (c *Client) chain(roundTrip RoundTripFunc) RoundTripFunc {
+	for i := len(c.Middlewares) - 1; i >= 0; i-- {
+		roundTrip = c.Middlewares[i](roundTrip)
+	}
+
+	return roundTrip
+}
+
+// HeaderMiddleware returns middleware that sets given headers
+// to every request.
+func HeaderMiddleware(headers http.Header) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			for name, values := range headers {
+				req.Header.Del(name)
+				for _, value := range values {
+					req.Header.Add(name, value)
+				}
+			}
+
+			return next(req)
+		}
+	}
+}
+
+// RequestIDMiddleware returns middleware that sets a unique ID to every
+// request in given header, RequestIDHeader is used if it's empty.
+// Requests that already have the header are left as is.
+func RequestIDMiddleware(header string) Middleware {
+	if header == "" {
+		header = RequestIDHeader
+	}
+
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(header) == "" {
+				req.Header.Set(header, newRequestID())
+			}
+
+			return next(req)
+		}
+	}
+}
+
+// newRequestID returns random request ID.
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// LoggingMiddleware returns middleware that logs every request with its
+// status code and duration.
+func LoggingMiddleware(logger GenericLogger) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			duration := time.Since(start)
+
+			requestID := req.Header.Get(RequestIDHeader)
+
+			switch {
+			case resp != nil:
+				logger.Infof("%s %s %d %v request_id=%s",
+					req.Method, req.URL.Path, resp.StatusCode, duration, requestID)
+			case err != nil:
+				logger.Errorf("%s %s failed after %v request_id=%s: %s",
+					req.Method, req.URL.Path, duration, requestID, err)
+			}
+
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	th "github.com/dstdfx/go-gcore/gcore/internal/testhelper"
+)
+
+// testLogger records formatted messages.
+type testLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *testLogger) record(level, msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.messages = append(l.messages, level+" "+msg)
+}
+
+func (l *testLogger) Debug(args ...interface{}) {
+	l.record("DEBUG", fmt.Sprint(args...))
+}
+
+func (l *testLogger) Debugf(format string, args ...interface{}) {
+	l.record("DEBUG", fmt.Sprintf(format, args...))
+}
+
+func (l *testLogger) Info(args ...interface{}) {
+	l.record("INFO", fmt.Sprint(args...))
+}
+
+func (l *testLogger) Infof(format string, args ...interface{}) {
+	l.record("INFO", fmt.Sprintf(format, args...))
+}
+
+func (l *testLogger) Warn(args ...interface{}) {
+	l.record("WARN", fmt.Sprint(args...))
+}
+
+func (l *testLogger) Warnf(format string, args ...interface{}) {
+	l.record("WARN", fmt.Sprintf(format, args...))
+}
+
+func (l *testLogger) Error(args ...interface{}) {
+	l.record("ERROR", fmt.Sprint(args...))
+}
+
+func (l *testLogger) Errorf(format string, args ...interface{}) {
+	l.record("ERROR", fmt.Sprintf(format, args...))
+}
+
+func TestClient_Do_Middlewares(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	var gotHeaders http.Header
+	testEnv.Mux.HandleFunc(resourcesURL, func(w http.ResponseWriter, r *http.Request) {
+		gotHeaders = r.Header.Clone()
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, `[]`)
+	})
+
+	var order []string
+	tracing := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+" before")
+				resp, err := next(req)
+				order = append(order, name+" after")
+				return resp, err
+			}
+		}
+	}
+
+	logger := &testLogger{}
+
+	client, err := NewCommon(
+		WithBaseURL(testEnv.Server.URL),
+		WithMiddleware(tracing("outer"), tracing("inner")),
+		WithMiddleware(
+			HeaderMiddleware(http.Header{"X-Trace": []string{"abc"}}),
+			RequestIDMiddleware(""),
+			LoggingMiddleware(logger),
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = client.Resources.List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedOrder := []string{"outer before", "inner before", "inner after", "outer after"}
+	if !reflect.DeepEqual(order, expectedOrder) {
+		t.Errorf("Expected: %v, got %v", expectedOrder, order)
+	}
+
+	if gotHeaders.Get("X-Trace") != "abc" {
+		t.Errorf("Expected: %s, got %s", "abc", gotHeaders.Get("X-Trace"))
+	}
+
+	requestID := gotHeaders.Get(RequestIDHeader)
+	if len(requestID) != 32 {
+		t.Errorf("unexpected request ID %q", requestID)
+	}
+
+	if len(logger.messages) != 1 ||
+		!strings.HasPrefix(logger.messages[0], "INFO GET /resources 200") ||
+		!strings.HasSuffix(logger.messages[0], "request_id="+requestID) {
+		t.Errorf("unexpected log messages: %v", logger.messages)
+	}
+}
+
+func TestClient_Do_MiddlewareSeesError(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testEnv.Mux.HandleFunc(fmt.Sprintf(ruleURL, 1, 1), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	var gotErr error
+	audit := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			gotErr = err
+			return resp, err
+		}
+	}
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	client.Middlewares = []Middleware{audit}
+
+	_, err := client.Rules.Delete(context.Background(), 1, 1)
+	if !IsNotFound(err) || !IsNotFound(gotErr) {
+		t.Fatalf("expected not found error, got %v and %v", err, gotErr)
+	}
+}
@@ -0,0 +1,223 @@
+package gcore
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option configures Client created by NewCommon or NewReseller.
+type Option func(*options) error
+
+// options represents configuration of Client.
+type options struct {
+	baseURL     *url.URL
+	httpClient  *http.Client
+	timeout     *time.Duration
+	userAgent   string
+	logger      *slog.Logger
+	token       *Token
+	credentials CredentialsProvider
+	retryPolicy *RetryPolicy
+	rateLimiter RateLimiter
+	middlewares []Middleware
+}
+
+// WithBaseURL sets base URL for API requests.
+func WithBaseURL(rawURL string) Option {
+	return func(o *options) error {
+		baseURL, err := url.Parse(rawURL)
+		if err != nil {
+			return err
+		}
+		if baseURL.Scheme == "" || baseURL.Host == "" {
+			return errors.New("gcore: base URL must be absolute")
+		}
+
+		o.baseURL = baseURL
+
+		return nil
+	}
+}
+
+// WithHTTPClient sets HTTP client used to communicate with the API.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) error {
+		o.httpClient = httpClient
+
+		return nil
+	}
+}
+
+// WithTimeout sets timeout for HTTP requests. Custom HTTP client provided
+// with WithHTTPClient isn't modified, its copy is used instead.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) error {
+		if timeout < 0 {
+			return errors.New("gcore: timeout must not be negative")
+		}
+
+		o.timeout = &timeout
+
+		return nil
+	}
+}
+
+// WithUserAgent sets value of User-Agent header.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) error {
+		o.userAgent = userAgent
+
+		return nil
+	}
+}
+
+// WithLogger sets printf-style logger for the client, records are written
+// to it with GenericLoggerHandler.
+func WithLogger(logger GenericLogger) Option {
+	return func(o *options) error {
+		o.logger = newSlogLogger(logger)
+
+		return nil
+	}
+}
+
+// WithSlogLogger sets structured logger for the client. Request and response
+// bodies are logged with LevelBody level if the logger handles it.
+func WithSlogLogger(logger *slog.Logger) Option {
+	return func(o *options) error {
+		if logger == nil {
+			return errors.New("gcore: logger must not be nil")
+		}
+		o.logger = logger
+
+		return nil
+	}
+}
+
+// WithToken sets token to communicate with the API.
+func WithToken(token *Token) Option {
+	return func(o *options) error {
+		o.token = token
+
+		return nil
+	}
+}
+
+// WithCredentials sets provider of the credentials. Client gets a token
+// with them before the first request and renews it when needed.
+func WithCredentials(provider CredentialsProvider) Option {
+	return func(o *options) error {
+		o.credentials = provider
+
+		return nil
+	}
+}
+
+// WithRetryPolicy sets retry policy for failed requests.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(o *options) error {
+		o.retryPolicy = policy
+
+		return nil
+	}
+}
+
+// WithRateLimiter sets limiter of the requests rate.
+func WithRateLimiter(limiter RateLimiter) Option {
+	return func(o *options) error {
+		o.rateLimiter = limiter
+
+		return nil
+	}
+}
+
+// newClient creates Client configured with given options.
+func newClient(opts ...Option) (*Client, error) {
+	baseURL, _ := url.Parse(defaultBaseURL)
+
+	o := &options{
+		baseURL:   baseURL,
+		userAgent: defaultUserAgent,
+	}
+
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+
+	httpClient := o.httpClient
+	if httpClient == nil {
+		httpClient = NewHTTPClient()
+	}
+
+	if o.timeout != nil {
+		customClient := *httpClient
+		customClient.Timeout = *o.timeout
+		httpClient = &customClient
+	}
+
+	logger := o.logger
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+
+	c := &Client{
+		client:      httpClient,
+		BaseURL:     o.baseURL,
+		UserAgent:   o.userAgent,
+		logger:      logger,
+		Token:       o.token,
+		credentials: o.credentials,
+		RetryPolicy: o.retryPolicy,
+		RateLimiter: o.rateLimiter,
+		Middlewares: o.middlewares,
+	}
+	c.common.client = c
+
+	return c, nil
+}
+
+// NewCommon creates basic G-Core client configured with given options.
+func NewCommon(opts ...Option) (*CommonClient, error) {
+	c, err := newClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	commonServices := CommonServices{}
+	commonServices.Account = (*AccountService)(&c.common)
+	commonServices.Resources = (*ResourcesService)(&c.common)
+	commonServices.OriginGroups = (*OriginGroupsService)(&c.common)
+	commonServices.Rules = (*RulesService)(&c.common)
+	commonServices.Certificates = (*CertService)(&c.common)
+
+	commonClient := &CommonClient{
+		Client:         c,
+		CommonServices: commonServices,
+	}
+
+	return commonClient, nil
+}
+
+// NewReseller creates reseller G-Core client configured with given options.
+func NewReseller(opts ...Option) (*ResellerClient, error) {
+	c, err := newClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	resellerServices := ResellerServices{}
+	resellerServices.Clients = (*ClientsService)(&c.common)
+	resellerServices.GeoRestrictions = (*GeoRestrictionsService)(&c.common)
+	resellerServices.Services = (*ServicesService)(&c.common)
+	resellClient := &ResellerClient{
+		Client:           c,
+		ResellerServices: resellerServices,
+	}
+
+	return resellClient, nil
+}
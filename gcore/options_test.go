@@ -0,0 +1,102 @@
+package gcore
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	th "github.com/dstdfx/go-gcore/gcore/internal/testhelper"
+)
+
+func TestNewCommon(t *testing.T) {
+	endpointCalled := false
+
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	handleOpts := &th.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         accountDetailsURL,
+		RawResponse: testAccountDetailResponse,
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    &endpointCalled,
+	}
+
+	th.HandleReqWithoutBody(t, handleOpts)
+
+	customClient := &http.Client{}
+
+	client, err := NewCommon(
+		WithBaseURL(testEnv.Server.URL),
+		WithHTTPClient(customClient),
+		WithTimeout(5*time.Second),
+		WithUserAgent("test-agent"),
+		WithCredentials(NewStaticCredentials("whatever", "whatever")),
+		WithRetryPolicy(testRetryPolicy()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if client.UserAgent != "test-agent" {
+		t.Errorf("Expected: %s, got %s", "test-agent", client.UserAgent)
+	}
+
+	if client.client.Timeout != 5*time.Second {
+		t.Errorf("Expected: %v, got %v", 5*time.Second, client.client.Timeout)
+	}
+
+	if customClient.Timeout != 0 {
+		t.Error("custom HTTP client must not be modified")
+	}
+
+	// The token is taken with the credentials before the first request.
+	if _, _, err = client.Account.Details(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !endpointCalled {
+		t.Fatal("didn't get account details")
+	}
+
+	if client.Token == nil || client.Token.Value != th.TestFakeToken {
+		t.Errorf("Expected: %s, got %+v", th.TestFakeToken, client.Token)
+	}
+}
+
+func TestNewReseller(t *testing.T) {
+	token := &Token{Value: "whatever", Scheme: APIKeyAuthScheme}
+
+	client, err := NewReseller(WithToken(token))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if client.BaseURL.String() != defaultBaseURL {
+		t.Errorf("Expected: %s, got %s", defaultBaseURL, client.BaseURL)
+	}
+
+	if client.UserAgent != defaultUserAgent {
+		t.Errorf("Expected: %s, got %s", defaultUserAgent, client.UserAgent)
+	}
+
+	if client.Token != token {
+		t.Errorf("Expected: %+v, got %+v", token, client.Token)
+	}
+
+	if client.Clients == nil || client.GeoRestrictions == nil || client.Services == nil {
+		t.Error("reseller services must be initialized")
+	}
+}
+
+func TestNewCommon_InvalidOptions(t *testing.T) {
+	if _, err := NewCommon(WithBaseURL("api.gcdn.co")); err == nil {
+		t.Error("expected relative base URL to be rejected")
+	}
+
+	if _, err := NewReseller(WithTimeout(-time.Second)); err == nil {
+		t.Error("expected negative timeout to be rejected")
+	}
+}
@@ -2,7 +2,9 @@ package gcore
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -21,6 +23,34 @@ type Origin struct {
 	Enabled bool   `json:"enabled,omitempty"`
 	Backup  bool   `json:"backup"`
 	Source  string `json:"source"`
+
+	// Extra represents fields the library doesn't model yet by their keys.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface,
+// unknown fields are stored in Extra.
+func (o *Origin) UnmarshalJSON(b []byte) error {
+	type origin Origin
+
+	var alias origin
+	extra, err := unmarshalWithExtra(b, &alias)
+	if err != nil {
+		return err
+	}
+
+	*o = Origin(alias)
+	o.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface,
+// fields from Extra are added unless Origin has a field for them.
+func (o Origin) MarshalJSON() ([]byte, error) {
+	type origin Origin
+
+	return marshalWithExtra(origin(o), o.Extra)
 }
 
 // OriginGroup represents G-Core's origin group.
@@ -30,6 +60,34 @@ type OriginGroup struct {
 	UseNext   bool     `json:"useNext"`
 	OriginIDs []Origin `json:"origin_ids,omitempty"`
 	Origins   []Origin `json:"origins"`
+
+	// Extra represents fields the library doesn't model yet by their keys.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface,
+// unknown fields are stored in Extra.
+func (o *OriginGroup) UnmarshalJSON(b []byte) error {
+	type originGroup OriginGroup
+
+	var alias originGroup
+	extra, err := unmarshalWithExtra(b, &alias)
+	if err != nil {
+		return err
+	}
+
+	*o = OriginGroup(alias)
+	o.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface,
+// fields from Extra are added unless OriginGroup has a field for them.
+func (o OriginGroup) MarshalJSON() ([]byte, error) {
+	type originGroup OriginGroup
+
+	return marshalWithExtra(originGroup(o), o.Extra)
 }
 
 // UpdateOriginGroupBody represents request body for origin group update.
@@ -37,6 +95,29 @@ type UpdateOriginGroupBody struct {
 	Name    string   `json:"name"`
 	UseNext bool     `json:"useNext"`
 	Origins []Origin `json:"origins"`
+
+	// Extra represents fields the library doesn't model yet by their keys,
+	// they are sent as is.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler interface,
+// fields from Extra are added unless UpdateOriginGroupBody has a field for them.
+func (u UpdateOriginGroupBody) MarshalJSON() ([]byte, error) {
+	type updateOriginGroupBody UpdateOriginGroupBody
+
+	return marshalWithExtra(updateOriginGroupBody(u), u.Extra)
+}
+
+// UpdateBody returns request body that updates the origin group to its
+// current state including the fields kept in Extra.
+func (g *OriginGroup) UpdateBody() *UpdateOriginGroupBody {
+	return &UpdateOriginGroupBody{
+		Name:    g.Name,
+		UseNext: g.UseNext,
+		Origins: g.Origins,
+		Extra:   g.Extra,
+	}
 }
 
 // CreateOriginGroupBody represents request body for origin group create.
@@ -46,21 +127,56 @@ type CreateOriginGroupBody struct {
 	Origins []Origin `json:"origins"`
 }
 
+// ListOriginGroupsOpts represents list of additional options to filter and
+// paginate origin groups by.
+type ListOriginGroupsOpts struct {
+	Name string `param:"name,omitempty"`
+
+	// Ordering represents field to sort the origin groups by, "-" prefix
+	// reverses the order, e.g. "-id".
+	Ordering string `param:"ordering,omitempty"`
+
+	// Fields represents fields of the origin groups returned by the API,
+	// all fields are returned if it's empty.
+	Fields []string `param:"fields,omitempty"`
+
+	Limit  int `param:"limit,omitempty"`
+	Offset int `param:"offset,omitempty"`
+}
+
 // List method returns list information about Origins Groups and Origin Sources.
 func (s *OriginGroupsService) List(ctx context.Context) ([]*OriginGroup, *http.Response, error) {
-	req, err := s.client.NewRequest(ctx, http.MethodGet, originGroupsURL, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	originGroups := make([]*OriginGroup, 0)
+	return s.ListWithOpts(ctx, ListOriginGroupsOpts{})
+}
 
-	resp, err := s.client.Do(req, &originGroups)
+// ListWithOpts method returns list information about Origins Groups and
+// Origin Sources filtered by given opts. All origin groups are returned
+// unless opts.Limit is set.
+func (s *OriginGroupsService) ListWithOpts(ctx context.Context, opts ListOriginGroupsOpts) ([]*OriginGroup, *http.Response, error) {
+	page, resp, err := s.ListPage(ctx, opts)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	return originGroups, resp, nil
+	return page.Results, resp, nil
+}
+
+// ListPage method returns page of the origin groups filtered by given opts
+// along with total number of the matching origin groups.
+func (s *OriginGroupsService) ListPage(ctx context.Context, opts ListOriginGroupsOpts) (*Page[*OriginGroup], *http.Response, error) {
+	return listPage[*OriginGroup](ctx, s.client, originGroupsURL, opts, opts.Limit, opts.Offset)
+}
+
+// ListAll method returns iterator over all origin groups filtered by given
+// opts, the pages are requested as the iteration goes.
+func (s *OriginGroupsService) ListAll(ctx context.Context, opts ListOriginGroupsOpts) iter.Seq2[*OriginGroup, error] {
+	return listAll(ctx, opts.Limit, opts.Offset, func(ctx context.Context, limit, offset int) (*Page[*OriginGroup], error) {
+		pageOpts := opts
+		pageOpts.Limit, pageOpts.Offset = limit, offset
+		page, _, err := s.ListPage(ctx, pageOpts)
+
+		return page, err
+	})
 }
 
 // Get method returns origin group info for given ID.
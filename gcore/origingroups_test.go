@@ -2,6 +2,7 @@ package gcore
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -137,6 +138,7 @@ var (
 				Backup:  false,
 			},
 		},
+		Extra: map[string]json.RawMessage{"path": json.RawMessage(`""`)},
 	}
 
 	testListOriginGroupsExpected = []*OriginGroup{
@@ -159,6 +161,7 @@ var (
 					Backup:  false,
 				},
 			},
+			Extra: map[string]json.RawMessage{"path": json.RawMessage(`""`)},
 		},
 	}
 
@@ -181,6 +184,7 @@ var (
 				Backup:  false,
 			},
 		},
+		Extra: map[string]json.RawMessage{"path": json.RawMessage(`""`)},
 	}
 
 	testUpdateOriginGroupExpected = &OriginGroup{
@@ -202,6 +206,7 @@ var (
 				Backup:  false,
 			},
 		},
+		Extra: map[string]json.RawMessage{"path": json.RawMessage(`""`)},
 	}
 )
 
@@ -354,6 +359,87 @@ func TestOriginGroupsService_List(t *testing.T) {
 	}
 }
 
+func TestOriginGroupsService_ListWithOpts(t *testing.T) {
+	endpointCalled := false
+
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	handleOpts := &th.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         originGroupsURL,
+		RawResponse: testListOriginGroupsRawResponse,
+		QueryParams: map[string]string{"name": "group"},
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    &endpointCalled,
+	}
+
+	th.HandleReqWithoutBody(t, handleOpts)
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	got, _, err := client.OriginGroups.ListWithOpts(context.Background(), ListOriginGroupsOpts{Name: "group"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !endpointCalled {
+		t.Fatal("didn't get a list of origin groups")
+	}
+
+	if !reflect.DeepEqual(got, testListOriginGroupsExpected) {
+		t.Errorf("Expected: %+v, got %+v\n", testListOriginGroupsExpected, got)
+	}
+}
+
+func TestOriginGroupsService_ListPage(t *testing.T) {
+	endpointCalled := false
+
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	handleOpts := &th.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         originGroupsURL,
+		RawResponse: `{"count": 10, "results": ` + testListOriginGroupsRawResponse + `}`,
+		QueryParams: map[string]string{
+			"name":     "group",
+			"ordering": "name",
+			"limit":    "1",
+			"offset":   "2",
+		},
+		Method:   http.MethodGet,
+		Status:   http.StatusOK,
+		CallFlag: &endpointCalled,
+	}
+
+	th.HandleReqWithoutBody(t, handleOpts)
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	got, _, err := client.OriginGroups.ListPage(context.Background(), ListOriginGroupsOpts{Name: "group", Ordering: "name", Limit: 1, Offset: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !endpointCalled {
+		t.Fatal("didn't get a page of origin groups")
+	}
+
+	expected := &Page[*OriginGroup]{Count: 10, Results: testListOriginGroupsExpected, Limit: 1, Offset: 2}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected: %+v, got %+v\n", expected, got)
+	}
+	if !got.HasNext() || got.NextOffset() != 2+len(testListOriginGroupsExpected) {
+		t.Errorf("expected the next page at %d", 2+len(testListOriginGroupsExpected))
+	}
+}
+
 func TestOriginGroupsService_Update(t *testing.T) {
 	endpointCalled := false
 
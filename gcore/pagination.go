@@ -0,0 +1,131 @@
+package gcore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"iter"
+	"net/http"
+	"strings"
+)
+
+// DefaultPageSize represents number of the items requested per page by
+// ListAll methods if the options don't set Limit.
+const DefaultPageSize = 100
+
+// Page represents page of a list returned by the API.
+type Page[T any] struct {
+	// Count represents total number of the items matching the filters.
+	// It's 0 if the API has returned a plain array instead of a paginated
+	// object, the array holds all items after Offset then.
+	Count int
+
+	// Results represents items of the page.
+	Results []T
+
+	// Limit and Offset represent the requested window of the list.
+	Limit  int
+	Offset int
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface. The API returns
+// a paginated object if limit is set and a plain array with all items
+// otherwise, both of them are accepted. The plain array has no total
+// count, so Count is left 0.
+func (p *Page[T]) UnmarshalJSON(b []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("[")) {
+		var results []T
+		if err := json.Unmarshal(b, &results); err != nil {
+			return err
+		}
+		p.Results = results
+
+		return nil
+	}
+
+	var page struct {
+		Count   int `json:"count"`
+		Results []T `json:"results"`
+	}
+	if err := json.Unmarshal(b, &page); err != nil {
+		return err
+	}
+	p.Count = page.Count
+	p.Results = page.Results
+
+	return nil
+}
+
+// HasNext reports whether there are items after the page.
+func (p *Page[T]) HasNext() bool {
+	return len(p.Results) > 0 && p.NextOffset() < p.Count
+}
+
+// NextOffset returns offset of the next page.
+func (p *Page[T]) NextOffset() int {
+	return p.Offset + len(p.Results)
+}
+
+// listPage requests the page of the list at given URL filtered by opts,
+// limit and offset are the ones set in opts.
+func listPage[T any](ctx context.Context, c *Client, url string, opts interface{}, limit, offset int) (*Page[T], *http.Response, error) {
+	queryParams, err := BuildQueryParameters(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if queryParams != "" {
+		url = strings.Join([]string{url, queryParams}, "?")
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page := &Page[T]{Limit: limit, Offset: offset}
+
+	resp, err := c.Do(req, page)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if page.Results == nil {
+		page.Results = make([]T, 0)
+	}
+
+	return page, resp, nil
+}
+
+// listAll returns iterator over the items of the pages returned by fetch
+// starting from offset. The iteration stops at the first error. Every
+// iteration starts from the first page, so the iterator can be reused.
+func listAll[T any](ctx context.Context, limit, start int,
+	fetch func(ctx context.Context, limit, offset int) (*Page[T], error)) iter.Seq2[T, error] {
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+
+	return func(yield func(T, error) bool) {
+		offset := start
+		for {
+			page, err := fetch(ctx, limit, offset)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range page.Results {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if !page.HasNext() {
+				return
+			}
+			offset = page.NextOffset()
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package gcore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	th "github.com/dstdfx/go-gcore/gcore/internal/testhelper"
+)
+
+func TestPage_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		name    string
+		raw     string
+		offset  int
+		count   int
+		results []int
+		hasNext bool
+	}{
+		{
+			name:    "paginated object",
+			raw:     `{"count": 5, "results": [1, 2]}`,
+			count:   5,
+			results: []int{1, 2},
+			hasNext: true,
+		},
+		{
+			name:    "last page",
+			raw:     `{"count": 5, "results": [5]}`,
+			offset:  4,
+			count:   5,
+			results: []int{5},
+		},
+		{
+			name:    "plain array",
+			raw:     ` [1, 2, 3]`,
+			results: []int{1, 2, 3},
+		},
+		{
+			name:    "plain array with offset",
+			raw:     `[3]`,
+			offset:  2,
+			results: []int{3},
+		},
+		{
+			name:  "empty page",
+			raw:   `{"count": 5, "results": []}`,
+			count: 5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			page := &Page[int]{Offset: tc.offset}
+			if err := json.Unmarshal([]byte(tc.raw), page); err != nil {
+				t.Fatal(err)
+			}
+
+			if page.Count != tc.count || len(page.Results) != len(tc.results) ||
+				(len(tc.results) > 0 && !reflect.DeepEqual(page.Results, tc.results)) {
+				t.Errorf("unexpected page: %+v", page)
+			}
+			if page.HasNext() != tc.hasNext {
+				t.Errorf("expected HasNext to be %v", tc.hasNext)
+			}
+		})
+	}
+}
+
+// handlePages serves the list at the URL in pages by limit and offset and
+// counts the requests.
+func handlePages(t *testing.T, mux *http.ServeMux, url string, items []map[string]interface{}, requests *int) {
+	mux.HandleFunc(url, func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+
+		var limit, offset int
+		if _, err := fmt.Sscan(r.URL.Query().Get("limit"), &limit); err != nil {
+			t.Errorf("unexpected limit: %v", err)
+		}
+		_, _ = fmt.Sscan(r.URL.Query().Get("offset"), &offset)
+
+		results := items[min(offset, len(items)):]
+		results = results[:min(limit, len(results))]
+
+		w.Header().Add("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": len(items), "results": results})
+	})
+}
+
+func TestListAll(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	items := make([]map[string]interface{}, 5)
+	for i := range items {
+		items[i] = map[string]interface{}{"id": i + 1, "cname": fmt.Sprintf("cdn%d.site.com", i+1)}
+	}
+
+	var requests int
+	handlePages(t, testEnv.Mux, resourcesURL, items, &requests)
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	var ids []int
+	for resource, err := range client.Resources.ListAll(context.Background(), ListResourcesOpts{Limit: 2}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, resource.ID)
+	}
+
+	if !reflect.DeepEqual(ids, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("unexpected resources: %v", ids)
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 requests, got %d", requests)
+	}
+
+	// Breaking the loop stops requesting the pages.
+	requests = 0
+	for resource, err := range client.Resources.ListAll(context.Background(), ListResourcesOpts{Limit: 2, Offset: 1}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resource.ID != 2 {
+			t.Errorf("expected the iteration to start at the offset, got %d", resource.ID)
+		}
+		break
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+}
+
+func TestListAll_Reuse(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	items := make([]map[string]interface{}, 5)
+	for i := range items {
+		items[i] = map[string]interface{}{"id": i + 1}
+	}
+
+	var requests int
+	handlePages(t, testEnv.Mux, resourcesURL, items, &requests)
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	resources := client.Resources.ListAll(context.Background(), ListResourcesOpts{Limit: 2, Offset: 1})
+	for i := 0; i < 2; i++ {
+		var ids []int
+		for resource, err := range resources {
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, resource.ID)
+		}
+
+		if !reflect.DeepEqual(ids, []int{2, 3, 4, 5}) {
+			t.Errorf("iteration %d: unexpected resources: %v", i+1, ids)
+		}
+	}
+}
+
+func TestListAll_DefaultPageSize(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	items := make([]map[string]interface{}, DefaultPageSize+1)
+	for i := range items {
+		items[i] = map[string]interface{}{"id": i + 1}
+	}
+
+	var requests int
+	handlePages(t, testEnv.Mux, certificatesURL, items, &requests)
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	n := 0
+	for _, err := range client.Certificates.ListAll(context.Background(), ListCertsOpts{}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		n++
+	}
+
+	if n != len(items) || requests != 2 {
+		t.Errorf("expected %d certificates in 2 requests, got %d in %d", len(items), n, requests)
+	}
+}
+
+func TestListAll_Error(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testEnv.Mux.HandleFunc(originGroupsURL, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"message": "Invalid ordering."}`)
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	n := 0
+	for group, err := range client.OriginGroups.ListAll(context.Background(), ListOriginGroupsOpts{Ordering: "foo"}) {
+		n++
+		var apiErr *Error
+		if group != nil || !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected API error, got %v, %v", group, err)
+		}
+	}
+
+	if n != 1 {
+		t.Errorf("expected the iteration to stop at the error, got %d items", n)
+	}
+}
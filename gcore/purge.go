@@ -0,0 +1,277 @@
+package gcore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	purgeStatusesURL = "/purge_statuses"
+	purgeStatusURL   = "/purge_statuses/%d"
+)
+
+// PurgeMode represents the type for the purge request mode.
+type PurgeMode string
+
+// The list of the possible purge request modes.
+const (
+	// PurgeModeURLs purges exact URLs, query strings are taken into account.
+	PurgeModeURLs PurgeMode = "urls"
+
+	// PurgeModePatterns purges paths matching the patterns, e.g. "/static/*.css".
+	PurgeModePatterns PurgeMode = "patterns"
+
+	// PurgeModeAll purges all cache of the resource.
+	PurgeModeAll PurgeMode = "all"
+)
+
+// The list of the possible purge types returned by the purge statuses API.
+const (
+	PurgeTypeURLs     = "purge_by_url"
+	PurgeTypePatterns = "purge_by_pattern"
+	PurgeTypeAll      = "purge_all"
+)
+
+// The list of the possible purge statuses.
+const (
+	PurgeStatusInProgress = "In progress"
+	PurgeStatusSuccessful = "Successful"
+	PurgeStatusFailed     = "Failed"
+)
+
+// ErrPurgeFailed is returned by WaitForPurge if the purge has failed.
+var ErrPurgeFailed = errors.New("gcore: purge has failed")
+
+// PurgeRequest represents request body for purge.
+type PurgeRequest struct {
+	Mode  PurgeMode
+	Paths []string
+}
+
+// PurgeURLs returns request to purge given URLs.
+func PurgeURLs(urls ...string) *PurgeRequest {
+	return &PurgeRequest{Mode: PurgeModeURLs, Paths: urls}
+}
+
+// PurgePatterns returns request to purge paths matching given patterns.
+func PurgePatterns(patterns ...string) *PurgeRequest {
+	return &PurgeRequest{Mode: PurgeModePatterns, Paths: patterns}
+}
+
+// PurgeAll returns request to purge all cache of the resource.
+func PurgeAll() *PurgeRequest {
+	return &PurgeRequest{Mode: PurgeModeAll}
+}
+
+// Validate checks that the request paths match its mode.
+func (r *PurgeRequest) Validate() error {
+	switch r.Mode {
+	case PurgeModeURLs, PurgeModePatterns:
+		if len(r.Paths) == 0 {
+			return fmt.Errorf("gcore: %s purge requires at least one path", r.Mode)
+		}
+	case PurgeModeAll:
+		if len(r.Paths) != 0 {
+			return errors.New("gcore: purge of all cache doesn't accept paths")
+		}
+	default:
+		return fmt.Errorf("gcore: unknown purge mode %q", r.Mode)
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (r PurgeRequest) MarshalJSON() ([]byte, error) {
+	switch r.Mode {
+	case PurgeModeURLs:
+		return json.Marshal(struct {
+			URLs []string `json:"urls"`
+		}{r.Paths})
+	case PurgeModePatterns:
+		return json.Marshal(struct {
+			Paths []string `json:"paths"`
+		}{r.Paths})
+	}
+
+	return []byte(`{"paths":[]}`), nil
+}
+
+// PurgeTask represents purge created by the API.
+type PurgeTask struct {
+	ID int `json:"id"`
+}
+
+// PurgeStatusResource represents resource the purge belongs to.
+type PurgeStatusResource struct {
+	ID    int    `json:"id"`
+	Cname string `json:"cname"`
+}
+
+// PurgeStatus represents state of the purge.
+type PurgeStatus struct {
+	ID        int                 `json:"id"`
+	Name      string              `json:"name"`
+	Created   *Time               `json:"created"`
+	PurgeType string              `json:"purge_type"`
+	Status    string              `json:"status"`
+	Resource  PurgeStatusResource `json:"resource"`
+	URLs      []string            `json:"urls"`
+
+	// Extra represents fields the library doesn't model yet by their keys.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface,
+// unknown fields are stored in Extra.
+func (p *PurgeStatus) UnmarshalJSON(b []byte) error {
+	type purgeStatus PurgeStatus
+
+	var alias purgeStatus
+	extra, err := unmarshalWithExtra(b, &alias)
+	if err != nil {
+		return err
+	}
+
+	*p = PurgeStatus(alias)
+	p.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface,
+// fields from Extra are added unless PurgeStatus has a field for them.
+func (p PurgeStatus) MarshalJSON() ([]byte, error) {
+	type purgeStatus PurgeStatus
+
+	return marshalWithExtra(purgeStatus(p), p.Extra)
+}
+
+// Done reports whether the purge is finished.
+func (p *PurgeStatus) Done() bool {
+	return p.Status == PurgeStatusSuccessful || p.Status == PurgeStatusFailed
+}
+
+// PurgeStatusesOpts represents list of additional options to filter
+// purge history by.
+type PurgeStatusesOpts struct {
+	Cname       string `param:"cname,omitempty"`
+	PurgeType   string `param:"purge_type,omitempty"`
+	Status      string `param:"status,omitempty"`
+	FromCreated string `param:"from_created,omitempty"`
+	ToCreated   string `param:"to_created,omitempty"`
+	Limit       int    `param:"limit,omitempty"`
+	Offset      int    `param:"offset,omitempty"`
+}
+
+// CreatePurge method purges cache of the resource by given request and
+// returns the created purge, its status can be tracked with GetPurgeStatus.
+func (s *ResourcesService) CreatePurge(ctx context.Context, resourceID int, body *PurgeRequest) (*PurgeTask, *http.Response, error) {
+	if body == nil {
+		return nil, nil, errNilBody
+	}
+
+	if err := body.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx,
+		http.MethodPost,
+		fmt.Sprintf(resourcePurgeURL, resourceID), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	task := &PurgeTask{}
+
+	resp, err := s.client.Do(req, task)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return task, resp, nil
+}
+
+// PurgeStatuses method returns history of the purges of the account.
+func (s *ResourcesService) PurgeStatuses(ctx context.Context, opts PurgeStatusesOpts) ([]*PurgeStatus, *http.Response, error) {
+	url := purgeStatusesURL
+	queryParams, err := BuildQueryParameters(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if queryParams != "" {
+		url = strings.Join([]string{url, queryParams}, "?")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var statuses struct {
+		Count   int            `json:"count"`
+		Results []*PurgeStatus `json:"results"`
+	}
+
+	resp, err := s.client.Do(req, &statuses)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if statuses.Results == nil {
+		statuses.Results = make([]*PurgeStatus, 0)
+	}
+
+	return statuses.Results, resp, nil
+}
+
+// GetPurgeStatus method returns status of the purge by given purgeID.
+func (s *ResourcesService) GetPurgeStatus(ctx context.Context, purgeID int) (*PurgeStatus, *http.Response, error) {
+	req, err := s.client.NewRequest(ctx,
+		http.MethodGet,
+		fmt.Sprintf(purgeStatusURL, purgeID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status := &PurgeStatus{}
+
+	resp, err := s.client.Do(req, status)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return status, resp, nil
+}
+
+// WaitForPurge method polls status of the purge by given purgeID until it's
+// finished. ErrPurgeFailed is returned if the purge has failed.
+// It accepts the same options as WaitForStatus.
+func (s *ResourcesService) WaitForPurge(ctx context.Context, purgeID int, opts ...WaitOption) (*PurgeStatus, error) {
+	return poll(ctx, newWaitOptions(opts...),
+		func(ctx context.Context) (*PurgeStatus, bool, error) {
+			status, _, err := s.GetPurgeStatus(ctx, purgeID)
+			if err != nil {
+				return nil, false, err
+			}
+
+			if status.Status == PurgeStatusFailed {
+				return status, true, ErrPurgeFailed
+			}
+
+			return status, status.Done(), nil
+		},
+		func(status *PurgeStatus, err error) error {
+			if status == nil {
+				return fmt.Errorf("gcore: purge %d hasn't finished: %w", purgeID, err)
+			}
+
+			return fmt.Errorf("gcore: purge %d is still in %q status: %w",
+				purgeID, status.Status, err)
+		})
+}
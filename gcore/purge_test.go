@@ -0,0 +1,294 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	th "github.com/dstdfx/go-gcore/gcore/internal/testhelper"
+)
+
+// Fixtures
+const (
+	testCreatePurgeRawResponse = `{"id": 1234}`
+	testPurgeStatusRawResponse = `{
+  "id": 1234,
+  "name": "purge_1234",
+  "created": "2019-06-12T10:11:12.000000Z",
+  "purge_type": "purge_by_url",
+  "status": "Successful",
+  "resource": {
+    "id": 4478,
+    "cname": "cdn.site.com"
+  },
+  "urls": ["/static/app.css"]
+}`
+	testPurgeStatusesRawResponse = `{
+  "count": 1,
+  "results": [` + testPurgeStatusRawResponse + `]
+}`
+)
+
+var testPurgeStatusExpected = &PurgeStatus{
+	ID:        1234,
+	Name:      "purge_1234",
+	Created:   NewTime(time.Date(2019, 6, 12, 10, 11, 12, 0, time.UTC)),
+	PurgeType: PurgeTypeURLs,
+	Status:    PurgeStatusSuccessful,
+	Resource: PurgeStatusResource{
+		ID:    4478,
+		Cname: "cdn.site.com",
+	},
+	URLs: []string{"/static/app.css"},
+}
+
+func TestResourcesService_CreatePurge(t *testing.T) {
+	testCases := []struct {
+		request    *PurgeRequest
+		rawRequest string
+	}{
+		{
+			request:    PurgeURLs("/static/app.css?v=1"),
+			rawRequest: `{"urls": ["/static/app.css?v=1"]}`,
+		},
+		{
+			request:    PurgePatterns("/static/*.css"),
+			rawRequest: `{"paths": ["/static/*.css"]}`,
+		},
+		{
+			request:    PurgeAll(),
+			rawRequest: `{"paths": []}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		endpointCalled := false
+
+		testEnv := th.SetupTestEnv()
+
+		handleOpts := &th.HandleReqOpts{
+			Mux:         testEnv.Mux,
+			URL:         fmt.Sprintf(resourcePurgeURL, testGetResourceExpected.ID),
+			RawResponse: testCreatePurgeRawResponse,
+			RawRequest:  tc.rawRequest,
+			Method:      http.MethodPost,
+			Status:      http.StatusCreated,
+			CallFlag:    &endpointCalled,
+		}
+
+		th.HandleReqWithBody(t, handleOpts)
+
+		client := NewCommonClient()
+		client.BaseURL = testEnv.GetServerURL()
+		_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+		got, _, err := client.Resources.CreatePurge(context.Background(), testGetResourceExpected.ID, tc.request)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !endpointCalled {
+			t.Fatalf("didn't purge cache by %s", tc.request.Mode)
+		}
+
+		if got.ID != 1234 {
+			t.Errorf("Expected: %d, got %d", 1234, got.ID)
+		}
+
+		testEnv.TearDownTestEnv()
+	}
+}
+
+func TestPurgeRequest_Validate(t *testing.T) {
+	testCases := []struct {
+		request *PurgeRequest
+		valid   bool
+	}{
+		{request: PurgeURLs("/a"), valid: true},
+		{request: PurgeURLs(), valid: false},
+		{request: PurgePatterns("/a/*"), valid: true},
+		{request: PurgePatterns(), valid: false},
+		{request: PurgeAll(), valid: true},
+		{request: &PurgeRequest{Mode: PurgeModeAll, Paths: []string{"/a"}}, valid: false},
+		{request: &PurgeRequest{Mode: "unknown"}, valid: false},
+	}
+
+	for _, tc := range testCases {
+		if err := tc.request.Validate(); (err == nil) != tc.valid {
+			t.Errorf("%+v: expected valid=%v, got error %v", tc.request, tc.valid, err)
+		}
+	}
+}
+
+func TestResourcesService_PurgeStatuses(t *testing.T) {
+	endpointCalled := false
+
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	handleOpts := &th.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         purgeStatusesURL,
+		RawResponse: testPurgeStatusesRawResponse,
+		QueryParams: map[string]string{
+			"cname":  "cdn.site.com",
+			"status": PurgeStatusSuccessful,
+			"limit":  "10",
+		},
+		Method:   http.MethodGet,
+		Status:   http.StatusOK,
+		CallFlag: &endpointCalled,
+	}
+
+	th.HandleReqWithoutBody(t, handleOpts)
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	got, _, err := client.Resources.PurgeStatuses(context.Background(), PurgeStatusesOpts{
+		Cname:  "cdn.site.com",
+		Status: PurgeStatusSuccessful,
+		Limit:  10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !endpointCalled {
+		t.Fatal("didn't get purge statuses")
+	}
+
+	expected := []*PurgeStatus{testPurgeStatusExpected}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected: %+v, got %+v\n", expected, got)
+	}
+}
+
+func TestResourcesService_WaitForPurge(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	calls := 0
+	testEnv.Mux.HandleFunc(fmt.Sprintf(purgeStatusURL, 1234), func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Add("Content-Type", "application/json")
+
+		if calls < 3 {
+			fmt.Fprint(w, `{"id": 1234, "status": "In progress"}`)
+			return
+		}
+		fmt.Fprint(w, testPurgeStatusRawResponse)
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	got, err := client.Resources.WaitForPurge(context.Background(), 1234, WithPollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, testPurgeStatusExpected) {
+		t.Errorf("Expected: %+v, got %+v\n", testPurgeStatusExpected, got)
+	}
+
+	if calls != 3 {
+		t.Errorf("Expected: %d calls, got %d", 3, calls)
+	}
+}
+
+func TestResourcesService_WaitForPurge_Failed(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testEnv.Mux.HandleFunc(fmt.Sprintf(purgeStatusURL, 1234), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": 1234, "status": "Failed"}`)
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	_, err := client.Resources.WaitForPurge(context.Background(), 1234, WithPollInterval(time.Millisecond))
+	if !errors.Is(err, ErrPurgeFailed) {
+		t.Errorf("Expected: %v, got %v", ErrPurgeFailed, err)
+	}
+}
+
+func TestResourcesService_WaitForPurge_TimeoutDuringCheck(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testEnv.Mux.HandleFunc(fmt.Sprintf(purgeStatusURL, 1234), func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	got, err := client.Resources.WaitForPurge(context.Background(), 1234,
+		WithPollInterval(time.Millisecond), WithWaitTimeout(20*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected: %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	if !strings.Contains(err.Error(), "hasn't finished") {
+		t.Errorf("Expected the wait error, got %v", err)
+	}
+
+	if got != nil {
+		t.Errorf("Expected: nil, got %+v", got)
+	}
+}
+
+func TestResourcesService_WaitForPurge_ZeroPollInterval(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testEnv.Mux.HandleFunc(fmt.Sprintf(purgeStatusURL, 1234), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, testPurgeStatusRawResponse)
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	got, err := client.Resources.WaitForPurge(context.Background(), 1234, WithPollInterval(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, testPurgeStatusExpected) {
+		t.Errorf("Expected: %+v, got %+v\n", testPurgeStatusExpected, got)
+	}
+}
+
+func TestResourcesService_WaitForPurge_Timeout(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testEnv.Mux.HandleFunc(fmt.Sprintf(purgeStatusURL, 1234), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": 1234, "status": "In progress"}`)
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	_, err := client.Resources.WaitForPurge(context.Background(), 1234,
+		WithPollInterval(time.Millisecond), WithWaitTimeout(20*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected: %v, got %v", context.DeadlineExceeded, err)
+	}
+}
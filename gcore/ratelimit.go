@@ -0,0 +1,269 @@
+package gcore
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// minRateDivider defines how much TokenBucket can lower its rate
+	// comparing to the configured one after 429 responses.
+	minRateDivider = 10
+
+	// rateRecoveryFactor defines the part of the configured rate that
+	// TokenBucket restores after each successful response.
+	rateRecoveryFactor = 0.05
+
+	// epochThreshold is used to tell unix timestamps from amount of seconds
+	// in rate-limit reset headers.
+	epochThreshold = 1000000000
+)
+
+// The list of the endpoint classes returned by EndpointClass.
+const (
+	EndpointClassRead  = "read"
+	EndpointClassWrite = "write"
+	EndpointClassPurge = "purge"
+)
+
+// RateLimiter represents a client-side limiter of the requests rate.
+// Client waits for a permit before every request and reports every response
+// back to the limiter so it can adapt its rate.
+type RateLimiter interface {
+	// Wait blocks until the request is permitted or the context is done.
+	Wait(ctx context.Context, req *http.Request) error
+
+	// Observe adapts the limiter to the response of the permitted request.
+	Observe(req *http.Request, resp *http.Response)
+}
+
+// EndpointClass returns the class of the endpoint for given request:
+// "purge" for purge and prefetch requests, "read" for other GET and HEAD
+// requests and "write" for the rest of them.
+func EndpointClass(req *http.Request) string {
+	if strings.HasSuffix(req.URL.Path, "/purge") || strings.HasSuffix(req.URL.Path, "/prefetch") {
+		return EndpointClassPurge
+	}
+
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return EndpointClassRead
+	}
+
+	return EndpointClassWrite
+}
+
+// TokenBucket represents a token bucket RateLimiter.
+// It halves its rate on 429 responses, pauses until the time requested by
+// Retry-After or X-RateLimit-Reset headers and slowly restores the configured
+// rate after successful responses.
+type TokenBucket struct {
+	mu sync.Mutex
+
+	maxRate     float64
+	minRate     float64
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+// NewTokenBucket returns a reference to a token bucket that permits
+// ratePerSecond requests per second with bursts of given size.
+// It panics if ratePerSecond isn't positive like time.NewTicker does.
+func NewTokenBucket(ratePerSecond float64, burst int) *TokenBucket {
+	if !(ratePerSecond > 0) {
+		panic("gcore: non-positive rate for NewTokenBucket")
+	}
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &TokenBucket{
+		maxRate: ratePerSecond,
+		minRate: ratePerSecond / minRateDivider,
+		rate:    ratePerSecond,
+		burst:   float64(burst),
+		tokens:  float64(burst),
+		last:    time.Now(),
+	}
+}
+
+// Rate returns current rate of the bucket in requests per second.
+func (b *TokenBucket) Rate() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.rate
+}
+
+// Wait implements RateLimiter interface.
+func (b *TokenBucket) Wait(ctx context.Context, _ *http.Request) error {
+	delay := b.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes a token from the bucket and returns the time to wait until
+// the token becomes available.
+func (b *TokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.refill(now)
+	b.tokens--
+
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+
+	if pause := b.pausedUntil.Sub(now); pause > delay {
+		delay = pause
+	}
+
+	return delay
+}
+
+// cancel returns the token that hasn't been used back to the bucket.
+func (b *TokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = math.Min(b.tokens+1, b.burst)
+}
+
+// refill adds tokens that have been accumulated since the last refill.
+func (b *TokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+
+	b.tokens = math.Min(b.tokens+elapsed*b.rate, b.burst)
+	b.last = now
+}
+
+// Observe implements RateLimiter interface.
+func (b *TokenBucket) Observe(_ *http.Request, resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.refill(now)
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		b.rate = math.Max(b.rate/2, b.minRate)
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			b.pause(now.Add(d))
+		}
+
+		return
+	}
+
+	if resp.StatusCode < http.StatusBadRequest {
+		b.rate = math.Min(b.rate+b.maxRate*rateRecoveryFactor, b.maxRate)
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, ok := parseRateLimitReset(resp.Header.Get("X-RateLimit-Reset"), now); ok {
+			b.pause(reset)
+		}
+	}
+}
+
+// pause forbids requests until given time.
+func (b *TokenBucket) pause(until time.Time) {
+	if until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+}
+
+// parseRateLimitReset parses X-RateLimit-Reset header value which can be
+// either a number of seconds or a unix timestamp.
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	reset, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || reset < 0 {
+		return time.Time{}, false
+	}
+
+	if reset < epochThreshold {
+		return now.Add(time.Duration(reset) * time.Second), true
+	}
+
+	return time.Unix(reset, 0), true
+}
+
+// ClassRateLimiter represents a RateLimiter that combines the limiter for
+// all requests of the client with separate limiters per endpoint class,
+// e.g. purge requests vs. read requests.
+type ClassRateLimiter struct {
+	// Global limits all requests of the client, it's optional.
+	Global RateLimiter
+
+	// Classes represents limiters per endpoint class. Requests of the
+	// classes without a limiter are limited by Global only.
+	Classes map[string]RateLimiter
+
+	// Classify returns endpoint class of the request, EndpointClass is
+	// used by default.
+	Classify func(req *http.Request) string
+}
+
+// classLimiter returns limiter for the request class.
+func (l *ClassRateLimiter) classLimiter(req *http.Request) RateLimiter {
+	classify := l.Classify
+	if classify == nil {
+		classify = EndpointClass
+	}
+
+	return l.Classes[classify(req)]
+}
+
+// Wait implements RateLimiter interface.
+func (l *ClassRateLimiter) Wait(ctx context.Context, req *http.Request) error {
+	if l.Global != nil {
+		if err := l.Global.Wait(ctx, req); err != nil {
+			return err
+		}
+	}
+
+	if limiter := l.classLimiter(req); limiter != nil {
+		return limiter.Wait(ctx, req)
+	}
+
+	return nil
+}
+
+// Observe implements RateLimiter interface.
+func (l *ClassRateLimiter) Observe(req *http.Request, resp *http.Response) {
+	if l.Global != nil {
+		l.Global.Observe(req, resp)
+	}
+
+	if limiter := l.classLimiter(req); limiter != nil {
+		limiter.Observe(req, resp)
+	}
+}
@@ -0,0 +1,200 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	th "github.com/dstdfx/go-gcore/gcore/internal/testhelper"
+)
+
+// testRateLimiter records calls made by Client.
+type testRateLimiter struct {
+	waits    int
+	observed []int
+}
+
+func (l *testRateLimiter) Wait(_ context.Context, _ *http.Request) error {
+	l.waits++
+	return nil
+}
+
+func (l *testRateLimiter) Observe(_ *http.Request, resp *http.Response) {
+	l.observed = append(l.observed, resp.StatusCode)
+}
+
+func TestClient_Do_RateLimiter(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	calls := 0
+	testEnv.Mux.HandleFunc(resourcesURL, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Add("Content-Type", "application/json")
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, testListResourcesRawResponse)
+	})
+
+	limiter := &testRateLimiter{}
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	client.RateLimiter = limiter
+	client.RetryPolicy = testRetryPolicy()
+
+	_, _, err := client.Resources.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if limiter.waits != 2 {
+		t.Errorf("Expected: %d waits, got %d", 2, limiter.waits)
+	}
+
+	if len(limiter.observed) != 2 ||
+		limiter.observed[0] != http.StatusTooManyRequests ||
+		limiter.observed[1] != http.StatusOK {
+		t.Errorf("unexpected observed responses: %v", limiter.observed)
+	}
+}
+
+func TestTokenBucket_Wait(t *testing.T) {
+	bucket := NewTokenBucket(100, 2)
+	req := httptest.NewRequest(http.MethodGet, resourcesURL, nil)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := bucket.Wait(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Two requests fit the burst, two more need 10ms each.
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("expected requests to be limited, elapsed %v", elapsed)
+	}
+}
+
+func TestTokenBucket_WaitContextCanceled(t *testing.T) {
+	bucket := NewTokenBucket(1, 1)
+	req := httptest.NewRequest(http.MethodGet, resourcesURL, nil)
+
+	if err := bucket.Wait(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := bucket.Wait(ctx, req); err != context.DeadlineExceeded {
+		t.Fatalf("Expected: %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestTokenBucket_Observe(t *testing.T) {
+	bucket := NewTokenBucket(10, 1)
+	req := httptest.NewRequest(http.MethodGet, resourcesURL, nil)
+
+	bucket.Observe(req, &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}})
+	if rate := bucket.Rate(); rate != 5 {
+		t.Errorf("Expected: %v, got %v", 5, rate)
+	}
+
+	for i := 0; i < 10; i++ {
+		bucket.Observe(req, &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}})
+	}
+	if rate := bucket.Rate(); rate != 1 {
+		t.Errorf("Expected rate to be limited by minimum %v, got %v", 1, rate)
+	}
+
+	for i := 0; i < 100; i++ {
+		bucket.Observe(req, &http.Response{StatusCode: http.StatusOK, Header: http.Header{}})
+	}
+	if rate := bucket.Rate(); rate != 10 {
+		t.Errorf("Expected rate to be restored to %v, got %v", 10, rate)
+	}
+
+	header := http.Header{}
+	header.Set("X-RateLimit-Remaining", "0")
+	header.Set("X-RateLimit-Reset", "60")
+	bucket.Observe(req, &http.Response{StatusCode: http.StatusOK, Header: header})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := bucket.Wait(ctx, req); err != context.DeadlineExceeded {
+		t.Fatalf("expected bucket to be paused, got %v", err)
+	}
+}
+
+func TestClassRateLimiter(t *testing.T) {
+	purgeLimiter := &testRateLimiter{}
+	globalLimiter := &testRateLimiter{}
+
+	limiter := &ClassRateLimiter{
+		Global: globalLimiter,
+		Classes: map[string]RateLimiter{
+			EndpointClassPurge: purgeLimiter,
+		},
+	}
+
+	purgeReq := httptest.NewRequest(http.MethodPost, fmt.Sprintf(resourcePurgeURL, 1), nil)
+	readReq := httptest.NewRequest(http.MethodGet, resourcesURL, nil)
+
+	for _, req := range []*http.Request{purgeReq, readReq} {
+		if err := limiter.Wait(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+		limiter.Observe(req, &http.Response{StatusCode: http.StatusOK})
+	}
+
+	if globalLimiter.waits != 2 || len(globalLimiter.observed) != 2 {
+		t.Errorf("unexpected global limiter calls: %d waits, %d observed",
+			globalLimiter.waits, len(globalLimiter.observed))
+	}
+
+	if purgeLimiter.waits != 1 || len(purgeLimiter.observed) != 1 {
+		t.Errorf("unexpected purge limiter calls: %d waits, %d observed",
+			purgeLimiter.waits, len(purgeLimiter.observed))
+	}
+}
+
+func TestEndpointClass(t *testing.T) {
+	testCases := []struct {
+		method   string
+		path     string
+		expected string
+	}{
+		{http.MethodPost, fmt.Sprintf(resourcePurgeURL, 1), EndpointClassPurge},
+		{http.MethodPost, fmt.Sprintf(resourcePrefetchURL, 1), EndpointClassPurge},
+		{http.MethodGet, resourcesURL, EndpointClassRead},
+		{http.MethodPut, fmt.Sprintf(resourceURL, 1), EndpointClassWrite},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		if got := EndpointClass(req); got != tc.expected {
+			t.Errorf("%s %s: Expected: %s, got %s", tc.method, tc.path, tc.expected, got)
+		}
+	}
+}
+
+func TestNewTokenBucket_NonPositiveRate(t *testing.T) {
+	for _, rate := range []float64{0, -1, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected panic for rate %v", rate)
+				}
+			}()
+			NewTokenBucket(rate, 1)
+		}()
+	}
+}
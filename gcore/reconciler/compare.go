@@ -0,0 +1,150 @@
+package reconciler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// originGroupChanges returns JSON names of the origin group fields
+// that differ.
+func originGroupChanges(desired *OriginGroupSpec, current *gcore.OriginGroup) []string {
+	var changes []string
+
+	if desired.UseNext != current.UseNext {
+		changes = append(changes, "useNext")
+	}
+
+	if !reflect.DeepEqual(originKeys(desired.Origins), originKeys(current.Origins)) {
+		changes = append(changes, "origins")
+	}
+
+	return changes
+}
+
+// originKeys returns sorted keys of the origins that are compared. Enabled
+// isn't compared since false is omitted from the requests and can't be
+// applied.
+func originKeys(origins []gcore.Origin) []string {
+	keys := make([]string, 0, len(origins))
+	for _, origin := range origins {
+		keys = append(keys, fmt.Sprintf("%s|%t", origin.Source, origin.Backup))
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// equalStrings reports whether a and b contain the same strings
+// regardless of their order.
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+
+	return reflect.DeepEqual(a, b)
+}
+
+// setOptions returns JSON of the options that are set by their names.
+func setOptions(options *gcore.Options) map[string]json.RawMessage {
+	set := make(map[string]json.RawMessage)
+	if options == nil {
+		return set
+	}
+
+	b, err := json.Marshal(options)
+	if err != nil {
+		return set
+	}
+	_ = json.Unmarshal(b, &set)
+
+	for name, value := range set {
+		if bytes.Equal(value, []byte("null")) {
+			delete(set, name)
+		}
+	}
+
+	return set
+}
+
+// optionsChanges returns names of the desired options that differ from
+// the current ones prefixed with "options.".
+func optionsChanges(desired, current *gcore.Options) []string {
+	if desired == nil {
+		return nil
+	}
+
+	desiredSet, currentSet := setOptions(desired), setOptions(current)
+
+	names := make([]string, 0, len(desiredSet))
+	for name := range desiredSet {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var changes []string
+	for _, name := range names {
+		if !equalJSON(desiredSet[name], currentSet[name]) {
+			changes = append(changes, "options."+name)
+		}
+	}
+
+	return changes
+}
+
+// equalJSON reports whether a and b represent the same JSON value.
+func equalJSON(a, b json.RawMessage) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return bytes.Equal(a, b)
+	}
+
+	return reflect.DeepEqual(va, vb)
+}
+
+// mergeOptions returns current options with the desired options set.
+func mergeOptions(current, desired *gcore.Options) (*gcore.Options, error) {
+	if desired == nil {
+		return current, nil
+	}
+
+	merged := make(map[string]json.RawMessage)
+	if current != nil {
+		b, err := json.Marshal(current)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(b, &merged); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, value := range setOptions(desired) {
+		merged[name] = value
+	}
+
+	b, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &gcore.Options{}
+	if err = json.Unmarshal(b, options); err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
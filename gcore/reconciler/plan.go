@@ -0,0 +1,104 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Action represents type of the operation.
+type Action string
+
+// The list of the possible actions of the operations.
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Kind represents type of the object the operation is applied to.
+type Kind string
+
+// The list of the possible kinds of the objects.
+const (
+	KindOriginGroup Kind = "origin_group"
+	KindCertificate Kind = "certificate"
+	KindResource    Kind = "resource"
+	KindRule        Kind = "rule"
+)
+
+// Operation represents a single change of the plan.
+type Operation struct {
+	Action Action
+	Kind   Kind
+
+	// Name represents name of the origin group, the certificate or the rule
+	// and cname of the resource.
+	Name string
+
+	// Resource represents cname of the resource the rule belongs to.
+	Resource string
+
+	// ID represents ID of the live object, it's 0 for created objects.
+	ID int
+
+	// Changes represents JSON names of the fields that differ from
+	// the live object.
+	Changes []string
+
+	apply func(ctx context.Context, s *state) error
+}
+
+// String returns short description of the operation.
+func (o *Operation) String() string {
+	name := o.Name
+	if o.Kind == KindRule {
+		name = o.Resource + "/" + o.Name
+	}
+
+	description := fmt.Sprintf("%s %s %q", o.Action, o.Kind, name)
+	if len(o.Changes) > 0 {
+		description += " (" + strings.Join(o.Changes, ", ") + ")"
+	}
+
+	return description
+}
+
+// Plan represents operations that bring the account to the desired state
+// in the order they are applied: origin groups and certificates are created
+// and updated before resources and resources before rules, deletions are
+// applied in the reverse order.
+type Plan struct {
+	Operations []*Operation
+
+	state *state
+}
+
+// Empty reports whether the account is already in the desired state.
+func (p *Plan) Empty() bool {
+	return len(p.Operations) == 0
+}
+
+// String returns the operations one per line.
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "No changes.\n"
+	}
+
+	var b strings.Builder
+	for _, op := range p.Operations {
+		b.WriteString(actionSymbols[op.Action])
+		b.WriteString(" ")
+		b.WriteString(op.String())
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// actionSymbols represents prefixes of the operations in the plan output.
+var actionSymbols = map[Action]string{
+	ActionCreate: "+",
+	ActionUpdate: "~",
+	ActionDelete: "-",
+}
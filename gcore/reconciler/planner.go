@@ -0,0 +1,546 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// planner builds the plan from the spec and the live objects.
+type planner struct {
+	client *gcore.CommonClient
+	spec   *Spec
+	live   *live
+	state  *state
+	plan   *Plan
+}
+
+// add appends operation to the plan.
+func (p *planner) add(op *Operation) {
+	p.plan.Operations = append(p.plan.Operations, op)
+}
+
+// checkReferences checks that origin groups and certificates the resources
+// and the rules refer to either exist or are in the spec.
+func (p *planner) checkReferences() error {
+	originGroups := make(map[string]struct{})
+	for _, group := range p.spec.OriginGroups {
+		originGroups[group.Name] = struct{}{}
+	}
+	certificates := make(map[string]struct{})
+	for _, cert := range p.spec.Certificates {
+		certificates[cert.Name] = struct{}{}
+	}
+
+	known := func(names map[string]struct{}, live map[string]int, name string) bool {
+		_, inSpec := names[name]
+		_, inLive := live[name]
+		return inSpec || inLive
+	}
+
+	for _, resource := range p.spec.Resources {
+		if !known(originGroups, p.state.originGroups, resource.OriginGroup) {
+			return fmt.Errorf("reconciler: resource %q refers to unknown origin group %q",
+				resource.Cname, resource.OriginGroup)
+		}
+		if resource.Certificate != "" && !known(certificates, p.state.certificates, resource.Certificate) {
+			return fmt.Errorf("reconciler: resource %q refers to unknown certificate %q",
+				resource.Cname, resource.Certificate)
+		}
+		for _, rule := range resource.Rules {
+			if rule.OriginGroup != "" && !known(originGroups, p.state.originGroups, rule.OriginGroup) {
+				return fmt.Errorf("reconciler: rule %q of resource %q refers to unknown origin group %q",
+					rule.Name, resource.Cname, rule.OriginGroup)
+			}
+		}
+	}
+
+	return nil
+}
+
+// planOriginGroups plans creation and update of the origin groups.
+func (p *planner) planOriginGroups() {
+	for _, desired := range p.spec.OriginGroups {
+		current, ok := p.live.originGroups[desired.Name]
+		if !ok {
+			p.add(&Operation{
+				Action: ActionCreate,
+				Kind:   KindOriginGroup,
+				Name:   desired.Name,
+				apply: func(ctx context.Context, s *state) error {
+					group, _, err := p.client.OriginGroups.Create(ctx, &gcore.CreateOriginGroupBody{
+						Name:    desired.Name,
+						UseNext: desired.UseNext,
+						Origins: desired.Origins,
+					})
+					if err != nil {
+						return err
+					}
+					s.originGroups[desired.Name] = group.ID
+
+					return nil
+				},
+			})
+			continue
+		}
+
+		changes := originGroupChanges(desired, current)
+		if len(changes) == 0 {
+			continue
+		}
+
+		p.add(&Operation{
+			Action:  ActionUpdate,
+			Kind:    KindOriginGroup,
+			Name:    desired.Name,
+			ID:      current.ID,
+			Changes: changes,
+			apply: func(ctx context.Context, _ *state) error {
+				body := current.UpdateBody()
+				body.UseNext = desired.UseNext
+				body.Origins = desired.Origins
+
+				_, _, err := p.client.OriginGroups.Update(ctx, current.ID, body)
+
+				return err
+			},
+		})
+	}
+}
+
+// planCertificates plans creation of the missing certificates.
+func (p *planner) planCertificates() error {
+	for _, desired := range p.spec.Certificates {
+		if _, ok := p.live.certificates[desired.Name]; ok {
+			continue
+		}
+
+		if desired.Certificate == "" || desired.PrivateKey == "" {
+			return fmt.Errorf("reconciler: certificate %q doesn't exist and can't be created without "+
+				"the certificate and the private key", desired.Name)
+		}
+
+		p.add(&Operation{
+			Action: ActionCreate,
+			Kind:   KindCertificate,
+			Name:   desired.Name,
+			apply: func(ctx context.Context, s *state) error {
+				cert, _, err := p.client.Certificates.Add(ctx, &gcore.AddCertBody{
+					Name:        desired.Name,
+					Certificate: desired.Certificate,
+					PrivateKey:  desired.PrivateKey,
+				})
+				if err != nil {
+					return err
+				}
+				s.certificates[desired.Name] = cert.ID
+
+				return nil
+			},
+		})
+	}
+
+	return nil
+}
+
+// planResources plans creation and update of the resources.
+func (p *planner) planResources() {
+	for _, desired := range p.spec.Resources {
+		current, ok := p.live.resources[desired.Cname]
+		if !ok {
+			p.add(&Operation{
+				Action: ActionCreate,
+				Kind:   KindResource,
+				Name:   desired.Cname,
+				apply: func(ctx context.Context, s *state) error {
+					return p.createResource(ctx, s, desired)
+				},
+			})
+			continue
+		}
+
+		changes := p.resourceChanges(desired, current)
+		if len(changes) == 0 {
+			continue
+		}
+
+		p.add(&Operation{
+			Action:  ActionUpdate,
+			Kind:    KindResource,
+			Name:    desired.Cname,
+			ID:      current.ID,
+			Changes: changes,
+			apply: func(ctx context.Context, s *state) error {
+				return p.updateResource(ctx, s, desired, current)
+			},
+		})
+	}
+}
+
+// createResource creates the resource and stores its ID in the state.
+func (p *planner) createResource(ctx context.Context, s *state, desired *ResourceSpec) error {
+	originGroupID, err := s.originGroupID(desired.OriginGroup)
+	if err != nil {
+		return err
+	}
+
+	body := &gcore.CreateResourceBody{
+		Cname:              desired.Cname,
+		OriginGroupID:      &originGroupID,
+		SecondaryHostnames: desired.SecondaryHostnames,
+		OriginProtocol:     desired.OriginProtocol,
+		Options:            desired.Options,
+	}
+	if desired.Certificate != "" {
+		certID, err := s.certificateID(desired.Certificate)
+		if err != nil {
+			return err
+		}
+		body.SslData = &certID
+		body.SslEnabled = true
+	}
+
+	resource, _, err := p.client.Resources.Create(ctx, body)
+	if err != nil {
+		return err
+	}
+	s.resources[desired.Cname] = resource.ID
+
+	return nil
+}
+
+// updateResource updates the live resource keeping the fields and
+// the options that aren't managed by the spec.
+func (p *planner) updateResource(ctx context.Context, s *state, desired *ResourceSpec, current *gcore.Resource) error {
+	originGroupID, err := s.originGroupID(desired.OriginGroup)
+	if err != nil {
+		return err
+	}
+
+	body := current.UpdateBody()
+	body.OriginGroup = originGroupID
+	if desired.OriginProtocol != "" {
+		body.OriginProtocol = desired.OriginProtocol
+	}
+
+	body.SecondaryHostnames = desired.SecondaryHostnames
+	if body.SecondaryHostnames == nil {
+		body.SecondaryHostnames = []string{}
+	}
+
+	sslEnabled := desired.Certificate != ""
+	body.SslEnabled = &sslEnabled
+	if sslEnabled {
+		certID, err := s.certificateID(desired.Certificate)
+		if err != nil {
+			return err
+		}
+		body.SslData = &certID
+	}
+
+	if body.Options, err = mergeOptions(current.Options, desired.Options); err != nil {
+		return err
+	}
+
+	_, _, err = p.client.Resources.Update(ctx, current.ID, body)
+
+	return err
+}
+
+// resourceChanges returns JSON names of the resource fields that differ.
+func (p *planner) resourceChanges(desired *ResourceSpec, current *gcore.Resource) []string {
+	var changes []string
+
+	if id, ok := p.state.originGroups[desired.OriginGroup]; !ok || id != current.OriginGroup {
+		changes = append(changes, "originGroup")
+	}
+
+	if desired.OriginProtocol != "" && desired.OriginProtocol != current.OriginProtocol {
+		changes = append(changes, "originProtocol")
+	}
+
+	if !equalStrings(desired.SecondaryHostnames, current.SecondaryHostnames) {
+		changes = append(changes, "secondaryHostnames")
+	}
+
+	if desired.Certificate == "" {
+		if current.SslEnabled {
+			changes = append(changes, "sslEnabled")
+		}
+	} else if id, ok := p.state.certificates[desired.Certificate]; !ok || !current.SslEnabled ||
+		current.SslData == nil || *current.SslData != id {
+		changes = append(changes, "sslData")
+	}
+
+	return append(changes, optionsChanges(desired.Options, current.Options)...)
+}
+
+// planRules plans creation, update and deletion of the rules of
+// the resources in the spec.
+func (p *planner) planRules() {
+	var deletions []*Operation
+
+	for _, resource := range p.spec.Resources {
+		// Rules are matched by their names, duplicates are deleted.
+		current := make(map[string]*gcore.Rule)
+		for _, rule := range p.live.rules[resource.Cname] {
+			if _, ok := current[rule.Name]; !ok {
+				current[rule.Name] = rule
+			}
+		}
+
+		desiredNames := make(map[string]struct{}, len(resource.Rules))
+		for _, desired := range resource.Rules {
+			desiredNames[desired.Name] = struct{}{}
+
+			rule, ok := current[desired.Name]
+			if !ok {
+				p.add(&Operation{
+					Action:   ActionCreate,
+					Kind:     KindRule,
+					Name:     desired.Name,
+					Resource: resource.Cname,
+					apply: func(ctx context.Context, s *state) error {
+						return p.createRule(ctx, s, resource.Cname, desired)
+					},
+				})
+				continue
+			}
+
+			changes := p.ruleChanges(desired, rule)
+			if len(changes) == 0 {
+				continue
+			}
+
+			p.add(&Operation{
+				Action:   ActionUpdate,
+				Kind:     KindRule,
+				Name:     desired.Name,
+				Resource: resource.Cname,
+				ID:       rule.ID,
+				Changes:  changes,
+				apply: func(ctx context.Context, s *state) error {
+					return p.updateRule(ctx, s, resource.Cname, desired, rule)
+				},
+			})
+		}
+
+		for _, rule := range p.live.rules[resource.Cname] {
+			if _, ok := desiredNames[rule.Name]; ok && current[rule.Name] == rule {
+				continue
+			}
+			deletions = append(deletions, p.deleteRule(resource.Cname, rule))
+		}
+	}
+
+	p.plan.Operations = append(p.plan.Operations, deletions...)
+}
+
+// deleteRule returns operation that deletes the live rule.
+func (p *planner) deleteRule(cname string, rule *gcore.Rule) *Operation {
+	return &Operation{
+		Action:   ActionDelete,
+		Kind:     KindRule,
+		Name:     rule.Name,
+		Resource: cname,
+		ID:       rule.ID,
+		apply: func(ctx context.Context, s *state) error {
+			resourceID, err := s.resourceID(cname)
+			if err != nil {
+				return err
+			}
+
+			_, err = p.client.Rules.Delete(ctx, resourceID, rule.ID)
+
+			return err
+		},
+	}
+}
+
+// createRule creates the rule and sets its fields that can't be set
+// on creation.
+func (p *planner) createRule(ctx context.Context, s *state, cname string, desired *RuleSpec) error {
+	resourceID, err := s.resourceID(cname)
+	if err != nil {
+		return err
+	}
+
+	body := &gcore.CreateRuleBody{
+		Rule:     desired.Rule,
+		Name:     desired.Name,
+		RuleType: desired.RuleType,
+	}
+	if desired.Options != nil {
+		body.Options = *desired.Options
+	}
+
+	rule, _, err := p.client.Rules.Create(ctx, resourceID, body)
+	if err != nil {
+		return err
+	}
+
+	patch := &gcore.UpdateRuleBody{}
+	if err = setRuleFields(s, patch, desired); err != nil {
+		return err
+	}
+	if patch.Weight == nil && patch.OriginGroup == nil && patch.OriginProtocol == nil {
+		return nil
+	}
+
+	_, _, err = p.client.Rules.Patch(ctx, resourceID, rule.ID, patch)
+
+	return err
+}
+
+// updateRule updates the live rule keeping the fields and the options that
+// aren't managed by the spec.
+func (p *planner) updateRule(ctx context.Context, s *state, cname string, desired *RuleSpec, current *gcore.Rule) error {
+	resourceID, err := s.resourceID(cname)
+	if err != nil {
+		return err
+	}
+
+	body := current.UpdateBody()
+	body.Rule = &desired.Rule
+	body.RuleType = &desired.RuleType
+	if err = setRuleFields(s, body, desired); err != nil {
+		return err
+	}
+
+	if body.Options, err = mergeOptions(&current.Options, desired.Options); err != nil {
+		return err
+	}
+
+	_, _, err = p.client.Rules.Update(ctx, resourceID, current.ID, body)
+
+	return err
+}
+
+// setRuleFields sets the optional rule fields that are managed by the spec.
+func setRuleFields(s *state, body *gcore.UpdateRuleBody, desired *RuleSpec) error {
+	if desired.Weight != 0 {
+		body.Weight = &desired.Weight
+	}
+
+	if desired.OriginGroup != "" {
+		originGroupID, err := s.originGroupID(desired.OriginGroup)
+		if err != nil {
+			return err
+		}
+		body.OriginGroup = &originGroupID
+	}
+
+	if desired.OriginProtocol != "" {
+		body.OriginProtocol = &desired.OriginProtocol
+	}
+
+	return nil
+}
+
+// ruleChanges returns JSON names of the rule fields that differ.
+func (p *planner) ruleChanges(desired *RuleSpec, current *gcore.Rule) []string {
+	var changes []string
+
+	if desired.Rule != current.Rule {
+		changes = append(changes, "rule")
+	}
+
+	if desired.RuleType != current.RuleType {
+		changes = append(changes, "ruleType")
+	}
+
+	if desired.Weight != 0 && desired.Weight != current.Weight {
+		changes = append(changes, "weight")
+	}
+
+	if desired.OriginGroup != "" {
+		if id, ok := p.state.originGroups[desired.OriginGroup]; !ok || id != current.OriginGroup {
+			changes = append(changes, "originGroup")
+		}
+	}
+
+	if desired.OriginProtocol != "" && desired.OriginProtocol != current.OriginProtocol {
+		changes = append(changes, "originProtocol")
+	}
+
+	return append(changes, optionsChanges(desired.Options, &current.Options)...)
+}
+
+// planPrune plans deletion of the resources, origin groups and certificates
+// that aren't in the spec.
+func (p *planner) planPrune() {
+	resources := make(map[string]struct{}, len(p.spec.Resources))
+	originGroups := make(map[string]struct{}, len(p.spec.OriginGroups))
+	certificates := make(map[string]struct{}, len(p.spec.Certificates))
+
+	for _, group := range p.spec.OriginGroups {
+		originGroups[group.Name] = struct{}{}
+	}
+	for _, cert := range p.spec.Certificates {
+		certificates[cert.Name] = struct{}{}
+	}
+	for _, resource := range p.spec.Resources {
+		resources[resource.Cname] = struct{}{}
+		originGroups[resource.OriginGroup] = struct{}{}
+		if resource.Certificate != "" {
+			certificates[resource.Certificate] = struct{}{}
+		}
+		for _, rule := range resource.Rules {
+			if rule.OriginGroup != "" {
+				originGroups[rule.OriginGroup] = struct{}{}
+			}
+		}
+	}
+
+	for _, cname := range p.live.order.resources {
+		if _, ok := resources[cname]; ok {
+			continue
+		}
+		resource := p.live.resources[cname]
+		p.add(&Operation{
+			Action: ActionDelete,
+			Kind:   KindResource,
+			Name:   cname,
+			ID:     resource.ID,
+			apply: func(ctx context.Context, _ *state) error {
+				_, err := p.client.Resources.Delete(ctx, resource.ID)
+				return err
+			},
+		})
+	}
+
+	for _, name := range p.live.order.originGroups {
+		if _, ok := originGroups[name]; ok {
+			continue
+		}
+		group := p.live.originGroups[name]
+		p.add(&Operation{
+			Action: ActionDelete,
+			Kind:   KindOriginGroup,
+			Name:   name,
+			ID:     group.ID,
+			apply: func(ctx context.Context, _ *state) error {
+				_, err := p.client.OriginGroups.Delete(ctx, group.ID)
+				return err
+			},
+		})
+	}
+
+	for _, name := range p.live.order.certificates {
+		if _, ok := certificates[name]; ok {
+			continue
+		}
+		cert := p.live.certificates[name]
+		p.add(&Operation{
+			Action: ActionDelete,
+			Kind:   KindCertificate,
+			Name:   name,
+			ID:     cert.ID,
+			apply: func(ctx context.Context, _ *state) error {
+				_, err := p.client.Certificates.Delete(ctx, cert.ID)
+				return err
+			},
+		})
+	}
+}
@@ -0,0 +1,257 @@
+// Package reconciler brings CDN resources, their rules, origin groups and
+// certificates of the account to the desired state.
+//
+// Reconciler compares the spec with the live account, plans create, update
+// and delete operations and applies them in the dependency order:
+//
+//	r := reconciler.New(client, reconciler.WithPrune())
+//	plan, err := r.Plan(ctx, spec)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Print(plan)
+//	err = r.Apply(ctx, plan)
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// Reconciler plans and applies changes of the account.
+type Reconciler struct {
+	client *gcore.CommonClient
+	prune  bool
+	dryRun bool
+}
+
+// Option configures Reconciler.
+type Option func(*Reconciler)
+
+// WithPrune makes the plan delete resources, origin groups and certificates
+// that aren't in the spec. Origin groups and certificates the spec refers to
+// are kept. Rules of the resources in the spec are always pruned.
+func WithPrune() Option {
+	return func(r *Reconciler) {
+		r.prune = true
+	}
+}
+
+// WithDryRun makes Reconcile return the plan without applying it.
+func WithDryRun() Option {
+	return func(r *Reconciler) {
+		r.dryRun = true
+	}
+}
+
+// New returns a reference to Reconciler of the account of given client.
+func New(client *gcore.CommonClient, opts ...Option) *Reconciler {
+	r := &Reconciler{client: client}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Reconcile plans changes of the account and applies them unless dry run
+// is enabled. The plan is returned even if applying has failed.
+func (r *Reconciler) Reconcile(ctx context.Context, spec *Spec) (*Plan, error) {
+	plan, err := r.Plan(ctx, spec)
+	if err != nil || r.dryRun {
+		return plan, err
+	}
+
+	return plan, r.Apply(ctx, plan)
+}
+
+// Apply applies operations of the plan in their order and stops at the first
+// failed one. The plan must be applied only once.
+func (r *Reconciler) Apply(ctx context.Context, plan *Plan) error {
+	for _, op := range plan.Operations {
+		if err := op.apply(ctx, plan.state); err != nil {
+			return fmt.Errorf("reconciler: %s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// Plan returns operations that bring the account to the state of the spec.
+func (r *Reconciler) Plan(ctx context.Context, spec *Spec) (*Plan, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	l, err := r.fetch(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &planner{
+		client: r.client,
+		spec:   spec,
+		live:   l,
+		state:  newState(l),
+		plan:   &Plan{},
+	}
+	p.plan.state = p.state
+
+	if err = p.checkReferences(); err != nil {
+		return nil, err
+	}
+
+	p.planOriginGroups()
+	if err = p.planCertificates(); err != nil {
+		return nil, err
+	}
+	p.planResources()
+	p.planRules()
+
+	if r.prune {
+		p.planPrune()
+	}
+
+	return p.plan, nil
+}
+
+// live represents objects of the account by their names.
+type live struct {
+	originGroups map[string]*gcore.OriginGroup
+	certificates map[string]*gcore.CertSSL
+	resources    map[string]*gcore.Resource
+
+	// rules represents rules of the resources that are in the spec
+	// by cname of the resource.
+	rules map[string][]*gcore.Rule
+
+	// order represents names of the live objects in the order the API
+	// has returned them, so the plan is stable.
+	order struct {
+		originGroups []string
+		certificates []string
+		resources    []string
+	}
+}
+
+// fetch returns live objects of the account and rules of the resources
+// that are in the spec.
+func (r *Reconciler) fetch(ctx context.Context, spec *Spec) (*live, error) {
+	l := &live{
+		originGroups: make(map[string]*gcore.OriginGroup),
+		certificates: make(map[string]*gcore.CertSSL),
+		resources:    make(map[string]*gcore.Resource),
+		rules:        make(map[string][]*gcore.Rule),
+	}
+
+	groups, _, err := r.client.OriginGroups.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if _, ok := l.originGroups[group.Name]; ok {
+			continue
+		}
+		l.originGroups[group.Name] = group
+		l.order.originGroups = append(l.order.originGroups, group.Name)
+	}
+
+	certs, _, err := r.client.Certificates.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, cert := range certs {
+		if _, ok := l.certificates[cert.Name]; ok || cert.Deleted {
+			continue
+		}
+		l.certificates[cert.Name] = cert
+		l.order.certificates = append(l.order.certificates, cert.Name)
+	}
+
+	resources, _, err := r.client.Resources.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, resource := range resources {
+		if _, ok := l.resources[resource.Cname]; ok || resource.Deleted {
+			continue
+		}
+		l.resources[resource.Cname] = resource
+		l.order.resources = append(l.order.resources, resource.Cname)
+	}
+
+	for _, desired := range spec.Resources {
+		resource, ok := l.resources[desired.Cname]
+		if !ok {
+			continue
+		}
+
+		rules, _, err := r.client.Rules.List(ctx, resource.ID)
+		if err != nil {
+			return nil, err
+		}
+		l.rules[desired.Cname] = rules
+	}
+
+	return l, nil
+}
+
+// state represents IDs of the objects by their names. It's filled with
+// the live objects and updated when the plan creates new ones.
+type state struct {
+	originGroups map[string]int
+	certificates map[string]int
+	resources    map[string]int
+}
+
+// newState returns a reference to state with IDs of the live objects.
+func newState(l *live) *state {
+	s := &state{
+		originGroups: make(map[string]int, len(l.originGroups)),
+		certificates: make(map[string]int, len(l.certificates)),
+		resources:    make(map[string]int, len(l.resources)),
+	}
+	for name, group := range l.originGroups {
+		s.originGroups[name] = group.ID
+	}
+	for name, cert := range l.certificates {
+		s.certificates[name] = cert.ID
+	}
+	for cname, resource := range l.resources {
+		s.resources[cname] = resource.ID
+	}
+
+	return s
+}
+
+// originGroupID returns ID of the origin group by its name.
+func (s *state) originGroupID(name string) (int, error) {
+	id, ok := s.originGroups[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown origin group %q", name)
+	}
+
+	return id, nil
+}
+
+// certificateID returns ID of the certificate by its name.
+func (s *state) certificateID(name string) (int, error) {
+	id, ok := s.certificates[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown certificate %q", name)
+	}
+
+	return id, nil
+}
+
+// resourceID returns ID of the resource by its cname.
+func (s *state) resourceID(cname string) (int, error) {
+	id, ok := s.resources[cname]
+	if !ok {
+		return 0, fmt.Errorf("unknown resource %q", cname)
+	}
+
+	return id, nil
+}
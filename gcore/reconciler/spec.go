@@ -0,0 +1,151 @@
+package reconciler
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dstdfx/go-gcore/gcore"
+)
+
+// Spec represents desired state of the account. Origin groups and
+// certificates are identified by their names, resources by their cnames
+// and rules by their names within the resource.
+type Spec struct {
+	OriginGroups []*OriginGroupSpec `json:"originGroups,omitempty"`
+	Certificates []*CertificateSpec `json:"certificates,omitempty"`
+	Resources    []*ResourceSpec    `json:"resources,omitempty"`
+}
+
+// OriginGroupSpec represents desired state of the origin group.
+type OriginGroupSpec struct {
+	Name    string         `json:"name"`
+	UseNext bool           `json:"useNext"`
+	Origins []gcore.Origin `json:"origins"`
+}
+
+// CertificateSpec represents SSL certificate the resources refer to by its
+// name. Certificate and PrivateKey are required only to create a missing
+// certificate, existing certificates are never changed.
+type CertificateSpec struct {
+	Name        string `json:"name"`
+	Certificate string `json:"sslCertificate,omitempty"`
+	PrivateKey  string `json:"sslPrivateKey,omitempty"`
+}
+
+// ResourceSpec represents desired state of the resource and its rules.
+type ResourceSpec struct {
+	Cname string `json:"cname"`
+
+	// OriginGroup represents name of the origin group.
+	OriginGroup string `json:"originGroup"`
+
+	// OriginProtocol isn't changed if it's empty.
+	OriginProtocol string `json:"originProtocol,omitempty"`
+
+	SecondaryHostnames []string `json:"secondaryHostnames,omitempty"`
+
+	// Certificate represents name of the certificate, SSL is disabled
+	// if it's empty.
+	Certificate string `json:"certificate,omitempty"`
+
+	// Options represents options that are managed by the spec,
+	// options that aren't set stay unchanged.
+	Options *gcore.Options `json:"options,omitempty"`
+
+	// Rules represents all rules of the resource, rules that aren't
+	// in the spec are deleted.
+	Rules []*RuleSpec `json:"rules,omitempty"`
+}
+
+// RuleSpec represents desired state of the rule.
+type RuleSpec struct {
+	Name     string `json:"name"`
+	Rule     string `json:"rule"`
+	RuleType int    `json:"ruleType"`
+
+	// Weight isn't changed if it's 0.
+	Weight int `json:"weight,omitempty"`
+
+	// OriginGroup represents name of the origin group, it isn't changed
+	// if it's empty.
+	OriginGroup string `json:"originGroup,omitempty"`
+
+	// OriginProtocol isn't changed if it's empty.
+	OriginProtocol string `json:"originProtocol,omitempty"`
+
+	// Options represents options that are managed by the spec,
+	// options that aren't set stay unchanged.
+	Options *gcore.Options `json:"options,omitempty"`
+}
+
+// Validate checks that the spec objects have names without duplicates
+// and their options are valid. References are checked against the live
+// account by Reconciler.Plan.
+func (s *Spec) Validate() error {
+	var errs []error
+
+	originGroups := make(map[string]struct{}, len(s.OriginGroups))
+	for _, group := range s.OriginGroups {
+		if group.Name == "" {
+			errs = append(errs, errors.New("reconciler: origin group name is empty"))
+			continue
+		}
+		if _, ok := originGroups[group.Name]; ok {
+			errs = append(errs, fmt.Errorf("reconciler: duplicate origin group %q", group.Name))
+		}
+		originGroups[group.Name] = struct{}{}
+
+		if len(group.Origins) == 0 {
+			errs = append(errs, fmt.Errorf("reconciler: origin group %q has no origins", group.Name))
+		}
+	}
+
+	certificates := make(map[string]struct{}, len(s.Certificates))
+	for _, cert := range s.Certificates {
+		if cert.Name == "" {
+			errs = append(errs, errors.New("reconciler: certificate name is empty"))
+			continue
+		}
+		if _, ok := certificates[cert.Name]; ok {
+			errs = append(errs, fmt.Errorf("reconciler: duplicate certificate %q", cert.Name))
+		}
+		certificates[cert.Name] = struct{}{}
+	}
+
+	resources := make(map[string]struct{}, len(s.Resources))
+	for _, resource := range s.Resources {
+		if resource.Cname == "" {
+			errs = append(errs, errors.New("reconciler: resource cname is empty"))
+			continue
+		}
+		if _, ok := resources[resource.Cname]; ok {
+			errs = append(errs, fmt.Errorf("reconciler: duplicate resource %q", resource.Cname))
+		}
+		resources[resource.Cname] = struct{}{}
+
+		if resource.OriginGroup == "" {
+			errs = append(errs, fmt.Errorf("reconciler: resource %q has no origin group", resource.Cname))
+		}
+		if err := resource.Options.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("reconciler: resource %q: %w", resource.Cname, err))
+		}
+
+		rules := make(map[string]struct{}, len(resource.Rules))
+		for _, rule := range resource.Rules {
+			if rule.Name == "" {
+				errs = append(errs, fmt.Errorf("reconciler: resource %q has a rule without name", resource.Cname))
+				continue
+			}
+			if _, ok := rules[rule.Name]; ok {
+				errs = append(errs, fmt.Errorf("reconciler: resource %q has duplicate rule %q", resource.Cname, rule.Name))
+			}
+			rules[rule.Name] = struct{}{}
+
+			if err := rule.Options.Validate(); err != nil {
+				errs = append(errs, fmt.Errorf("reconciler: rule %q of resource %q: %w", rule.Name, resource.Cname, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -2,8 +2,11 @@ package gcore
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
+	"time"
 )
 
 const (
@@ -13,6 +16,19 @@ const (
 	resourcePrefetchURL = "/resources/%d/prefetch"
 )
 
+// The list of the possible values for the resource Status.
+const (
+	ResourceStatusActive    = "active"
+	ResourceStatusSuspended = "suspended"
+	ResourceStatusProcessed = "processed"
+)
+
+// The default configuration of WaitForStatus.
+const (
+	defaultPollInterval = 5 * time.Second
+	defaultWaitTimeout  = 10 * time.Minute
+)
+
 // ResourcesService handles communication with the resource related methods
 // of the G-Core CDN API.
 type ResourcesService service
@@ -37,6 +53,53 @@ type Resource struct {
 	UpdatedAt          *Time    `json:"updated"`
 	SslData            *int     `json:"sslData"`
 	SslEnabled         bool     `json:"sslEnabled"`
+
+	// Extra represents fields the library doesn't model yet by their keys.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface,
+// unknown fields are stored in Extra.
+func (r *Resource) UnmarshalJSON(b []byte) error {
+	type resource Resource
+
+	var alias resource
+	extra, err := unmarshalWithExtra(b, &alias)
+	if err != nil {
+		return err
+	}
+
+	*r = Resource(alias)
+	r.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface,
+// fields from Extra are added unless Resource has a field for them.
+func (r Resource) MarshalJSON() ([]byte, error) {
+	type resource Resource
+
+	return marshalWithExtra(resource(r), r.Extra)
+}
+
+// UpdateBody returns request body that updates the resource to its current
+// state including the fields kept in Extra, so the resource got from the API
+// can be modified and updated without losing them.
+func (r *Resource) UpdateBody() *UpdateResourceBody {
+	active, enabled, sslEnabled := r.Active, r.Enabled, r.SslEnabled
+
+	return &UpdateResourceBody{
+		Active:             &active,
+		Enabled:            &enabled,
+		OriginGroup:        r.OriginGroup,
+		SecondaryHostnames: r.SecondaryHostnames,
+		OriginProtocol:     r.OriginProtocol,
+		SslData:            r.SslData,
+		SslEnabled:         &sslEnabled,
+		Options:            r.Options,
+		Extra:              r.Extra,
+	}
 }
 
 // CreateResourceBody represents request body for resource create.
@@ -61,10 +124,30 @@ type UpdateResourceBody struct {
 	SslData            *int     `json:"sslData,omitempty"`
 	SslEnabled         *bool    `json:"sslEnabled,omitempty"`
 	Options            *Options `json:"options,omitempty"`
+
+	// Extra represents fields the library doesn't model yet by their keys,
+	// they are sent as is.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler interface,
+// fields from Extra are added unless UpdateResourceBody has a field for them.
+func (u UpdateResourceBody) MarshalJSON() ([]byte, error) {
+	type updateResourceBody UpdateResourceBody
+
+	return marshalWithExtra(updateResourceBody(u), u.Extra)
 }
 
 // Update method updates resource by given body.
 func (s *ResourcesService) Update(ctx context.Context, resourceID int, body *UpdateResourceBody) (*Resource, *http.Response, error) {
+	if body == nil {
+		return nil, nil, errNilBody
+	}
+
+	if err := body.Options.Validate(); err != nil {
+		return nil, nil, err
+	}
+
 	req, err := s.client.NewRequest(ctx,
 		http.MethodPut,
 		fmt.Sprintf(resourceURL, resourceID), body)
@@ -82,21 +165,59 @@ func (s *ResourcesService) Update(ctx context.Context, resourceID int, body *Upd
 	return resource, resp, nil
 }
 
+// ListResourcesOpts represents list of additional options to filter and
+// paginate resources by.
+type ListResourcesOpts struct {
+	Cname       string `param:"cname,omitempty"`
+	Status      string `param:"status,omitempty"`
+	Deleted     bool   `param:"deleted,omitempty"`
+	OriginGroup int    `param:"originGroup,omitempty"`
+
+	// Ordering represents field to sort the resources by, "-" prefix
+	// reverses the order, e.g. "-created".
+	Ordering string `param:"ordering,omitempty"`
+
+	// Fields represents fields of the resources returned by the API,
+	// all fields are returned if it's empty.
+	Fields []string `param:"fields,omitempty"`
+
+	Limit  int `param:"limit,omitempty"`
+	Offset int `param:"offset,omitempty"`
+}
+
 // List method returns all resources for this account.
 func (s *ResourcesService) List(ctx context.Context) ([]*Resource, *http.Response, error) {
-	req, err := s.client.NewRequest(ctx, http.MethodGet, resourcesURL, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	resources := make([]*Resource, 0)
+	return s.ListWithOpts(ctx, ListResourcesOpts{})
+}
 
-	resp, err := s.client.Do(req, &resources)
+// ListWithOpts method returns resources of the account filtered by given
+// opts. All resources are returned unless opts.Limit is set.
+func (s *ResourcesService) ListWithOpts(ctx context.Context, opts ListResourcesOpts) ([]*Resource, *http.Response, error) {
+	page, resp, err := s.ListPage(ctx, opts)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	return resources, resp, nil
+	return page.Results, resp, nil
+}
+
+// ListPage method returns page of the resources filtered by given opts
+// along with total number of the matching resources.
+func (s *ResourcesService) ListPage(ctx context.Context, opts ListResourcesOpts) (*Page[*Resource], *http.Response, error) {
+	return listPage[*Resource](ctx, s.client, resourcesURL, opts, opts.Limit, opts.Offset)
+}
+
+// ListAll method returns iterator over all resources filtered by given opts,
+// the pages of opts.Limit or DefaultPageSize resources are requested as
+// the iteration goes.
+func (s *ResourcesService) ListAll(ctx context.Context, opts ListResourcesOpts) iter.Seq2[*Resource, error] {
+	return listAll(ctx, opts.Limit, opts.Offset, func(ctx context.Context, limit, offset int) (*Page[*Resource], error) {
+		pageOpts := opts
+		pageOpts.Limit, pageOpts.Offset = limit, offset
+		page, _, err := s.ListPage(ctx, pageOpts)
+
+		return page, err
+	})
 }
 
 // Get method returns resource by given resourceID.
@@ -120,6 +241,14 @@ func (s *ResourcesService) Get(ctx context.Context, resourceID int) (*Resource,
 
 // Create method creates resource.
 func (s *ResourcesService) Create(ctx context.Context, body *CreateResourceBody) (*Resource, *http.Response, error) {
+	if body == nil {
+		return nil, nil, errNilBody
+	}
+
+	if err := body.Options.Validate(); err != nil {
+		return nil, nil, err
+	}
+
 	req, err := s.client.NewRequest(ctx, http.MethodPost, resourcesURL, body)
 	if err != nil {
 		return nil, nil, err
@@ -137,6 +266,7 @@ func (s *ResourcesService) Create(ctx context.Context, body *CreateResourceBody)
 
 // Purge method deletes cache from CDN servers for given paths.
 // If `paths` is empty - purges all cache.
+// Use CreatePurge to purge exact URLs and to track the purge status.
 func (s *ResourcesService) Purge(ctx context.Context, resourceID int, paths []string) (*http.Response, error) {
 	var pathsBody struct {
 		Paths []string `json:"paths"`
@@ -180,3 +310,150 @@ func (s *ResourcesService) Prefetch(ctx context.Context, resourceID int, paths [
 
 	return resp, nil
 }
+
+// Delete method deletes resource by given resourceID.
+func (s *ResourcesService) Delete(ctx context.Context, resourceID int) (*http.Response, error) {
+	req, err := s.client.NewRequest(ctx,
+		http.MethodDelete,
+		fmt.Sprintf(resourceURL, resourceID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// Suspend method stops delivering content of the resource by given resourceID.
+func (s *ResourcesService) Suspend(ctx context.Context, resourceID int) (*Resource, *http.Response, error) {
+	return s.setActive(ctx, resourceID, false)
+}
+
+// Activate method resumes delivering content of the resource by given resourceID.
+func (s *ResourcesService) Activate(ctx context.Context, resourceID int) (*Resource, *http.Response, error) {
+	return s.setActive(ctx, resourceID, true)
+}
+
+// setActive method updates Active flag of the resource. The resource is
+// updated with its current settings, so they aren't reset by the update.
+func (s *ResourcesService) setActive(ctx context.Context, resourceID int, active bool) (*Resource, *http.Response, error) {
+	resource, resp, err := s.Get(ctx, resourceID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	body := resource.UpdateBody()
+	body.Active = &active
+	// Options aren't sent, so they stay unchanged.
+	body.Options = nil
+
+	return s.Update(ctx, resourceID, body)
+}
+
+// WaitOption configures WaitForStatus.
+type WaitOption func(*waitOptions)
+
+// waitOptions represents configuration of WaitForStatus.
+type waitOptions struct {
+	pollInterval time.Duration
+	timeout      time.Duration
+}
+
+// newWaitOptions returns a reference to waitOptions configured with
+// given options.
+func newWaitOptions(opts ...WaitOption) *waitOptions {
+	o := &waitOptions{
+		pollInterval: defaultPollInterval,
+		timeout:      defaultWaitTimeout,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	// time.NewTicker panics on non-positive intervals.
+	if o.pollInterval <= 0 {
+		o.pollInterval = defaultPollInterval
+	}
+
+	return o
+}
+
+// WithPollInterval sets interval between resource status checks,
+// 5 seconds are used by default and if the interval isn't positive.
+func WithPollInterval(interval time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.pollInterval = interval
+	}
+}
+
+// WithWaitTimeout sets maximum time to wait for the status,
+// 10 minutes are used by default. Zero timeout means no timeout besides
+// the context one.
+func WithWaitTimeout(timeout time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WaitForStatus method polls resource by given resourceID until its Status
+// becomes the given one and returns the resource. It fails if the resource
+// can't be got or the timeout expires.
+func (s *ResourcesService) WaitForStatus(ctx context.Context, resourceID int, status string, opts ...WaitOption) (*Resource, error) {
+	return poll(ctx, newWaitOptions(opts...),
+		func(ctx context.Context) (*Resource, bool, error) {
+			resource, _, err := s.Get(ctx, resourceID)
+			if err != nil {
+				return nil, false, err
+			}
+
+			return resource, resource.Status == status, nil
+		},
+		func(resource *Resource, err error) error {
+			if resource == nil {
+				return fmt.Errorf("gcore: resource %d hasn't got %q status: %w", resourceID, status, err)
+			}
+
+			return fmt.Errorf("gcore: resource %d has %q status instead of %q: %w",
+				resourceID, resource.Status, status, err)
+		})
+}
+
+// poll calls check with the configured interval until it reports that
+// the wait is done or returns an error, the last checked value is returned
+// along with the error. If the timeout or the context expires first, even
+// during a check, the error is built by expired from the last successfully
+// checked value and the context error. The value is zero if no check has
+// succeeded yet.
+func poll[T any](ctx context.Context, o *waitOptions,
+	check func(ctx context.Context) (T, bool, error), expired func(last T, err error) error) (T, error) {
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(o.pollInterval)
+	defer ticker.Stop()
+
+	var last T
+	for {
+		value, done, err := check(ctx)
+		if err != nil && ctx.Err() != nil {
+			return last, expired(last, ctx.Err())
+		}
+		if err != nil || done {
+			return value, err
+		}
+		last = value
+
+		select {
+		case <-ctx.Done():
+			return last, expired(last, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,9 +2,12 @@ package gcore
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -267,6 +270,15 @@ const (
 	testPrefetchResourceCacheRawRequest = `{"paths":["/path/*.css", "/path/*.js"]}`
 )
 
+// testResourceExtra represents fields of the resource fixtures
+// that Resource doesn't model.
+var testResourceExtra = map[string]json.RawMessage{
+	"logTarget":        json.RawMessage(`""`),
+	"preset_applied":   json.RawMessage(`false`),
+	"shieldDatacenter": json.RawMessage(`""`),
+	"shielded":         json.RawMessage(`false`),
+}
+
 var (
 	testGetResourceExpected = &Resource{
 		ID:                 4478,
@@ -319,6 +331,7 @@ var (
 		SslData:    IntPtr(1189),
 		CreatedAt:  NewTime(time.Date(2018, time.April, 9, 11, 31, 40, 0, time.UTC)),
 		UpdatedAt:  NewTime(time.Date(2018, time.April, 9, 11, 32, 31, 0, time.UTC)),
+		Extra:      testResourceExtra,
 	}
 
 	testListResourcesExpected = []*Resource{{
@@ -372,6 +385,7 @@ var (
 		SslData:    IntPtr(1189),
 		CreatedAt:  NewTime(time.Date(2018, time.April, 9, 11, 31, 40, 0, time.UTC)),
 		UpdatedAt:  NewTime(time.Date(2018, time.April, 9, 11, 32, 31, 0, time.UTC)),
+		Extra:      testResourceExtra,
 	}}
 
 	testCreateResourceExpected = &Resource{
@@ -425,6 +439,7 @@ var (
 		SslData:    IntPtr(1189),
 		CreatedAt:  NewTime(time.Date(2018, time.April, 9, 11, 31, 40, 0, time.UTC)),
 		UpdatedAt:  NewTime(time.Date(2018, time.April, 9, 11, 32, 31, 0, time.UTC)),
+		Extra:      testResourceExtra,
 	}
 
 	testUpdateResourceExpected = &Resource{
@@ -478,6 +493,7 @@ var (
 		SslData:    IntPtr(1189),
 		CreatedAt:  NewTime(time.Date(2018, time.April, 9, 11, 31, 40, 0, time.UTC)),
 		UpdatedAt:  NewTime(time.Date(2018, time.April, 9, 11, 32, 31, 0, time.UTC)),
+		Extra:      testResourceExtra,
 	}
 )
 
@@ -601,6 +617,98 @@ func TestResourcesService_List(t *testing.T) {
 	}
 }
 
+func TestResourcesService_ListWithOpts(t *testing.T) {
+	endpointCalled := false
+
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	handleOpts := &th.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         resourcesURL,
+		RawResponse: testListResourcesRawResponse,
+		QueryParams: map[string]string{"cname": "cdn.site.com"},
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    &endpointCalled,
+	}
+
+	th.HandleReqWithoutBody(t, handleOpts)
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	got, _, err := client.Resources.ListWithOpts(context.Background(), ListResourcesOpts{Cname: "cdn.site.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !endpointCalled {
+		t.Fatal("didn't get a list of resources")
+	}
+
+	if !reflect.DeepEqual(got, testListResourcesExpected) {
+		t.Errorf("Expected: %+v, got %+v\n", testListResourcesExpected, got)
+	}
+}
+
+func TestResourcesService_ListPage(t *testing.T) {
+	endpointCalled := false
+
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	handleOpts := &th.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         resourcesURL,
+		RawResponse: `{"count": 10, "results": ` + testListResourcesRawResponse + `}`,
+		QueryParams: map[string]string{
+			"cname":       "cdn.site.com",
+			"status":      ResourceStatusActive,
+			"originGroup": "132",
+			"ordering":    "-created",
+			"fields":      "id,cname",
+			"limit":       "1",
+			"offset":      "2",
+		},
+		Method:   http.MethodGet,
+		Status:   http.StatusOK,
+		CallFlag: &endpointCalled,
+	}
+
+	th.HandleReqWithoutBody(t, handleOpts)
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	got, _, err := client.Resources.ListPage(context.Background(), ListResourcesOpts{
+		Cname:       "cdn.site.com",
+		Status:      ResourceStatusActive,
+		OriginGroup: 132,
+		Ordering:    "-created",
+		Fields:      []string{"id", "cname"},
+		Limit:       1,
+		Offset:      2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !endpointCalled {
+		t.Fatal("didn't get a page of resources")
+	}
+
+	expected := &Page[*Resource]{Count: 10, Results: testListResourcesExpected, Limit: 1, Offset: 2}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected: %+v, got %+v\n", expected, got)
+	}
+	if !got.HasNext() || got.NextOffset() != 2+len(testListResourcesExpected) {
+		t.Errorf("expected the next page at %d", 2+len(testListResourcesExpected))
+	}
+}
+
 func TestResourcesService_Prefetch(t *testing.T) {
 	endpointCalled := false
 
@@ -714,3 +822,213 @@ func TestResourcesService_Update(t *testing.T) {
 		t.Errorf("Expected: %+v, got %+v\n", expected, got)
 	}
 }
+
+func TestResourcesService_Delete(t *testing.T) {
+	endpointCalled := false
+
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	handleOpts := &th.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         fmt.Sprintf(resourceURL, testGetResourceExpected.ID),
+		RawResponse: "",
+		Method:      http.MethodDelete,
+		Status:      http.StatusNoContent,
+		CallFlag:    &endpointCalled,
+	}
+
+	th.HandleReqWithoutBody(t, handleOpts)
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	_, err := client.Resources.Delete(context.Background(), testGetResourceExpected.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !endpointCalled {
+		t.Fatal("didn't delete resource")
+	}
+}
+
+func TestResourcesService_Suspend(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	var updateBody map[string]interface{}
+	testEnv.Mux.HandleFunc(fmt.Sprintf(resourceURL, testGetResourceExpected.ID), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, testGetResourceRawResponse)
+		case http.MethodPut:
+			if err := json.NewDecoder(r.Body).Decode(&updateBody); err != nil {
+				t.Errorf("unable to decode the request body: %v", err)
+			}
+			fmt.Fprint(w, strings.Replace(testGetResourceRawResponse,
+				`"active": true`, `"active": false`, 1))
+		default:
+			t.Errorf("unexpected %s request", r.Method)
+		}
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	got, _, err := client.Resources.Suspend(context.Background(), testGetResourceExpected.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Active {
+		t.Error("resource is still active")
+	}
+
+	if updateBody["active"] != false {
+		t.Errorf("Expected: active=false, got %v", updateBody["active"])
+	}
+
+	if !reflect.DeepEqual(updateBody["secondaryHostnames"], []interface{}{}) {
+		t.Errorf("secondary hostnames haven't been preserved: %v", updateBody["secondaryHostnames"])
+	}
+
+	if updateBody["originGroup"] != float64(testGetResourceExpected.OriginGroup) {
+		t.Errorf("origin group hasn't been preserved: %v", updateBody["originGroup"])
+	}
+
+	if _, ok := updateBody["shielded"]; !ok {
+		t.Errorf("unknown fields haven't been preserved: %v", updateBody)
+	}
+
+	if _, ok := updateBody["options"]; ok {
+		t.Errorf("options have been sent: %v", updateBody["options"])
+	}
+}
+
+func TestResourcesService_WaitForStatus(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	calls := 0
+	testEnv.Mux.HandleFunc(fmt.Sprintf(resourceURL, testGetResourceExpected.ID), func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Add("Content-Type", "application/json")
+
+		if calls < 3 {
+			fmt.Fprint(w, strings.Replace(testGetResourceRawResponse,
+				`"status": "active"`, `"status": "processed"`, 1))
+			return
+		}
+		fmt.Fprint(w, testGetResourceRawResponse)
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	got, err := client.Resources.WaitForStatus(context.Background(), testGetResourceExpected.ID,
+		ResourceStatusActive, WithPollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Status != ResourceStatusActive {
+		t.Errorf("Expected: %s, got %s", ResourceStatusActive, got.Status)
+	}
+
+	if calls != 3 {
+		t.Errorf("Expected: %d calls, got %d", 3, calls)
+	}
+}
+
+func TestResourcesService_WaitForStatus_TimeoutDuringCheck(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	calls := 0
+	testEnv.Mux.HandleFunc(fmt.Sprintf(resourceURL, testGetResourceExpected.ID), func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls > 1 {
+			<-r.Context().Done()
+			return
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, testGetResourceRawResponse)
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	got, err := client.Resources.WaitForStatus(context.Background(), testGetResourceExpected.ID,
+		ResourceStatusSuspended, WithPollInterval(time.Millisecond), WithWaitTimeout(50*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected: %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	if !strings.Contains(err.Error(), "instead of") {
+		t.Errorf("Expected the wait error, got %v", err)
+	}
+
+	if got == nil || got.Status != ResourceStatusActive {
+		t.Errorf("Expected the last checked resource, got %+v", got)
+	}
+}
+
+func TestNewWaitOptions_NonPositivePollInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if o := newWaitOptions(WithPollInterval(interval)); o.pollInterval != defaultPollInterval {
+			t.Errorf("Expected: %v, got %v", defaultPollInterval, o.pollInterval)
+		}
+	}
+}
+
+func TestResourcesService_WaitForStatus_ZeroPollInterval(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testEnv.Mux.HandleFunc(fmt.Sprintf(resourceURL, testGetResourceExpected.ID), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, testGetResourceRawResponse)
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	got, err := client.Resources.WaitForStatus(context.Background(), testGetResourceExpected.ID,
+		ResourceStatusActive, WithPollInterval(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Status != ResourceStatusActive {
+		t.Errorf("Expected: %s, got %s", ResourceStatusActive, got.Status)
+	}
+}
+
+func TestResourcesService_WaitForStatus_Timeout(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testEnv.Mux.HandleFunc(fmt.Sprintf(resourceURL, testGetResourceExpected.ID), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, testGetResourceRawResponse)
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	_, err := client.Resources.WaitForStatus(context.Background(), testGetResourceExpected.ID,
+		ResourceStatusSuspended, WithPollInterval(time.Millisecond), WithWaitTimeout(20*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected: %v, got %v", context.DeadlineExceeded, err)
+	}
+}
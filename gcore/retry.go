@@ -0,0 +1,214 @@
+package gcore
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultRetryMaxAttempts represents default number of attempts
+	// (including the first one) to execute a request.
+	defaultRetryMaxAttempts = 3
+
+	// defaultRetryBaseDelay represents default delay before the first retry.
+	defaultRetryBaseDelay = 500 * time.Millisecond
+
+	// defaultRetryMaxDelay represents default maximum delay between retries.
+	defaultRetryMaxDelay = 30 * time.Second
+)
+
+// RetryPolicy represents configuration of the retries that Client applies
+// to failed requests. Client doesn't retry requests if the policy isn't set.
+type RetryPolicy struct {
+	// MaxAttempts represents maximum number of attempts to execute a request
+	// including the first one.
+	MaxAttempts int
+
+	// BaseDelay represents delay before the first retry, every next delay
+	// is doubled.
+	BaseDelay time.Duration
+
+	// MaxDelay limits delay between retries including the one that is
+	// requested by the server via Retry-After header.
+	MaxDelay time.Duration
+
+	// Jitter enables randomization of delays to avoid synchronized retries
+	// of many clients.
+	Jitter bool
+
+	// RetryableStatusCodes represents list of response status codes that
+	// should be retried.
+	RetryableStatusCodes []int
+
+	// RetryNonIdempotent allows retrying of non-idempotent requests
+	// such as POST and PATCH.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a reference to a retry policy that retries
+// idempotent requests on network errors, 429, 502, 503 and 504 responses.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: defaultRetryMaxAttempts,
+		BaseDelay:   defaultRetryBaseDelay,
+		MaxDelay:    defaultRetryMaxDelay,
+		Jitter:      true,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// allowsMethod checks if requests with given method can be retried.
+func (p *RetryPolicy) allowsMethod(method string) bool {
+	if p.RetryNonIdempotent {
+		return true
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions,
+		http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// shouldRetry checks if request that finished with given error should be
+// retried.
+func (p *RetryPolicy) shouldRetry(req *http.Request, err error) bool {
+	if err == nil || req.Context().Err() != nil {
+		return false
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		// Network errors like connection resets.
+		return true
+	}
+
+	for _, code := range p.RetryableStatusCodes {
+		if apiErr.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// delay returns time to wait before the next attempt.
+func (p *RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return p.limit(d)
+		}
+	}
+
+	d := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	d = p.limit(d)
+
+	if p.Jitter && d > 0 {
+		half := d / 2
+		d = half + time.Duration(rand.Int63n(int64(d-half)+1))
+	}
+
+	return d
+}
+
+// limit caps given delay by MaxDelay.
+func (p *RetryPolicy) limit(d time.Duration) time.Duration {
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		return p.MaxDelay
+	}
+
+	return d
+}
+
+// parseRetryAfter parses Retry-After header value which can be either
+// a number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		d := time.Until(date)
+		if d < 0 {
+			d = 0
+		}
+
+		return d, true
+	}
+
+	return 0, false
+}
+
+// doWithRetry executes request and retries it according to the client's
+// retry policy.
+func (c *Client) doWithRetry(req *http.Request) (*http.Response, error) {
+	policy := c.RetryPolicy
+	if policy == nil || policy.MaxAttempts <= 1 || !policy.allowsMethod(req.Method) {
+		return c.sendWithAuth(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			// Replay the buffered JSON body built by NewRequest.
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := c.sendWithAuth(req)
+		if attempt >= policy.MaxAttempts || !policy.shouldRetry(req, err) {
+			return resp, err
+		}
+
+		if info := CallInfoFromContext(req.Context()); info != nil {
+			info.Retries++
+		}
+
+		delay := policy.delay(attempt, resp)
+		c.logger.LogAttrs(req.Context(), slog.LevelDebug, "gcore: retrying request",
+			slog.String("method", req.Method),
+			slog.String("path", req.URL.Path),
+			slog.Int("attempt", attempt),
+			slog.Duration("delay", delay),
+			slog.Any("error", err))
+
+		if resp != nil && resp.Body != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	th "github.com/dstdfx/go-gcore/gcore/internal/testhelper"
+)
+
+// testRetryPolicy returns retry policy with tiny delays.
+func testRetryPolicy() *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 5 * time.Millisecond
+
+	return policy
+}
+
+func TestClient_Do_RetryIdempotent(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	calls := 0
+	testEnv.Mux.HandleFunc(fmt.Sprintf(resourceURL, testGetResourceExpected.ID),
+		func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Add("Content-Type", "application/json")
+			if calls < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, testGetResourceRawResponse)
+		})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	client.RetryPolicy = testRetryPolicy()
+
+	got, _, err := client.Resources.Get(context.Background(), testGetResourceExpected.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 3 {
+		t.Errorf("Expected: %d calls, got %d", 3, calls)
+	}
+
+	if got.ID != testGetResourceExpected.ID {
+		t.Errorf("Expected: %d, got %d", testGetResourceExpected.ID, got.ID)
+	}
+}
+
+func TestClient_Do_RetryExhausted(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	calls := 0
+	testEnv.Mux.HandleFunc(resourcesURL, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	client.RetryPolicy = testRetryPolicy()
+
+	_, _, err := client.Resources.List(context.Background())
+	if !hasStatus(err, http.StatusBadGateway) {
+		t.Fatalf("expected 502 error, got %v", err)
+	}
+
+	if calls != client.RetryPolicy.MaxAttempts {
+		t.Errorf("Expected: %d calls, got %d", client.RetryPolicy.MaxAttempts, calls)
+	}
+}
+
+func TestClient_Do_NoRetryNonIdempotent(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	calls := 0
+	testEnv.Mux.HandleFunc(resourcesURL, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	client.RetryPolicy = testRetryPolicy()
+
+	_, _, err := client.Resources.Create(context.Background(), &CreateResourceBody{Cname: "cdn.site.com"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected: %d calls, got %d", 1, calls)
+	}
+}
+
+func TestClient_Do_RetryNonIdempotentReplaysBody(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	calls := 0
+	testEnv.Mux.HandleFunc(resourcesURL, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != "{\"cname\":\"cdn.site.com\"}\n" {
+			t.Errorf("unexpected body on attempt %d: %q", calls, body)
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		if calls == 1 {
+			w.Header().Add("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, testCreateResourceRawResponse)
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	client.RetryPolicy = testRetryPolicy()
+	client.RetryPolicy.RetryNonIdempotent = true
+
+	_, _, err := client.Resources.Create(context.Background(), &CreateResourceBody{Cname: "cdn.site.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected: %d calls, got %d", 2, calls)
+	}
+}
+
+func TestClient_Do_RetryContextCanceled(t *testing.T) {
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testEnv.Mux.HandleFunc(resourcesURL, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	client.RetryPolicy = testRetryPolicy()
+	client.RetryPolicy.MaxDelay = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, _, err := client.Resources.List(ctx)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("Expected: %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := &RetryPolicy{
+		BaseDelay: 100 * time.Millisecond,
+		MaxDelay:  time.Second,
+	}
+
+	testCases := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{5, time.Second},
+		{100, time.Second},
+	}
+
+	for _, tc := range testCases {
+		if got := policy.delay(tc.attempt, nil); got != tc.expected {
+			t.Errorf("attempt %d: Expected: %v, got %v", tc.attempt, tc.expected, got)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "3")
+	if got := policy.delay(1, resp); got != time.Second {
+		t.Errorf("Expected Retry-After to be limited by MaxDelay, got %v", got)
+	}
+
+	policy.Jitter = true
+	for i := 0; i < 100; i++ {
+		got := policy.delay(2, nil)
+		if got < 100*time.Millisecond || got > 200*time.Millisecond {
+			t.Fatalf("jittered delay %v is out of range", got)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter("120"); !ok || d != 2*time.Minute {
+		t.Errorf("Expected: %v, got %v", 2*time.Minute, d)
+	}
+
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if d, ok := parseRetryAfter(date); !ok || d < 59*time.Minute || d > time.Hour {
+		t.Errorf("unexpected delay %v for date %s", d, date)
+	}
+
+	if _, ok := parseRetryAfter("whatever"); ok {
+		t.Error("expected invalid value to be ignored")
+	}
+}
@@ -1,7 +1,9 @@
 package gcore
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -26,6 +28,34 @@ type Rule struct {
 	Weight         int     `json:"weight"`
 	PresetApplied  bool    `json:"preset_applied"`
 	OriginProtocol string  `json:"originProtocol"`
+
+	// Extra represents fields the library doesn't model yet by their keys.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface,
+// unknown fields are stored in Extra.
+func (r *Rule) UnmarshalJSON(b []byte) error {
+	type rule Rule
+
+	var alias rule
+	extra, err := unmarshalWithExtra(b, &alias)
+	if err != nil {
+		return err
+	}
+
+	*r = Rule(alias)
+	r.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface,
+// fields from Extra are added unless Rule has a field for them.
+func (r Rule) MarshalJSON() ([]byte, error) {
+	type rule Rule
+
+	return marshalWithExtra(rule(r), r.Extra)
 }
 
 // Options represent possible params for a Rule.
@@ -52,6 +82,82 @@ type Options struct {
 	Stale                *Stale                `json:"stale"`
 	StaticHeaders        *StaticHeaders        `json:"staticHeaders"`
 	UserAgentACL         *UserAgentACL         `json:"user_agent_acl"`
+
+	BrotliCompression           *BrotliCompression           `json:"brotli_compression,omitempty"`
+	BrowserCacheSettings        *BrowserCacheSettings        `json:"browser_cache_settings,omitempty"`
+	DisableProxyForceRanges     *DisableProxyForceRanges     `json:"disable_proxy_force_ranges,omitempty"`
+	EdgeCacheSettings           *EdgeCacheSettings           `json:"edge_cache_settings,omitempty"`
+	FollowOriginRedirect        *FollowOriginRedirect        `json:"follow_origin_redirect,omitempty"`
+	HTTP3Enabled                *HTTP3Enabled                `json:"http3_enabled,omitempty"`
+	ImageStack                  *ImageStack                  `json:"image_stack,omitempty"`
+	LimitBandwidth              *LimitBandwidth              `json:"limit_bandwidth,omitempty"`
+	ProxyConnectTimeout         *ProxyConnectTimeout         `json:"proxy_connect_timeout,omitempty"`
+	ProxyReadTimeout            *ProxyReadTimeout            `json:"proxy_read_timeout,omitempty"`
+	QueryParamsBlacklist        *QueryParamsBlacklist        `json:"query_params_blacklist,omitempty"`
+	QueryParamsWhitelist        *QueryParamsWhitelist        `json:"query_params_whitelist,omitempty"`
+	RedirectHTTPSToHTTP         *RedirectHTTPSToHTTP         `json:"redirect_https_to_http,omitempty"`
+	RedirectHTTPToHTTPS         *RedirectHTTPToHTTPS         `json:"redirect_http_to_https,omitempty"`
+	RequestLimiter              *RequestLimiter              `json:"request_limiter,omitempty"`
+	ResponseHeadersHidingPolicy *ResponseHeadersHidingPolicy `json:"response_headers_hiding_policy,omitempty"`
+	SNI                         *SNI                         `json:"sni,omitempty"`
+	StaticRequestHeaders        *StaticRequestHeaders        `json:"static_request_headers,omitempty"`
+	TLSVersions                 *TLSVersions                 `json:"tls_versions,omitempty"`
+	UseDefaultLEChain           *UseDefaultLEChain           `json:"use_default_le_chain,omitempty"`
+	WebSockets                  *WebSockets                  `json:"websockets,omitempty"`
+
+	// Extra represents options the library doesn't model yet by their keys.
+	// They are kept verbatim, so updating a resource or a rule got from
+	// the API doesn't drop them.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface,
+// unknown options are stored in Extra.
+func (o *Options) UnmarshalJSON(b []byte) error {
+	type options Options
+
+	var alias options
+	extra, err := unmarshalWithExtra(b, &alias)
+	if err != nil {
+		return err
+	}
+
+	*o = Options(alias)
+	o.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface, options from Extra
+// are added unless Options has a field for them.
+func (o Options) MarshalJSON() ([]byte, error) {
+	type options Options
+
+	return marshalWithExtra(options(o), o.Extra)
+}
+
+// UpdateBody returns request body that updates the rule to its current
+// state including the fields kept in Extra.
+func (r *Rule) UpdateBody() *UpdateRuleBody {
+	rule, name, ruleType, weight := r.Rule, r.Name, r.RuleType, r.Weight
+	originGroup, originProtocol, options := r.OriginGroup, r.OriginProtocol, r.Options
+
+	body := &UpdateRuleBody{
+		Rule:     &rule,
+		Name:     &name,
+		RuleType: &ruleType,
+		Weight:   &weight,
+		Options:  &options,
+		Extra:    r.Extra,
+	}
+	if originGroup != 0 {
+		body.OriginGroup = &originGroup
+	}
+	if originProtocol != "" {
+		body.OriginProtocol = &originProtocol
+	}
+
+	return body
 }
 
 // CreateRuleBody represents request body for rule create.
@@ -62,6 +168,52 @@ type CreateRuleBody struct {
 	Options  Options `json:"options"`
 }
 
+// UpdateRuleBody represents request body for rule update and patch.
+// Fields and options that are nil aren't sent, so they stay unchanged.
+type UpdateRuleBody struct {
+	Rule           *string  `json:"rule,omitempty"`
+	Name           *string  `json:"name,omitempty"`
+	RuleType       *int     `json:"ruleType,omitempty"`
+	Weight         *int     `json:"weight,omitempty"`
+	OriginGroup    *int     `json:"originGroup,omitempty"`
+	OriginProtocol *string  `json:"originProtocol,omitempty"`
+	Options        *Options `json:"options,omitempty"`
+
+	// Extra represents fields the library doesn't model yet by their keys,
+	// they are sent as is.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler interface, nil options are omitted
+// and fields from Extra are added.
+func (b UpdateRuleBody) MarshalJSON() ([]byte, error) {
+	type updateRuleBody UpdateRuleBody
+
+	body := struct {
+		updateRuleBody
+		Options map[string]json.RawMessage `json:"options,omitempty"`
+	}{updateRuleBody: updateRuleBody(b)}
+
+	if b.Options != nil {
+		rawOptions, err := json.Marshal(b.Options)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = json.Unmarshal(rawOptions, &body.Options); err != nil {
+			return nil, err
+		}
+
+		for name, value := range body.Options {
+			if bytes.Equal(value, []byte("null")) {
+				delete(body.Options, name)
+			}
+		}
+	}
+
+	return marshalWithExtra(body, b.Extra)
+}
+
 // CacheHTTPHeaders is list HTTP Headers that must be included in the response.
 type CacheHTTPHeaders struct {
 	Enabled bool     `json:"enabled"`
@@ -108,7 +260,17 @@ type CORS struct {
 	Value   []string `json:"value"`
 }
 
+// The list of the possible values for the policy type of the ACL options.
+const (
+	// PolicyTypeAllow allows access to everyone except the excepted values.
+	PolicyTypeAllow = "allow"
+
+	// PolicyTypeDeny denies access to everyone except the excepted values.
+	PolicyTypeDeny = "deny"
+)
+
 // CountryACL specifies control access to the content for specified countries.
+// ExceptedValues are ISO 3166-1 alpha-2 country codes.
 type CountryACL struct {
 	Enabled        bool     `json:"enabled"`
 	ExceptedValues []string `json:"excepted_values"`
@@ -176,6 +338,7 @@ type IgnoreCookie struct {
 // you need to independently monitor its relevance.
 // We recommend you use a script for automatically update IP ACL.
 // Read more https://docs.gcorelabs.com/cdn/#operation--public-ip-list-get.
+// ExceptedValues are IP networks in CIDR notation, e.g. "192.168.1.0/24".
 type IPAddressACL struct {
 	Enabled        bool     `json:"enabled"`
 	ExceptedValues []string `json:"excepted_values"`
@@ -204,6 +367,14 @@ type ReferrerACL struct {
 	PolicyType     string   `json:"policy_type"`
 }
 
+// The list of the possible values for the Rewrite option flag.
+const (
+	RewriteFlagBreak     = "break"
+	RewriteFlagLast      = "last"
+	RewriteFlagRedirect  = "redirect"
+	RewriteFlagPermanent = "permanent"
+)
+
 // Rewrite option. The pattern for Rewrite. At least one group should be specified.
 // For Example: /rewrite_from/(.*) /rewrite_to/$1
 // Read more about Rewrite option here http://nginx.org/en/docs/http/ngx_http_rewrite_module.html#rewrite.
@@ -213,6 +384,15 @@ type Rewrite struct {
 	Flag    string `json:"flag"`
 }
 
+// The list of the possible values for the SecureKey option type.
+const (
+	// SecureKeyTypeWithIP includes client's IP address into the token.
+	SecureKeyTypeWithIP = 0
+
+	// SecureKeyTypeWithoutIP doesn't include client's IP address into the token.
+	SecureKeyTypeWithoutIP = 2
+)
+
 // SecureKey option allows configuring an access with tokenized URLs.
 // It makes impossible to access content without a valid (unexpired) hash key.
 // When enabled you need to specify a key that you use to generate a token.
@@ -267,6 +447,222 @@ type UserAgentACL struct {
 	PolicyType     string   `json:"policy_type"`
 }
 
+// BrotliCompression option compresses content of given MIME types with Brotli
+// on the CDN's end. Your origin must not compress the content itself.
+type BrotliCompression struct {
+	Enabled bool     `json:"enabled"`
+	Value   []string `json:"value"`
+}
+
+// BrowserCacheSettings specifies cache expiration time for the end user's
+// browser, e.g. "3600s" or "1d".
+type BrowserCacheSettings struct {
+	Enabled bool   `json:"enabled"`
+	Value   string `json:"value"`
+}
+
+// DisableProxyForceRanges option disables 206 responses for the requests
+// with Range header when the origin doesn't support ranges.
+type DisableProxyForceRanges struct {
+	Enabled bool `json:"enabled"`
+	Value   bool `json:"value"`
+}
+
+// EdgeCacheSettings specifies cache expiration time on the CDN servers.
+// Value is used for 200, 206, 301 and 302 responses, CustomValues maps
+// status codes (or "any") to their expiration time and Default is used if
+// the origin doesn't send caching headers.
+type EdgeCacheSettings struct {
+	Enabled      bool              `json:"enabled"`
+	Value        string            `json:"value,omitempty"`
+	CustomValues map[string]string `json:"custom_values,omitempty"`
+	Default      string            `json:"default,omitempty"`
+}
+
+// FollowOriginRedirect option makes CDN servers follow the origin redirects
+// with given status codes and cache the final response.
+type FollowOriginRedirect struct {
+	Enabled bool  `json:"enabled"`
+	Codes   []int `json:"codes"`
+}
+
+// HTTP3Enabled option enables HTTP/3 protocol for the end users.
+type HTTP3Enabled struct {
+	Enabled bool `json:"enabled"`
+	Value   bool `json:"value"`
+}
+
+// ImageStack option optimizes images on the CDN's end: converts them
+// to AVIF or WebP and compresses them with given quality.
+type ImageStack struct {
+	Enabled     bool `json:"enabled"`
+	AVIFEnabled bool `json:"avif_enabled"`
+	WebPEnabled bool `json:"webp_enabled"`
+	Quality     int  `json:"quality"`
+	PNGLossless bool `json:"png_lossless"`
+}
+
+// LimitBandwidthType represents the type for the LimitBandwidth option type.
+type LimitBandwidthType string
+
+// The list of the possible values for the LimitBandwidth option type.
+const (
+	LimitBandwidthStatic  LimitBandwidthType = "static"
+	LimitBandwidthDynamic LimitBandwidthType = "dynamic"
+)
+
+// LimitBandwidth option limits download speed per connection. Speed is in
+// KB/s and Buffer is the amount of KB downloaded without the limit.
+type LimitBandwidth struct {
+	Enabled   bool               `json:"enabled"`
+	LimitType LimitBandwidthType `json:"limit_type"`
+	Speed     int                `json:"speed,omitempty"`
+	Buffer    int                `json:"buffer,omitempty"`
+}
+
+// ProxyConnectTimeout specifies timeout of connection to the origin,
+// e.g. "4s".
+type ProxyConnectTimeout struct {
+	Enabled bool   `json:"enabled"`
+	Value   string `json:"value"`
+}
+
+// ProxyReadTimeout specifies timeout between two reads of the origin
+// response, e.g. "10s".
+type ProxyReadTimeout struct {
+	Enabled bool   `json:"enabled"`
+	Value   string `json:"value"`
+}
+
+// QueryParamsBlacklist specifies query parameters that are ignored
+// in the cache key. Not supported with ignoreQueryString and
+// query_params_whitelist options.
+type QueryParamsBlacklist struct {
+	Enabled bool     `json:"enabled"`
+	Value   []string `json:"value"`
+}
+
+// QueryParamsWhitelist specifies the only query parameters that are used
+// in the cache key. Not supported with ignoreQueryString and
+// query_params_blacklist options.
+type QueryParamsWhitelist struct {
+	Enabled bool     `json:"enabled"`
+	Value   []string `json:"value"`
+}
+
+// RedirectHTTPSToHTTP option redirects HTTPS requests to HTTP.
+// Not supported with redirect_http_to_https option.
+type RedirectHTTPSToHTTP struct {
+	Enabled bool `json:"enabled"`
+	Value   bool `json:"value"`
+}
+
+// RedirectHTTPToHTTPS option redirects HTTP requests to HTTPS.
+// Not supported with redirect_https_to_http option.
+type RedirectHTTPToHTTPS struct {
+	Enabled bool `json:"enabled"`
+	Value   bool `json:"value"`
+}
+
+// RequestLimiterRateUnit represents the type for the RequestLimiter option
+// rate unit.
+type RequestLimiterRateUnit string
+
+// The list of the possible values for the RequestLimiter option rate unit.
+const (
+	RequestLimiterPerSecond RequestLimiterRateUnit = "r/s"
+	RequestLimiterPerMinute RequestLimiterRateUnit = "r/m"
+)
+
+// RequestLimiter option limits rate of the requests from a single IP address.
+type RequestLimiter struct {
+	Enabled  bool                   `json:"enabled"`
+	Rate     int                    `json:"rate"`
+	Burst    int                    `json:"burst"`
+	RateUnit RequestLimiterRateUnit `json:"rate_unit,omitempty"`
+	Delay    int                    `json:"delay,omitempty"`
+}
+
+// HeadersHidingMode represents the type for the ResponseHeadersHidingPolicy
+// option mode.
+type HeadersHidingMode string
+
+// The list of the possible values for the ResponseHeadersHidingPolicy
+// option mode.
+const (
+	// HeadersHidingModeHide hides all headers except the excepted ones.
+	HeadersHidingModeHide HeadersHidingMode = "hide"
+
+	// HeadersHidingModeShow shows all headers except the excepted ones.
+	HeadersHidingModeShow HeadersHidingMode = "show"
+)
+
+// ResponseHeadersHidingPolicy option hides the origin response headers
+// from the end users.
+type ResponseHeadersHidingPolicy struct {
+	Enabled  bool              `json:"enabled"`
+	Mode     HeadersHidingMode `json:"mode"`
+	Excepted []string          `json:"excepted"`
+}
+
+// SNIType represents the type for the SNI option type.
+type SNIType string
+
+// The list of the possible values for the SNI option type.
+const (
+	// SNITypeDynamic uses value of the hostHeader option as SNI hostname.
+	SNITypeDynamic SNIType = "dynamic"
+
+	// SNITypeCustom uses CustomHostname as SNI hostname.
+	SNITypeCustom SNIType = "custom"
+)
+
+// SNI option specifies the hostname CDN servers send in TLS handshake
+// with an HTTPS origin.
+type SNI struct {
+	Enabled        bool    `json:"enabled"`
+	SNIType        SNIType `json:"sni_type"`
+	CustomHostname string  `json:"custom_hostname,omitempty"`
+}
+
+// StaticRequestHeaders specifies custom HTTP headers that CDN servers add
+// to the requests to the origin.
+type StaticRequestHeaders struct {
+	Enabled bool              `json:"enabled"`
+	Value   map[string]string `json:"value"`
+}
+
+// TLSVersion represents the type for the TLSVersions option value.
+type TLSVersion string
+
+// The list of the possible values for the TLSVersions option.
+const (
+	TLSVersionSSLv3 TLSVersion = "SSLv3"
+	TLSVersion10    TLSVersion = "TLSv1"
+	TLSVersion11    TLSVersion = "TLSv1.1"
+	TLSVersion12    TLSVersion = "TLSv1.2"
+	TLSVersion13    TLSVersion = "TLSv1.3"
+)
+
+// TLSVersions specifies TLS versions CDN servers accept from the end users.
+type TLSVersions struct {
+	Enabled bool         `json:"enabled"`
+	Value   []TLSVersion `json:"value"`
+}
+
+// UseDefaultLEChain option makes Let's Encrypt certificates use the default
+// certificate chain instead of the alternative one.
+type UseDefaultLEChain struct {
+	Enabled bool `json:"enabled"`
+	Value   bool `json:"value"`
+}
+
+// WebSockets option allows WebSocket connections to the origin.
+type WebSockets struct {
+	Enabled bool `json:"enabled"`
+	Value   bool `json:"value"`
+}
+
 // List method returns list of the rules for given resourceID.
 func (s *RulesService) List(ctx context.Context, resourceID int) ([]*Rule, *http.Response, error) {
 	req, err := s.client.NewRequest(ctx,
@@ -288,6 +684,14 @@ func (s *RulesService) List(ctx context.Context, resourceID int) ([]*Rule, *http
 
 // Create method creates rule for given resourceID.
 func (s *RulesService) Create(ctx context.Context, resourceID int, body *CreateRuleBody) (*Rule, *http.Response, error) {
+	if body == nil {
+		return nil, nil, errNilBody
+	}
+
+	if err := body.Options.Validate(); err != nil {
+		return nil, nil, err
+	}
+
 	req, err := s.client.NewRequest(ctx,
 		http.MethodPost,
 		fmt.Sprintf(rulesURL, resourceID), body)
@@ -340,3 +744,41 @@ func (s *RulesService) Delete(ctx context.Context, resourceID, ruleID int) (*htt
 
 	return resp, nil
 }
+
+// Update method replaces rule by given ruleID with given body.
+func (s *RulesService) Update(ctx context.Context, resourceID, ruleID int, body *UpdateRuleBody) (*Rule, *http.Response, error) {
+	return s.update(ctx, http.MethodPut, resourceID, ruleID, body)
+}
+
+// Patch method changes only the fields and options of the rule
+// that are set in given body.
+func (s *RulesService) Patch(ctx context.Context, resourceID, ruleID int, body *UpdateRuleBody) (*Rule, *http.Response, error) {
+	return s.update(ctx, http.MethodPatch, resourceID, ruleID, body)
+}
+
+// update method sends given body to the rule with given HTTP method.
+func (s *RulesService) update(ctx context.Context, method string, resourceID, ruleID int, body *UpdateRuleBody) (*Rule, *http.Response, error) {
+	if body == nil {
+		return nil, nil, errNilBody
+	}
+
+	if err := body.Options.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx,
+		method,
+		fmt.Sprintf(ruleURL, resourceID, ruleID), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rule := &Rule{}
+
+	resp, err := s.client.Do(req, rule)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rule, resp, nil
+}
@@ -2,6 +2,7 @@ package gcore
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -180,6 +181,35 @@ const (
       "staticHeaders":null,
       "user_agent_acl":null
    }
+}`
+	testUpdateRuleRawRequest = `{
+   "rule":"/images",
+   "name":"whatever",
+   "ruleType":0,
+   "weight":2,
+   "originProtocol":"HTTP",
+   "options":{
+      "cache_http_headers":{
+         "enabled":true,
+         "value":[
+            "content-length",
+            "x-token",
+            "connection",
+            "date",
+            "server",
+            "content-type"
+         ]
+      }
+   }
+}`
+	testPatchRuleRawRequest = `{
+   "weight":2,
+   "options":{
+      "gzipOn":{
+         "enabled":false,
+         "value":true
+      }
+   }
 }`
 )
 
@@ -387,3 +417,160 @@ func TestRulesService_List(t *testing.T) {
 		t.Errorf("Expected: %+v, got %+v\n", expected, got)
 	}
 }
+
+func TestRulesService_Update(t *testing.T) {
+	endpointCalled := false
+
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	handleOpts := &th.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         fmt.Sprintf(ruleURL, fakeResourceID, testGetRuleExpected.ID),
+		RawResponse: testGetRuleRawResponse,
+		RawRequest:  testUpdateRuleRawRequest,
+		Method:      http.MethodPut,
+		Status:      http.StatusOK,
+		CallFlag:    &endpointCalled,
+	}
+
+	th.HandleReqWithBody(t, handleOpts)
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	expected := testGetRuleExpected
+
+	rule, name, ruleType, weight, originProtocol := "/images", "whatever", 0, 2, "HTTP"
+	body := &UpdateRuleBody{
+		Rule:           &rule,
+		Name:           &name,
+		RuleType:       &ruleType,
+		Weight:         &weight,
+		OriginProtocol: &originProtocol,
+		Options:        &testGetRuleExpected.Options,
+	}
+
+	got, _, err := client.Rules.Update(context.Background(), fakeResourceID, testGetRuleExpected.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !endpointCalled {
+		t.Fatal("didn't update rule")
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected: %+v, got %+v\n", expected, got)
+	}
+}
+
+func TestRulesService_Patch(t *testing.T) {
+	endpointCalled := false
+
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	handleOpts := &th.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         fmt.Sprintf(ruleURL, fakeResourceID, testGetRuleExpected.ID),
+		RawResponse: testGetRuleRawResponse,
+		RawRequest:  testPatchRuleRawRequest,
+		Method:      http.MethodPatch,
+		Status:      http.StatusOK,
+		CallFlag:    &endpointCalled,
+	}
+
+	th.HandleReqWithBody(t, handleOpts)
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	expected := testGetRuleExpected
+
+	weight := 2
+	body := &UpdateRuleBody{
+		Weight: &weight,
+		Options: &Options{
+			GZIPOn: &GZIPOn{Enabled: false, Value: true},
+		},
+	}
+
+	got, _, err := client.Rules.Patch(context.Background(), fakeResourceID, testGetRuleExpected.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !endpointCalled {
+		t.Fatal("didn't patch rule")
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected: %+v, got %+v\n", expected, got)
+	}
+}
+
+func TestOptions_JSON(t *testing.T) {
+	raw := `{
+  "gzipOn": {"enabled": true, "value": true},
+  "edge_cache_settings": {"enabled": true, "value": "43200s", "custom_values": {"404": "10s"}},
+  "websockets": {"enabled": true, "value": true},
+  "tls_versions": {"enabled": true, "value": ["TLSv1.2", "TLSv1.3"]},
+  "sni": {"enabled": true, "sni_type": "custom", "custom_hostname": "origin.site.com"},
+  "fastedge": {"enabled": true, "on_request_headers": {"app_id": "42"}},
+  "waap": {"enabled": false, "value": false}
+}`
+
+	options := &Options{}
+	if err := json.Unmarshal([]byte(raw), options); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &Options{
+		GZIPOn: &GZIPOn{Enabled: true, Value: true},
+		EdgeCacheSettings: &EdgeCacheSettings{
+			Enabled:      true,
+			Value:        "43200s",
+			CustomValues: map[string]string{"404": "10s"},
+		},
+		WebSockets:  &WebSockets{Enabled: true, Value: true},
+		TLSVersions: &TLSVersions{Enabled: true, Value: []TLSVersion{TLSVersion12, TLSVersion13}},
+		SNI:         &SNI{Enabled: true, SNIType: SNITypeCustom, CustomHostname: "origin.site.com"},
+		Extra: map[string]json.RawMessage{
+			"fastedge": json.RawMessage(`{"enabled": true, "on_request_headers": {"app_id": "42"}}`),
+			"waap":     json.RawMessage(`{"enabled": false, "value": false}`),
+		},
+	}
+
+	if !reflect.DeepEqual(options, expected) {
+		t.Errorf("Expected: %+v, got %+v\n", expected, options)
+	}
+
+	b, err := json.Marshal(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]interface{}
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"gzipOn", "edge_cache_settings", "websockets", "tls_versions", "sni", "fastedge", "waap"} {
+		if got[key] == nil {
+			t.Errorf("%s option has been lost: %s", key, b)
+		}
+	}
+
+	// Options that are modeled but not set are sent as null for backward
+	// compatibility, the new ones are omitted.
+	if value, ok := got["cors"]; !ok || value != nil {
+		t.Errorf("Expected: cors=null, got %v", value)
+	}
+
+	if _, ok := got["image_stack"]; ok {
+		t.Errorf("unset image_stack option has been sent: %s", b)
+	}
+}
@@ -2,6 +2,7 @@ package gcore
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -24,12 +25,61 @@ type Service struct {
 	Enabled bool   `json:"enabled"`
 	Start   *Time  `json:"start"`
 	// TODO: add trial options, options
+	// Extra represents fields the library doesn't model yet by their keys.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface,
+// unknown fields are stored in Extra.
+func (s *Service) UnmarshalJSON(b []byte) error {
+	type service Service
+
+	var alias service
+	extra, err := unmarshalWithExtra(b, &alias)
+	if err != nil {
+		return err
+	}
+
+	*s = Service(alias)
+	s.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface,
+// fields from Extra are added unless Service has a field for them.
+func (s Service) MarshalJSON() ([]byte, error) {
+	type service Service
+
+	return marshalWithExtra(service(s), s.Extra)
 }
 
 // UpdateServiceBody represents request body for service updating.
 type UpdateServiceBody struct {
 	Enabled bool   `json:"enabled"`
 	Status  string `json:"status"`
+
+	// Extra represents fields the library doesn't model yet by their keys,
+	// they are sent as is.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler interface,
+// fields from Extra are added unless UpdateServiceBody has a field for them.
+func (u UpdateServiceBody) MarshalJSON() ([]byte, error) {
+	type updateServiceBody UpdateServiceBody
+
+	return marshalWithExtra(updateServiceBody(u), u.Extra)
+}
+
+// UpdateBody returns request body that updates the service to its current
+// state including the fields kept in Extra.
+func (s *Service) UpdateBody() *UpdateServiceBody {
+	return &UpdateServiceBody{
+		Enabled: s.Enabled,
+		Status:  s.Status,
+		Extra:   s.Extra,
+	}
 }
 
 // List method returns list of the client's services.
@@ -0,0 +1,66 @@
+package snapshot
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+)
+
+// KeyCipher encrypts private keys of the certificates in the document.
+type KeyCipher interface {
+	// Encrypt returns encrypted plaintext encoded as a string.
+	Encrypt(plaintext []byte) (string, error)
+
+	// Decrypt returns plaintext of the string returned by Encrypt.
+	Decrypt(ciphertext string) ([]byte, error)
+}
+
+// AESCipher represents KeyCipher that uses AES-GCM, ciphertexts are base64
+// encoded and prefixed with a random nonce.
+type AESCipher struct {
+	aead cipher.AEAD
+}
+
+// NewAESCipher returns a reference to AESCipher with given 16, 24 or 32 bytes
+// key that selects AES-128, AES-192 or AES-256.
+func NewAESCipher(key []byte) (*AESCipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AESCipher{aead: aead}, nil
+}
+
+// Encrypt implements KeyCipher interface.
+func (c *AESCipher) Encrypt(plaintext []byte) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(c.aead.Seal(nonce, nonce, plaintext, nil)), nil
+}
+
+// Decrypt implements KeyCipher interface.
+func (c *AESCipher) Decrypt(ciphertext string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < c.aead.NonceSize() {
+		return nil, errors.New("snapshot: ciphertext is too short")
+	}
+
+	nonce, data := data[:c.aead.NonceSize()], data[c.aead.NonceSize():]
+
+	return c.aead.Open(nil, nonce, data, nil)
+}
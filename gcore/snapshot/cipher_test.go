@@ -0,0 +1,48 @@
+package snapshot
+
+import (
+	"testing"
+)
+
+func TestAESCipher(t *testing.T) {
+	cipher, err := NewAESCipher([]byte("0123456789abcdef0123456789abcdef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := cipher.Encrypt([]byte("private key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := cipher.Encrypt([]byte("private key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first == second {
+		t.Error("ciphertexts of the same plaintext are equal")
+	}
+
+	plaintext, err := cipher.Decrypt(first)
+	if err != nil || string(plaintext) != "private key" {
+		t.Errorf("Expected: %q, got %q, %v", "private key", plaintext, err)
+	}
+
+	other, err := NewAESCipher(make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = other.Decrypt(first); err == nil {
+		t.Error("expected error for a wrong key")
+	}
+
+	if _, err = cipher.Decrypt("c2hvcnQ="); err == nil {
+		t.Error("expected error for a short ciphertext")
+	}
+
+	if _, err = NewAESCipher([]byte("short")); err == nil {
+		t.Error("expected error for invalid key size")
+	}
+}
@@ -0,0 +1,123 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format represents encoding of the document.
+type Format string
+
+// The list of the supported formats.
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// FormatFromPath returns format of the document by extension of the file,
+// YAML is used unless the extension is ".json".
+func FormatFromPath(path string) Format {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return FormatJSON
+	}
+
+	return FormatYAML
+}
+
+// Encode writes the document in given format. YAML documents have the same
+// field names as JSON ones but omit null fields.
+func Encode(w io.Writer, doc *Document, format Format) error {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatJSON:
+		_, err = w.Write(append(data, '\n'))
+		return err
+	case FormatYAML:
+		// JSON is valid YAML, the node keeps order of the fields.
+		var node yaml.Node
+		if err = yaml.Unmarshal(data, &node); err != nil {
+			return err
+		}
+		cleanNode(&node)
+
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err = encoder.Encode(&node); err != nil {
+			return err
+		}
+
+		return encoder.Close()
+	default:
+		return fmt.Errorf("snapshot: unknown format %q", format)
+	}
+}
+
+// cleanNode makes the node and its children use the block style and removes
+// null fields that are the same as missing ones.
+func cleanNode(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
+		node.Style = 0
+	}
+	if node.Kind == yaml.ScalarNode && node.Style == yaml.DoubleQuotedStyle {
+		node.Style = 0
+	}
+
+	if node.Kind == yaml.MappingNode {
+		content := node.Content[:0]
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i+1].Tag == "!!null" {
+				continue
+			}
+			content = append(content, node.Content[i], node.Content[i+1])
+		}
+		node.Content = content
+	}
+
+	for _, child := range node.Content {
+		cleanNode(child)
+	}
+}
+
+// Decode reads the document in given format and checks its version.
+func Decode(r io.Reader, format Format) (*Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case FormatJSON:
+	case FormatYAML:
+		var value interface{}
+		if err = yaml.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		if data, err = json.Marshal(value); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("snapshot: unknown format %q", format)
+	}
+
+	doc := &Document{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if err = decoder.Decode(doc); err != nil {
+		return nil, err
+	}
+
+	if doc.Version != Version {
+		return nil, fmt.Errorf("snapshot: unsupported document version %d", doc.Version)
+	}
+
+	return doc, nil
+}
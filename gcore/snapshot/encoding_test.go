@@ -0,0 +1,89 @@
+package snapshot
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dstdfx/go-gcore/gcore"
+	"github.com/dstdfx/go-gcore/gcore/reconciler"
+)
+
+func testDocument() *Document {
+	return &Document{
+		Version: Version,
+		OriginGroups: []*reconciler.OriginGroupSpec{{
+			Name:    "origin",
+			Origins: []gcore.Origin{{Source: "origin.site.com", Enabled: true}},
+		}},
+		Certificates: []*Certificate{{Name: "site", Certificate: "-----BEGIN CERTIFICATE-----\nMIIB\n"}},
+		Resources: []*reconciler.ResourceSpec{{
+			Cname:       "cdn.site.com",
+			OriginGroup: "origin",
+			Certificate: "site",
+			Options: &gcore.Options{
+				HostHeader: &gcore.HostHeader{Enabled: true, Value: "true"},
+				EdgeCacheSettings: &gcore.EdgeCacheSettings{
+					Enabled:      true,
+					Value:        "43200s",
+					CustomValues: map[string]string{"404": "10s"},
+				},
+			},
+			Rules: []*reconciler.RuleSpec{{Name: "images", Rule: "/images/.*", Weight: 2}},
+		}},
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	for _, format := range []Format{FormatJSON, FormatYAML} {
+		var buf bytes.Buffer
+		if err := Encode(&buf, testDocument(), format); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+
+		doc, err := Decode(&buf, format)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+
+		if !reflect.DeepEqual(doc, testDocument()) {
+			t.Errorf("%s: Expected: %+v, got %+v", format, testDocument(), doc)
+		}
+	}
+}
+
+func TestEncode_YAML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Encode(&buf, testDocument(), FormatYAML); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{"version: 1\n", "originGroups:\n", "  - name: origin\n", "cname: cdn.site.com\n"} {
+		if !strings.Contains(buf.String(), line) {
+			t.Errorf("Expected: %q in the document, got:\n%s", line, buf.String())
+		}
+	}
+
+	if strings.Contains(buf.String(), "null") {
+		t.Errorf("null fields haven't been omitted:\n%s", buf.String())
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	testCases := map[string]string{
+		"unsupported version": "version: 2\n",
+		"missing version":     "resources: []\n",
+		"invalid document":    "version: [\n",
+	}
+
+	for name, raw := range testCases {
+		if _, err := Decode(strings.NewReader(raw), FormatYAML); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	if FormatFromPath("account.JSON") != FormatJSON || FormatFromPath("account.yml") != FormatYAML {
+		t.Error("unexpected format of the path")
+	}
+}
@@ -0,0 +1,366 @@
+// Package snapshot exports configuration of the whole CDN account to
+// a portable YAML or JSON document and imports it to another account.
+//
+// Objects of the document refer to each other by names instead of IDs,
+// so the document can be imported to any account:
+//
+//	doc, err := snapshot.Export(ctx, source)
+//	if err != nil {
+//		return err
+//	}
+//	if err = snapshot.Encode(file, doc, snapshot.FormatYAML); err != nil {
+//		return err
+//	}
+//
+//	plan, err := snapshot.Import(ctx, target, doc)
+//
+// The API never returns private keys of the certificates, so they are
+// exported only if they're provided by WithPrivateKeys and always encrypted
+// by the cipher. Keys of the secure_key options are exported only with
+// WithSecureKeys.
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/dstdfx/go-gcore/gcore"
+	"github.com/dstdfx/go-gcore/gcore/reconciler"
+)
+
+// Version represents version of the document format written by Export.
+const Version = 1
+
+// Document represents configuration of the account.
+type Document struct {
+	Version      int                           `json:"version"`
+	OriginGroups []*reconciler.OriginGroupSpec `json:"originGroups,omitempty"`
+	Certificates []*Certificate                `json:"certificates,omitempty"`
+	Resources    []*reconciler.ResourceSpec    `json:"resources,omitempty"`
+}
+
+// Certificate represents SSL certificate of the document.
+type Certificate struct {
+	Name        string `json:"name"`
+	Certificate string `json:"sslCertificate,omitempty"`
+
+	// EncryptedPrivateKey represents private key encrypted by the KeyCipher,
+	// it's empty if the key hasn't been exported.
+	EncryptedPrivateKey string `json:"encryptedPrivateKey,omitempty"`
+}
+
+// KeySource returns private key of the certificate by its name.
+type KeySource func(ctx context.Context, name string) (string, error)
+
+// Option configures Export and Import.
+type Option func(*options)
+
+// options represents configuration of Export and Import.
+type options struct {
+	keys       KeySource
+	cipher     KeyCipher
+	secureKeys bool
+	logger     *slog.Logger
+	reconciler []reconciler.Option
+}
+
+// newOptions returns a reference to options configured with given options.
+func newOptions(opts ...Option) *options {
+	o := &options{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithPrivateKeys makes Export include private keys of the certificates
+// encrypted by the cipher. Keys of the certificates the source returns
+// an empty key for aren't exported.
+func WithPrivateKeys(source KeySource, cipher KeyCipher) Option {
+	return func(o *options) {
+		o.keys = source
+		o.cipher = cipher
+	}
+}
+
+// WithSecureKeys makes Export include the secure_key options with their
+// keys in plain text. The options are left out of the document otherwise,
+// so Import doesn't change them.
+func WithSecureKeys() Option {
+	return func(o *options) {
+		o.secureKeys = true
+	}
+}
+
+// WithSlogLogger sets logger for the warnings of Import, slog.Default()
+// is used if it isn't set.
+func WithSlogLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithKeyCipher sets cipher that decrypts private keys on Import.
+func WithKeyCipher(cipher KeyCipher) Option {
+	return func(o *options) {
+		o.cipher = cipher
+	}
+}
+
+// WithReconcilerOptions sets options of the reconciler used by Import,
+// e.g. reconciler.WithDryRun.
+func WithReconcilerOptions(opts ...reconciler.Option) Option {
+	return func(o *options) {
+		o.reconciler = append(o.reconciler, opts...)
+	}
+}
+
+// Export returns configuration of the account of given client.
+func Export(ctx context.Context, client *gcore.CommonClient, opts ...Option) (*Document, error) {
+	o := newOptions(opts...)
+	doc := &Document{Version: Version}
+
+	groups, _, err := client.OriginGroups.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groupNames := make(map[int]string, len(groups))
+	for _, group := range groups {
+		groupNames[group.ID] = group.Name
+
+		origins := make([]gcore.Origin, 0, len(group.Origins))
+		for _, origin := range group.Origins {
+			origin.ID = 0
+			origins = append(origins, origin)
+		}
+
+		doc.OriginGroups = append(doc.OriginGroups, &reconciler.OriginGroupSpec{
+			Name:    group.Name,
+			UseNext: group.UseNext,
+			Origins: origins,
+		})
+	}
+
+	certs, _, err := client.Certificates.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	certNames := make(map[int]string, len(certs))
+	for _, cert := range certs {
+		if cert.Deleted {
+			continue
+		}
+		certNames[cert.ID] = cert.Name
+
+		exported := &Certificate{
+			Name:        cert.Name,
+			Certificate: cert.CertificateChain,
+		}
+		if exported.EncryptedPrivateKey, err = o.exportKey(ctx, cert.Name); err != nil {
+			return nil, err
+		}
+		doc.Certificates = append(doc.Certificates, exported)
+	}
+
+	resources, _, err := client.Resources.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, resource := range resources {
+		if resource.Deleted {
+			continue
+		}
+
+		spec := &reconciler.ResourceSpec{
+			Cname:              resource.Cname,
+			OriginGroup:        groupNames[resource.OriginGroup],
+			OriginProtocol:     resource.OriginProtocol,
+			SecondaryHostnames: resource.SecondaryHostnames,
+			Options:            o.exportOptions(resource.Options),
+		}
+		if spec.OriginGroup == "" {
+			return nil, fmt.Errorf("snapshot: resource %q refers to unknown origin group %d",
+				resource.Cname, resource.OriginGroup)
+		}
+		if resource.SslEnabled && resource.SslData != nil {
+			spec.Certificate = certNames[*resource.SslData]
+		}
+
+		rules, _, err := client.Rules.List(ctx, resource.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rule := range rules {
+			ruleSpec := &reconciler.RuleSpec{
+				Name:           rule.Name,
+				Rule:           rule.Rule,
+				RuleType:       rule.RuleType,
+				Weight:         rule.Weight,
+				OriginGroup:    groupNames[rule.OriginGroup],
+				OriginProtocol: rule.OriginProtocol,
+				Options:        o.exportOptions(&rule.Options),
+			}
+			if rule.OriginGroup != 0 && ruleSpec.OriginGroup == "" {
+				return nil, fmt.Errorf("snapshot: rule %q of resource %q refers to unknown origin group %d",
+					rule.Name, resource.Cname, rule.OriginGroup)
+			}
+			spec.Rules = append(spec.Rules, ruleSpec)
+		}
+
+		doc.Resources = append(doc.Resources, spec)
+	}
+
+	return doc, nil
+}
+
+// exportOptions returns copy of the options to export, the secure_key option
+// is left out unless secure keys are exported.
+func (o *options) exportOptions(options *gcore.Options) *gcore.Options {
+	if options == nil {
+		return nil
+	}
+
+	exported := *options
+	if !o.secureKeys {
+		exported.SecureKey = nil
+	}
+
+	return &exported
+}
+
+// exportKey returns encrypted private key of the certificate or an empty
+// string if private keys aren't exported.
+func (o *options) exportKey(ctx context.Context, name string) (string, error) {
+	if o.keys == nil {
+		return "", nil
+	}
+
+	key, err := o.keys(ctx, name)
+	if err != nil || key == "" {
+		return "", err
+	}
+
+	if o.cipher == nil {
+		return "", errors.New("snapshot: private keys can't be exported without a cipher")
+	}
+
+	return o.cipher.Encrypt([]byte(key))
+}
+
+// Spec returns desired state of the account described by the document.
+// Private keys are decrypted by the cipher that may be nil if there are
+// no encrypted keys.
+func (d *Document) Spec(cipher KeyCipher) (*reconciler.Spec, error) {
+	if d.Version != Version {
+		return nil, fmt.Errorf("snapshot: unsupported document version %d", d.Version)
+	}
+
+	spec := &reconciler.Spec{
+		OriginGroups: d.OriginGroups,
+		Resources:    d.Resources,
+	}
+
+	for _, cert := range d.Certificates {
+		certSpec := &reconciler.CertificateSpec{
+			Name:        cert.Name,
+			Certificate: cert.Certificate,
+		}
+
+		if cert.EncryptedPrivateKey != "" {
+			if cipher == nil {
+				return nil, fmt.Errorf("snapshot: private key of certificate %q is encrypted "+
+					"but no cipher is set", cert.Name)
+			}
+
+			key, err := cipher.Decrypt(cert.EncryptedPrivateKey)
+			if err != nil {
+				return nil, fmt.Errorf("snapshot: unable to decrypt private key of certificate %q: %w", cert.Name, err)
+			}
+			certSpec.PrivateKey = string(key)
+		}
+
+		spec.Certificates = append(spec.Certificates, certSpec)
+	}
+
+	return spec, nil
+}
+
+// Import recreates configuration of the document in the account of given
+// client. Objects are matched by names, so the existing ones are updated
+// and IDs of the created ones are remapped. Certificates that don't exist
+// in the account are created only if their private keys have been exported,
+// the other ones are skipped with a warning and SSL is disabled for
+// the resources that refer to them.
+func Import(ctx context.Context, client *gcore.CommonClient, doc *Document, opts ...Option) (*reconciler.Plan, error) {
+	o := newOptions(opts...)
+
+	spec, err := doc.Spec(o.cipher)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = o.skipMissingCertificates(ctx, client, spec); err != nil {
+		return nil, err
+	}
+
+	return reconciler.New(client, o.reconciler...).Reconcile(ctx, spec)
+}
+
+// skipMissingCertificates removes certificates that can't be created
+// because of the missing private keys and don't exist in the account from
+// the spec along with the references of the resources to them.
+func (o *options) skipMissingCertificates(ctx context.Context, client *gcore.CommonClient, spec *reconciler.Spec) error {
+	certs, _, err := client.Certificates.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]struct{}, len(certs))
+	for _, cert := range certs {
+		if !cert.Deleted {
+			existing[cert.Name] = struct{}{}
+		}
+	}
+
+	skipped := make(map[string]struct{})
+	certificates := make([]*reconciler.CertificateSpec, 0, len(spec.Certificates))
+	for _, cert := range spec.Certificates {
+		if _, ok := existing[cert.Name]; !ok && (cert.Certificate == "" || cert.PrivateKey == "") {
+			o.logger.WarnContext(ctx, "snapshot: skipping certificate that doesn't exist "+
+				"and has no private key", slog.String("certificate", cert.Name))
+			skipped[cert.Name] = struct{}{}
+			continue
+		}
+		certificates = append(certificates, cert)
+	}
+	spec.Certificates = certificates
+
+	if len(skipped) == 0 {
+		return nil
+	}
+
+	resources := make([]*reconciler.ResourceSpec, 0, len(spec.Resources))
+	for _, resource := range spec.Resources {
+		if _, ok := skipped[resource.Certificate]; ok {
+			o.logger.WarnContext(ctx, "snapshot: disabling SSL of resource with skipped certificate",
+				slog.String("resource", resource.Cname),
+				slog.String("certificate", resource.Certificate))
+
+			// The resource is copied to keep the document unchanged.
+			copied := *resource
+			copied.Certificate = ""
+			resource = &copied
+		}
+		resources = append(resources, resource)
+	}
+	spec.Resources = resources
+
+	return nil
+}
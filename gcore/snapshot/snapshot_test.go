@@ -0,0 +1,310 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dstdfx/go-gcore/gcore"
+	th "github.com/dstdfx/go-gcore/gcore/internal/testhelper"
+	"github.com/dstdfx/go-gcore/gcore/reconciler"
+)
+
+const (
+	testOriginGroupsRawResponse = `[{
+  "id": 7272,
+  "name": "origin",
+  "useNext": true,
+  "origins": [{"id": 9257, "source": "origin.site.com", "enabled": true, "backup": false}],
+  "origin_ids": [{"id": 9257, "source": "origin.site.com", "enabled": true, "backup": false}]
+}, {
+  "id": 7273,
+  "name": "images",
+  "useNext": false,
+  "origins": [{"id": 9258, "source": "images.site.com", "enabled": true, "backup": false}]
+}]`
+
+	testCertificatesRawResponse = `[
+  {"id": 1189, "name": "site", "sslCertificateChain": "-----BEGIN CERTIFICATE-----", "deleted": false},
+  {"id": 1190, "name": "old", "sslCertificateChain": "-----BEGIN CERTIFICATE-----", "deleted": true}
+]`
+
+	testResourcesRawResponse = `[{
+  "id": 4478,
+  "cname": "cdn.site.com",
+  "originGroup": 7272,
+  "originProtocol": "HTTPS",
+  "secondaryHostnames": ["cdn2.site.com"],
+  "sslData": 1189,
+  "sslEnabled": true,
+  "status": "active",
+  "options": {"gzipOn": {"enabled": true, "value": true}}
+}]`
+
+	testRulesRawResponse = `[{
+  "id": 1,
+  "name": "images",
+  "rule": "/images/.*",
+  "ruleType": 0,
+  "weight": 2,
+  "originGroup": 7273,
+  "options": {"cache_expire": {"enabled": true, "value": 86400}}
+}]`
+)
+
+// newTestClient returns client of the account that serves the fixtures,
+// given responses replace the fixtures of their URLs.
+func newTestClient(t *testing.T, responses map[string]string) (*gcore.CommonClient, func()) {
+	testEnv := th.SetupTestEnv()
+
+	fixtures := map[string]string{
+		"/originGroups":         testOriginGroupsRawResponse,
+		"/sslData":              testCertificatesRawResponse,
+		"/resources":            testResourcesRawResponse,
+		"/resources/4478/rules": testRulesRawResponse,
+	}
+	for url, response := range responses {
+		fixtures[url] = response
+	}
+	for url, response := range fixtures {
+		testEnv.Mux.HandleFunc(url, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				t.Errorf("unexpected %s request", r.Method)
+			}
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprint(w, response)
+		})
+	}
+
+	client, err := gcore.NewCommon(
+		gcore.WithBaseURL(testEnv.Server.URL),
+		gcore.WithToken(&gcore.Token{Value: th.TestFakeToken}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client, testEnv.TearDownTestEnv
+}
+
+func TestExport(t *testing.T) {
+	client, tearDown := newTestClient(t, nil)
+	defer tearDown()
+
+	cipher, err := NewAESCipher(make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := func(_ context.Context, name string) (string, error) {
+		return "private key of " + name, nil
+	}
+
+	doc, err := Export(context.Background(), client, WithPrivateKeys(keys, cipher))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.Version != Version {
+		t.Errorf("Expected: version %d, got %d", Version, doc.Version)
+	}
+
+	expectedGroups := []*reconciler.OriginGroupSpec{{
+		Name:    "origin",
+		UseNext: true,
+		Origins: []gcore.Origin{{Source: "origin.site.com", Enabled: true}},
+	}, {
+		Name:    "images",
+		Origins: []gcore.Origin{{Source: "images.site.com", Enabled: true}},
+	}}
+	if !reflect.DeepEqual(doc.OriginGroups, expectedGroups) {
+		t.Errorf("Expected: %+v, got %+v", expectedGroups, doc.OriginGroups)
+	}
+
+	if len(doc.Certificates) != 1 || doc.Certificates[0].Name != "site" {
+		t.Fatalf("unexpected certificates: %+v", doc.Certificates)
+	}
+
+	key, err := cipher.Decrypt(doc.Certificates[0].EncryptedPrivateKey)
+	if err != nil || string(key) != "private key of site" {
+		t.Errorf("private key hasn't been encrypted: %q, %v", key, err)
+	}
+
+	resource := doc.Resources[0]
+	if resource.OriginGroup != "origin" || resource.Certificate != "site" || resource.Cname != "cdn.site.com" {
+		t.Errorf("resource references haven't been exported by names: %+v", resource)
+	}
+
+	if len(resource.Rules) != 1 || resource.Rules[0].OriginGroup != "images" || resource.Rules[0].Weight != 2 {
+		t.Errorf("unexpected rules: %+v", resource.Rules)
+	}
+}
+
+func TestExport_WithoutPrivateKeys(t *testing.T) {
+	client, tearDown := newTestClient(t, nil)
+	defer tearDown()
+
+	doc, err := Export(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.Certificates[0].EncryptedPrivateKey != "" {
+		t.Errorf("private key has been exported: %+v", doc.Certificates[0])
+	}
+}
+
+func TestExport_SecureKeys(t *testing.T) {
+	client, tearDown := newTestClient(t, map[string]string{
+		"/resources/4478/rules": `[{
+  "id": 1,
+  "name": "signed",
+  "rule": "/signed/.*",
+  "options": {"secure_key": {"enabled": true, "body": "secret", "type": 0}}
+}]`,
+	})
+	defer tearDown()
+
+	doc, err := Export(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if options := doc.Resources[0].Rules[0].Options; options.SecureKey != nil {
+		t.Errorf("secure key has been exported: %+v", options.SecureKey)
+	}
+
+	doc, err = Export(context.Background(), client, WithSecureKeys())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if options := doc.Resources[0].Rules[0].Options; options.SecureKey == nil || options.SecureKey.Key != "secret" {
+		t.Errorf("secure key hasn't been exported: %+v", options.SecureKey)
+	}
+}
+
+func TestExport_UnknownRuleOriginGroup(t *testing.T) {
+	client, tearDown := newTestClient(t, map[string]string{
+		"/resources/4478/rules": `[{"id": 1, "name": "images", "rule": "/images/.*", "originGroup": 9999}]`,
+	})
+	defer tearDown()
+
+	if _, err := Export(context.Background(), client); err == nil {
+		t.Error("expected error for unknown origin group of the rule")
+	}
+}
+
+func TestImport(t *testing.T) {
+	client, tearDown := newTestClient(t, nil)
+	defer tearDown()
+
+	doc := &Document{
+		Version: Version,
+		OriginGroups: []*reconciler.OriginGroupSpec{{
+			Name:    "static",
+			Origins: []gcore.Origin{{Source: "static.site.com", Enabled: true}},
+		}},
+		Resources: []*reconciler.ResourceSpec{{
+			Cname:       "static.site.com",
+			OriginGroup: "static",
+			Certificate: "site",
+		}},
+	}
+
+	plan, err := Import(context.Background(), client, doc,
+		WithReconcilerOptions(reconciler.WithDryRun()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `+ create origin_group "static"
++ create resource "static.site.com"
+`
+	if plan.String() != expected {
+		t.Errorf("Expected: %s, got %s", expected, plan)
+	}
+}
+
+func TestImport_MissingCertificate(t *testing.T) {
+	client, tearDown := newTestClient(t, map[string]string{"/sslData": `[]`})
+	defer tearDown()
+
+	doc := &Document{
+		Version: Version,
+		OriginGroups: []*reconciler.OriginGroupSpec{{
+			Name:    "origin",
+			UseNext: true,
+			Origins: []gcore.Origin{{Source: "origin.site.com", Enabled: true}},
+		}},
+		Certificates: []*Certificate{{Name: "site", Certificate: "-----BEGIN CERTIFICATE-----"}},
+		Resources: []*reconciler.ResourceSpec{{
+			Cname:       "static.site.com",
+			OriginGroup: "origin",
+			Certificate: "site",
+		}},
+	}
+
+	var logs bytes.Buffer
+	plan, err := Import(context.Background(), client, doc,
+		WithSlogLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		WithReconcilerOptions(reconciler.WithDryRun()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `+ create resource "static.site.com"
+`
+	if plan.String() != expected {
+		t.Errorf("Expected: %s, got %s", expected, plan)
+	}
+
+	if !strings.Contains(logs.String(), "certificate=site") {
+		t.Errorf("skipped certificate hasn't been logged: %s", logs.String())
+	}
+
+	if doc.Resources[0].Certificate != "site" {
+		t.Error("document has been changed")
+	}
+}
+
+func TestDocument_Spec(t *testing.T) {
+	cipher, err := NewAESCipher(make([]byte, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encrypted, err := cipher.Encrypt([]byte("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc := &Document{
+		Version:      Version,
+		Certificates: []*Certificate{{Name: "site", Certificate: "cert", EncryptedPrivateKey: encrypted}},
+	}
+
+	if _, err = doc.Spec(nil); err == nil {
+		t.Error("expected error for encrypted key without cipher")
+	}
+
+	spec, err := doc.Spec(cipher)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*reconciler.CertificateSpec{{Name: "site", Certificate: "cert", PrivateKey: "key"}}
+	if !reflect.DeepEqual(spec.Certificates, expected) {
+		t.Errorf("Expected: %+v, got %+v", expected, spec.Certificates)
+	}
+
+	doc.Version = 2
+	if _, err = doc.Spec(cipher); err == nil {
+		t.Error("expected error for unsupported version")
+	}
+}
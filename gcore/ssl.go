@@ -2,7 +2,9 @@ package gcore
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -28,6 +30,34 @@ type CertSSL struct {
 	CertificateChain    string  `json:"sslCertificateChain"`
 	CertIssuer          string  `json:"cert_issuer"`
 	CertSubjectCn       string  `json:"cert_subject_cn"`
+
+	// Extra represents fields the library doesn't model yet by their keys.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface,
+// unknown fields are stored in Extra.
+func (c *CertSSL) UnmarshalJSON(b []byte) error {
+	type certSSL CertSSL
+
+	var alias certSSL
+	extra, err := unmarshalWithExtra(b, &alias)
+	if err != nil {
+		return err
+	}
+
+	*c = CertSSL(alias)
+	c.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface,
+// fields from Extra are added unless CertSSL has a field for them.
+func (c CertSSL) MarshalJSON() ([]byte, error) {
+	type certSSL CertSSL
+
+	return marshalWithExtra(certSSL(c), c.Extra)
 }
 
 // AddCertBody represents SSL certificate body for add certificate.
@@ -37,21 +67,55 @@ type AddCertBody struct {
 	PrivateKey  string `json:"sslPrivateKey"`
 }
 
+// ListCertsOpts represents list of additional options to filter and
+// paginate SSL certificates by.
+type ListCertsOpts struct {
+	Name string `param:"name,omitempty"`
+
+	// Ordering represents field to sort the certificates by, "-" prefix
+	// reverses the order, e.g. "-validity_not_after".
+	Ordering string `param:"ordering,omitempty"`
+
+	// Fields represents fields of the certificates returned by the API,
+	// all fields are returned if it's empty.
+	Fields []string `param:"fields,omitempty"`
+
+	Limit  int `param:"limit,omitempty"`
+	Offset int `param:"offset,omitempty"`
+}
+
 // List returns list of all SSL certificates.
 func (s *CertService) List(ctx context.Context) ([]*CertSSL, *http.Response, error) {
-	req, err := s.client.NewRequest(ctx, http.MethodGet, certificatesURL, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	certs := make([]*CertSSL, 0)
+	return s.ListWithOpts(ctx, ListCertsOpts{})
+}
 
-	resp, err := s.client.Do(req, &certs)
+// ListWithOpts returns list of SSL certificates filtered by given opts.
+// All certificates are returned unless opts.Limit is set.
+func (s *CertService) ListWithOpts(ctx context.Context, opts ListCertsOpts) ([]*CertSSL, *http.Response, error) {
+	page, resp, err := s.ListPage(ctx, opts)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	return certs, resp, nil
+	return page.Results, resp, nil
+}
+
+// ListPage method returns page of SSL certificates filtered by given opts
+// along with total number of the matching certificates.
+func (s *CertService) ListPage(ctx context.Context, opts ListCertsOpts) (*Page[*CertSSL], *http.Response, error) {
+	return listPage[*CertSSL](ctx, s.client, certificatesURL, opts, opts.Limit, opts.Offset)
+}
+
+// ListAll method returns iterator over all SSL certificates filtered by given
+// opts, the pages are requested as the iteration goes.
+func (s *CertService) ListAll(ctx context.Context, opts ListCertsOpts) iter.Seq2[*CertSSL, error] {
+	return listAll(ctx, opts.Limit, opts.Offset, func(ctx context.Context, limit, offset int) (*Page[*CertSSL], error) {
+		pageOpts := opts
+		pageOpts.Limit, pageOpts.Offset = limit, offset
+		page, _, err := s.ListPage(ctx, pageOpts)
+
+		return page, err
+	})
 }
 
 // Get method returns specific SSL certificate.
@@ -250,3 +250,83 @@ func TestCertService_List(t *testing.T) {
 		t.Errorf("Expected: %+v, got %+v\n", expected, got)
 	}
 }
+
+func TestCertService_ListWithOpts(t *testing.T) {
+	endpointCalled := false
+
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	handleOpts := &th.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         certificatesURL,
+		RawResponse: testListSSLRawResponse,
+		QueryParams: map[string]string{"name": "site"},
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    &endpointCalled,
+	}
+
+	th.HandleReqWithoutBody(t, handleOpts)
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	got, _, err := client.Certificates.ListWithOpts(context.Background(), ListCertsOpts{Name: "site"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !endpointCalled {
+		t.Fatal("didn't get a list of certificates")
+	}
+
+	if !reflect.DeepEqual(got, testListSSLExpected) {
+		t.Errorf("Expected: %+v, got %+v\n", testListSSLExpected, got)
+	}
+}
+
+func TestCertService_ListPage(t *testing.T) {
+	endpointCalled := false
+
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	handleOpts := &th.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         certificatesURL,
+		RawResponse: `{"count": 10, "results": ` + testListSSLRawResponse + `}`,
+		QueryParams: map[string]string{
+			"fields": "id,name",
+			"limit":  "1",
+			"offset": "2",
+		},
+		Method:   http.MethodGet,
+		Status:   http.StatusOK,
+		CallFlag: &endpointCalled,
+	}
+
+	th.HandleReqWithoutBody(t, handleOpts)
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+	_ = client.Authenticate(context.Background(), TestFakeAuthOptions)
+
+	got, _, err := client.Certificates.ListPage(context.Background(), ListCertsOpts{Fields: []string{"id", "name"}, Limit: 1, Offset: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !endpointCalled {
+		t.Fatal("didn't get a page of certificates")
+	}
+
+	expected := &Page[*CertSSL]{Count: 10, Results: testListSSLExpected, Limit: 1, Offset: 2}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected: %+v, got %+v\n", expected, got)
+	}
+	if !got.HasNext() || got.NextOffset() != 2+len(testListSSLExpected) {
+		t.Errorf("expected the next page at %d", 2+len(testListSSLExpected))
+	}
+}
@@ -0,0 +1,319 @@
+package gcore
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// errNilBody is returned by Create and Update methods of the resources and
+// rules and by the purge methods if the body is nil.
+var errNilBody = errors.New("gcore: request body must not be nil")
+
+// countryCodes represents ISO 3166-1 alpha-2 country codes accepted
+// by the CountryACL option.
+var countryCodes = newStringSet(strings.Fields(`
+	AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL
+	BM BN BO BQ BR BS BT BV BW BY BZ CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV
+	CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR GA GB GD
+	GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU ID IE IL IM
+	IN IO IQ IR IS IT JE JM JO JP KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK
+	LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV MW
+	MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR
+	PS PT PW PY QA RE RO RS RU RW SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS
+	ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ UA UG UM US UY
+	UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW
+`)...)
+
+// httpMethods represents values accepted by the AllowedHTTPMethods option.
+var httpMethods = map[HTTPMethodValue]struct{}{
+	HTTPMethodGET:    {},
+	HTTPMethodHEAD:   {},
+	HTTPMethodPOST:   {},
+	HTTPMethodPUT:    {},
+	HTTPMethodPATCH:  {},
+	HTTPMethodDELETE: {},
+	HTTPMethodOPTION: {},
+}
+
+// staleValues represents values accepted by the Stale option.
+var staleValues = map[StaleValue]struct{}{
+	StaleError:               {},
+	StaleForbidden:           {},
+	StaleBadRequest:          {},
+	StaleTooManyRequests:     {},
+	StaleInternalServerError: {},
+	StaleBadGateway:          {},
+	StaleServiceUnavailable:  {},
+	StaleGatewayTimeout:      {},
+	StaleInvalidHeader:       {},
+	StaleTimeout:             {},
+	StaleUpdating:            {},
+}
+
+// tlsVersions represents values accepted by the TLSVersions option.
+var tlsVersions = map[TLSVersion]struct{}{
+	TLSVersionSSLv3: {},
+	TLSVersion10:    {},
+	TLSVersion11:    {},
+	TLSVersion12:    {},
+	TLSVersion13:    {},
+}
+
+// newStringSet returns set of given values.
+func newStringSet(values ...string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+
+	return set
+}
+
+// validRewriteFlag reports whether the rewrite flag is known.
+func validRewriteFlag(flag string) bool {
+	switch flag {
+	case RewriteFlagBreak, RewriteFlagLast, RewriteFlagRedirect, RewriteFlagPermanent:
+		return true
+	}
+
+	return false
+}
+
+// validSecureKeyType reports whether the secure key type is known.
+func validSecureKeyType(keyType int) bool {
+	return keyType == SecureKeyTypeWithIP || keyType == SecureKeyTypeWithoutIP
+}
+
+// Validate checks values of the enabled options and returns *ValidationError
+// with all found problems or nil. Disabled options are skipped, so options
+// returned by Get can be sent back as is. Create and Update methods of the resources and
+// rules call it before sending the request.
+//
+// The Rewrite pattern is a PCRE regular expression evaluated by nginx, Go
+// can't compile it, so only the shape of the body and balanced parentheses
+// of the pattern are checked. Other mistakes in the pattern are reported by
+// the API.
+func (o *Options) Validate() error {
+	if o == nil {
+		return nil
+	}
+
+	v := &ValidationError{}
+
+	if o.CacheExpire != nil && o.CacheExpire.Enabled && o.CacheExpire.Value < 0 {
+		v.add("cache_expire.value", "must not be negative")
+	}
+
+	if o.AllowedHTTPMethods != nil && o.AllowedHTTPMethods.Enabled {
+		for _, method := range o.AllowedHTTPMethods.Value {
+			if _, ok := httpMethods[method]; !ok {
+				v.add("allowedHttpMethods.value", "unknown method %q", method)
+			}
+		}
+	}
+
+	if o.CountryACL != nil && o.CountryACL.Enabled {
+		validatePolicyType(v, "country_acl", o.CountryACL.PolicyType)
+		for _, code := range o.CountryACL.ExceptedValues {
+			if _, ok := countryCodes[code]; !ok {
+				v.add("country_acl.excepted_values", "%q is not an ISO 3166-1 alpha-2 country code", code)
+			}
+		}
+	}
+
+	if o.IPAddressACL != nil && o.IPAddressACL.Enabled {
+		validatePolicyType(v, "ip_address_acl", o.IPAddressACL.PolicyType)
+		for _, cidr := range o.IPAddressACL.ExceptedValues {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				v.add("ip_address_acl.excepted_values", "%q is not a CIDR", cidr)
+			}
+		}
+	}
+
+	if o.ReferrerACL != nil && o.ReferrerACL.Enabled {
+		validatePolicyType(v, "referrer_acl", o.ReferrerACL.PolicyType)
+	}
+
+	if o.UserAgentACL != nil && o.UserAgentACL.Enabled {
+		validatePolicyType(v, "user_agent_acl", o.UserAgentACL.PolicyType)
+	}
+
+	if o.ForceReturn != nil && o.ForceReturn.Enabled &&
+		(o.ForceReturn.Code < http.StatusContinue || o.ForceReturn.Code > 599) {
+		v.add("force_return.code", "%d is not an HTTP status code", o.ForceReturn.Code)
+	}
+
+	if o.FetchCompressed != nil && o.FetchCompressed.Enabled && o.FetchCompressed.Value &&
+		o.GZIPOn != nil && o.GZIPOn.Enabled && o.GZIPOn.Value {
+		v.add("fetch_compressed", "can't be used together with gzipOn")
+	}
+
+	if o.HostHeader != nil && o.HostHeader.Enabled && o.HostHeader.Value == "" {
+		v.add("hostHeader.value", "must not be empty")
+	}
+
+	if o.OverrideBrowserTTL != nil && o.OverrideBrowserTTL.Enabled && o.OverrideBrowserTTL.Value < 0 {
+		v.add("override_browser_ttl.value", "must not be negative")
+	}
+
+	if o.Rewrite != nil && o.Rewrite.Enabled {
+		validateRewrite(v, o.Rewrite)
+	}
+
+	if o.SecureKey != nil && o.SecureKey.Enabled {
+		if !validSecureKeyType(o.SecureKey.Type) {
+			v.add("secure_key.type", "unknown type %d", o.SecureKey.Type)
+		}
+		if o.SecureKey.Key == "" {
+			v.add("secure_key.key", "must not be empty")
+		}
+	}
+
+	if o.Stale != nil && o.Stale.Enabled {
+		for _, value := range o.Stale.Value {
+			if _, ok := staleValues[value]; !ok {
+				v.add("stale.value", "unknown value %q", value)
+			}
+		}
+	}
+
+	validateExtendedOptions(v, o)
+
+	return v.errOrNil()
+}
+
+// validateExtendedOptions checks the options that are omitted from
+// the requests if they aren't set.
+func validateExtendedOptions(v *ValidationError, o *Options) {
+	whitelist := o.QueryParamsWhitelist != nil && o.QueryParamsWhitelist.Enabled
+	blacklist := o.QueryParamsBlacklist != nil && o.QueryParamsBlacklist.Enabled
+	ignoreQuery := o.IgnoreQueryString != nil && o.IgnoreQueryString.Enabled && o.IgnoreQueryString.Value
+
+	switch {
+	case whitelist && blacklist:
+		v.add("query_params_whitelist", "can't be used together with query_params_blacklist")
+	case whitelist && ignoreQuery:
+		v.add("query_params_whitelist", "can't be used together with ignoreQueryString")
+	case blacklist && ignoreQuery:
+		v.add("query_params_blacklist", "can't be used together with ignoreQueryString")
+	}
+
+	if o.RedirectHTTPToHTTPS != nil && o.RedirectHTTPToHTTPS.Enabled && o.RedirectHTTPToHTTPS.Value &&
+		o.RedirectHTTPSToHTTP != nil && o.RedirectHTTPSToHTTP.Enabled && o.RedirectHTTPSToHTTP.Value {
+		v.add("redirect_http_to_https", "can't be used together with redirect_https_to_http")
+	}
+
+	if o.TLSVersions != nil && o.TLSVersions.Enabled {
+		for _, version := range o.TLSVersions.Value {
+			if _, ok := tlsVersions[version]; !ok {
+				v.add("tls_versions.value", "unknown version %q", version)
+			}
+		}
+	}
+
+	if o.ImageStack != nil && o.ImageStack.Enabled &&
+		(o.ImageStack.Quality < 1 || o.ImageStack.Quality > 100) {
+		v.add("image_stack.quality", "must be between 1 and 100")
+	}
+
+	if o.LimitBandwidth != nil && o.LimitBandwidth.Enabled {
+		switch o.LimitBandwidth.LimitType {
+		case LimitBandwidthStatic, LimitBandwidthDynamic:
+		default:
+			v.add("limit_bandwidth.limit_type", "unknown type %q", o.LimitBandwidth.LimitType)
+		}
+	}
+
+	if o.RequestLimiter != nil && o.RequestLimiter.Enabled {
+		if o.RequestLimiter.Rate <= 0 {
+			v.add("request_limiter.rate", "must be positive")
+		}
+		switch o.RequestLimiter.RateUnit {
+		case "", RequestLimiterPerSecond, RequestLimiterPerMinute:
+		default:
+			v.add("request_limiter.rate_unit", "unknown unit %q", o.RequestLimiter.RateUnit)
+		}
+	}
+
+	if o.ResponseHeadersHidingPolicy != nil && o.ResponseHeadersHidingPolicy.Enabled {
+		switch o.ResponseHeadersHidingPolicy.Mode {
+		case HeadersHidingModeHide, HeadersHidingModeShow:
+		default:
+			v.add("response_headers_hiding_policy.mode", "unknown mode %q", o.ResponseHeadersHidingPolicy.Mode)
+		}
+	}
+
+	if o.SNI != nil && o.SNI.Enabled {
+		switch o.SNI.SNIType {
+		case SNITypeDynamic:
+		case SNITypeCustom:
+			if o.SNI.CustomHostname == "" {
+				v.add("sni.custom_hostname", "must not be empty for custom SNI")
+			}
+		default:
+			v.add("sni.sni_type", "unknown type %q", o.SNI.SNIType)
+		}
+	}
+}
+
+// validatePolicyType checks policy type of the ACL option.
+func validatePolicyType(v *ValidationError, option string, policyType string) {
+	if policyType != PolicyTypeAllow && policyType != PolicyTypeDeny {
+		v.add(option+".policy_type", "unknown policy type %q", policyType)
+	}
+}
+
+// validateRewrite checks that the rewrite body consists of a pattern and
+// a replacement and the flag is known. The pattern is a PCRE regular
+// expression evaluated by nginx, so only its parentheses are checked.
+func validateRewrite(v *ValidationError, rewrite *Rewrite) {
+	if rewrite.Flag != "" && !validRewriteFlag(rewrite.Flag) {
+		v.add("rewrite.flag", "unknown flag %q", rewrite.Flag)
+	}
+
+	parts := strings.Fields(rewrite.Body)
+	if len(parts) != 2 {
+		v.add("rewrite.body", "must consist of a pattern and a replacement separated by a space")
+		return
+	}
+
+	if !balancedParentheses(parts[0]) {
+		v.add("rewrite.body", "unbalanced parentheses in pattern %q", parts[0])
+	}
+}
+
+// balancedParentheses reports whether the groups of the pattern are closed,
+// escaped characters and the ones inside character classes are skipped.
+func balancedParentheses(pattern string) bool {
+	depth := 0
+	inClass := false
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '\\':
+			i++
+		case inClass:
+			inClass = c != ']'
+		case c == '[':
+			inClass = true
+			// "]" right after "[" or "[^" is a literal.
+			if i+1 < len(pattern) && pattern[i+1] == '^' {
+				i++
+			}
+			if i+1 < len(pattern) && pattern[i+1] == ']' {
+				i++
+			}
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+
+	return depth == 0
+}
@@ -0,0 +1,259 @@
+package gcore
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+
+	th "github.com/dstdfx/go-gcore/gcore/internal/testhelper"
+)
+
+func TestOptions_Validate(t *testing.T) {
+	valid := &Options{
+		CacheExpire:        &CacheExpire{Enabled: true, Value: 3600},
+		AllowedHTTPMethods: &AllowedHTTPMethods{Enabled: true, Value: []HTTPMethodValue{HTTPMethodGET}},
+		CountryACL: &CountryACL{
+			Enabled:        true,
+			PolicyType:     PolicyTypeDeny,
+			ExceptedValues: []string{"GB", "DE"},
+		},
+		IPAddressACL: &IPAddressACL{
+			Enabled:        true,
+			PolicyType:     PolicyTypeAllow,
+			ExceptedValues: []string{"192.168.1.0/24", "2001:db8::/32"},
+		},
+		ForceReturn:     &ForceReturn{Enabled: true, Code: http.StatusMovedPermanently},
+		FetchCompressed: &FetchCompressed{Enabled: true, Value: true},
+		GZIPOn:          &GZIPOn{Enabled: false, Value: true},
+		Rewrite:         &Rewrite{Enabled: true, Body: "/from/(.*) /to/$1", Flag: RewriteFlagBreak},
+		SecureKey:       &SecureKey{Enabled: true, Key: "secret", Type: SecureKeyTypeWithoutIP},
+		Stale:           &Stale{Enabled: true, Value: []StaleValue{StaleError, StaleUpdating}},
+	}
+
+	if err := valid.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	var nilOptions *Options
+	if err := nilOptions.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	invalid := &Options{
+		CacheExpire:        &CacheExpire{Enabled: true, Value: -1},
+		AllowedHTTPMethods: &AllowedHTTPMethods{Enabled: true, Value: []HTTPMethodValue{"TRACE"}},
+		CountryACL: &CountryACL{
+			Enabled:        true,
+			PolicyType:     "block",
+			ExceptedValues: []string{"GB", "UK"},
+		},
+		IPAddressACL: &IPAddressACL{
+			Enabled:        true,
+			PolicyType:     PolicyTypeAllow,
+			ExceptedValues: []string{"192.168.1.300/24"},
+		},
+		ForceReturn:     &ForceReturn{Enabled: true, Code: 700},
+		FetchCompressed: &FetchCompressed{Enabled: true, Value: true},
+		GZIPOn:          &GZIPOn{Enabled: true, Value: true},
+		Rewrite:         &Rewrite{Enabled: true, Body: "/from/(.* /to/$1", Flag: "stop"},
+		SecureKey:       &SecureKey{Enabled: true, Type: 1},
+		Stale:           &Stale{Enabled: true, Value: []StaleValue{"http_418"}},
+	}
+
+	err := invalid.Validate()
+	if !IsValidation(err) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+
+	var fields []string
+	for field := range err.(*ValidationError).FieldErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	expected := []string{
+		"allowedHttpMethods.value",
+		"cache_expire.value",
+		"country_acl.excepted_values",
+		"country_acl.policy_type",
+		"fetch_compressed",
+		"force_return.code",
+		"ip_address_acl.excepted_values",
+		"rewrite.body",
+		"rewrite.flag",
+		"secure_key.key",
+		"secure_key.type",
+		"stale.value",
+	}
+
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Expected: %v, got %v", expected, fields)
+	}
+}
+
+func TestOptions_Validate_Extended(t *testing.T) {
+	testCases := []struct {
+		options *Options
+		field   string
+	}{
+		{
+			options: &Options{
+				QueryParamsWhitelist: &QueryParamsWhitelist{Enabled: true, Value: []string{"v"}},
+				QueryParamsBlacklist: &QueryParamsBlacklist{Enabled: true, Value: []string{"utm"}},
+			},
+			field: "query_params_whitelist",
+		},
+		{
+			options: &Options{
+				IgnoreQueryString:    &IgnoreQueryString{Enabled: true, Value: true},
+				QueryParamsBlacklist: &QueryParamsBlacklist{Enabled: true, Value: []string{"utm"}},
+			},
+			field: "query_params_blacklist",
+		},
+		{
+			options: &Options{
+				RedirectHTTPToHTTPS: &RedirectHTTPToHTTPS{Enabled: true, Value: true},
+				RedirectHTTPSToHTTP: &RedirectHTTPSToHTTP{Enabled: true, Value: true},
+			},
+			field: "redirect_http_to_https",
+		},
+		{
+			options: &Options{TLSVersions: &TLSVersions{Enabled: true, Value: []TLSVersion{"TLSv2"}}},
+			field:   "tls_versions.value",
+		},
+		{
+			options: &Options{ImageStack: &ImageStack{Enabled: true, Quality: 0}},
+			field:   "image_stack.quality",
+		},
+		{
+			options: &Options{LimitBandwidth: &LimitBandwidth{Enabled: true, LimitType: "burst"}},
+			field:   "limit_bandwidth.limit_type",
+		},
+		{
+			options: &Options{RequestLimiter: &RequestLimiter{Enabled: true, Rate: 5, RateUnit: "r/h"}},
+			field:   "request_limiter.rate_unit",
+		},
+		{
+			options: &Options{ResponseHeadersHidingPolicy: &ResponseHeadersHidingPolicy{Enabled: true, Mode: "drop"}},
+			field:   "response_headers_hiding_policy.mode",
+		},
+		{
+			options: &Options{SNI: &SNI{Enabled: true, SNIType: SNITypeCustom}},
+			field:   "sni.custom_hostname",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.options.Validate()
+
+		validationErr, ok := err.(*ValidationError)
+		if !ok {
+			t.Errorf("%s: expected validation error, got %v", tc.field, err)
+			continue
+		}
+
+		if _, ok := validationErr.FieldErrors[tc.field]; !ok || len(validationErr.FieldErrors) != 1 {
+			t.Errorf("%s: unexpected field errors %v", tc.field, validationErr.FieldErrors)
+		}
+	}
+}
+
+func TestOptions_Validate_Disabled(t *testing.T) {
+	options := &Options{
+		CountryACL:     &CountryACL{PolicyType: "block", ExceptedValues: []string{"UK"}},
+		SecureKey:      &SecureKey{Type: 1},
+		LimitBandwidth: &LimitBandwidth{},
+		Rewrite:        &Rewrite{Body: "/from"},
+		SNI:            &SNI{},
+	}
+
+	if err := options.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestOptions_Validate_RewritePattern(t *testing.T) {
+	testCases := []struct {
+		body  string
+		valid bool
+	}{
+		{body: "^/(?!api/)(.*)$ /app/$1", valid: true},
+		{body: "^/(?<name>[a-z]+)/ /$name/", valid: true},
+		{body: `^/[(\)]+(x) /y`, valid: true},
+		{body: "^/[]()]/ /y", valid: true},
+		{body: "^/(a/ /b", valid: false},
+		{body: "^/a)/ /b", valid: false},
+	}
+
+	for _, tc := range testCases {
+		options := &Options{Rewrite: &Rewrite{Enabled: true, Body: tc.body}}
+
+		err := options.Validate()
+		if tc.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.body, err)
+		}
+		if !tc.valid && !IsValidation(err) {
+			t.Errorf("%s: expected validation error, got %v", tc.body, err)
+		}
+	}
+}
+
+func TestResourcesService_NilBody(t *testing.T) {
+	client := NewCommonClient()
+
+	if _, _, err := client.Resources.Create(context.Background(), nil); err != errNilBody {
+		t.Errorf("Expected: %v, got %v", errNilBody, err)
+	}
+
+	if _, _, err := client.Resources.Update(context.Background(), fakeResourceID, nil); err != errNilBody {
+		t.Errorf("Expected: %v, got %v", errNilBody, err)
+	}
+
+	if _, _, err := client.Rules.Create(context.Background(), fakeResourceID, nil); err != errNilBody {
+		t.Errorf("Expected: %v, got %v", errNilBody, err)
+	}
+
+	if _, _, err := client.Rules.Patch(context.Background(), fakeResourceID, 1, nil); err != errNilBody {
+		t.Errorf("Expected: %v, got %v", errNilBody, err)
+	}
+
+	if _, _, err := client.Resources.CreatePurge(context.Background(), fakeResourceID, nil); err != errNilBody {
+		t.Errorf("Expected: %v, got %v", errNilBody, err)
+	}
+
+	if _, err := client.Resources.PurgeBatch(context.Background(), fakeResourceID, nil); err != errNilBody {
+		t.Errorf("Expected: %v, got %v", errNilBody, err)
+	}
+}
+
+func TestRulesService_Create_Invalid(t *testing.T) {
+	endpointCalled := false
+
+	testEnv := th.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testEnv.Mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		endpointCalled = true
+	})
+
+	client := NewCommonClient()
+	client.BaseURL = testEnv.GetServerURL()
+
+	body := &CreateRuleBody{
+		Rule: "/images",
+		Options: Options{
+			IPAddressACL: &IPAddressACL{Enabled: true, PolicyType: PolicyTypeDeny, ExceptedValues: []string{"10.0.0.1"}},
+		},
+	}
+
+	_, _, err := client.Rules.Create(context.Background(), fakeResourceID, body)
+	if !IsValidation(err) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+
+	if endpointCalled {
+		t.Error("invalid request has been sent")
+	}
+}
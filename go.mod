@@ -1 +1,5 @@
 module github.com/dstdfx/go-gcore
+
+go 1.25.0
+
+require gopkg.in/yaml.v3 v3.0.1
@@ -1,4 +1,8 @@
 #!/bin/bash
 
 echo "==> Running 'gotest' ..."
-go test -covermode=count -coverprofile=coverage.out -v ./gcore/.
+go test -covermode=count -coverprofile=coverage.out -v ./gcore/... || exit 1
+
+for module in gcore/gcoreotel gcore/gcoreprom; do
+  (cd "${module}" && go test -v ./...) || exit 1
+done